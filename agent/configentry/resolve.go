@@ -141,6 +141,16 @@ func ComputeResolvedServiceConfig(
 		if serviceConf.LocalRequestTimeoutMs > 0 {
 			proxyConf["local_request_timeout_ms"] = serviceConf.LocalRequestTimeoutMs
 		}
+		if serviceConf.GRPCTranscoding != nil {
+			services := make([]interface{}, 0, len(serviceConf.GRPCTranscoding.Services))
+			for _, svc := range serviceConf.GRPCTranscoding.Services {
+				services = append(services, svc)
+			}
+			proxyConf["grpc_transcoding"] = map[string]interface{}{
+				"proto_descriptor": serviceConf.GRPCTranscoding.ProtoDescriptor,
+				"services":         services,
+			}
+		}
 		// Add the proxy conf to the response if any fields were populated
 		if len(proxyConf) > 0 {
 			thisReply.ProxyConfig = proxyConf
@@ -106,6 +106,34 @@ func Test_ComputeResolvedServiceConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "proxy with grpc_transcoding",
+			args: args{
+				scReq: &structs.ServiceConfigRequest{
+					Name: "sid",
+				},
+				entries: &ResolvedServiceConfigSet{
+					ServiceDefaults: map[structs.ServiceID]*structs.ServiceConfigEntry{
+						sid: {
+							Protocol: "grpc",
+							GRPCTranscoding: &structs.GRPCTranscodingConfig{
+								ProtoDescriptor: "/etc/envoy/bookstore.pb",
+								Services:        []string{"bookstore.Bookstore"},
+							},
+						},
+					},
+				},
+			},
+			want: &structs.ServiceConfigResponse{
+				ProxyConfig: map[string]interface{}{
+					"protocol": "grpc",
+					"grpc_transcoding": map[string]interface{}{
+						"proto_descriptor": "/etc/envoy/bookstore.pb",
+						"services":         []interface{}{"bookstore.Bookstore"},
+					},
+				},
+			},
+		},
 		{
 			name: "proxy upstream mesh-gateway inherits proxy-defaults",
 			args: args{
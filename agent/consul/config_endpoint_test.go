@@ -1626,11 +1626,13 @@ func TestConfigEntry_ResolveServiceConfig_Upstreams(t *testing.T) {
 						Upstream: wildcard,
 						Config: map[string]interface{}{
 							"passive_health_check": map[string]interface{}{
-								"Interval":                int64(10),
-								"MaxFailures":             int64(2),
-								"EnforcingConsecutive5xx": int64(60),
-								"MaxEjectionPercent":      int64(61),
-								"BaseEjectionTime":        uint64(62 * time.Second),
+								"Interval":                       int64(10),
+								"MaxFailures":                    int64(2),
+								"EnforcingConsecutive5xx":        int64(60),
+								"MaxEjectionPercent":             int64(61),
+								"BaseEjectionTime":               uint64(62 * time.Second),
+								"SplitExternalLocalOriginErrors": false,
+								"ConsecutiveLocalOriginFailure":  nil,
 							},
 							"mesh_gateway": map[string]interface{}{
 								"Mode": "none",
@@ -1642,11 +1644,13 @@ func TestConfigEntry_ResolveServiceConfig_Upstreams(t *testing.T) {
 						Upstream: mysql,
 						Config: map[string]interface{}{
 							"passive_health_check": map[string]interface{}{
-								"Interval":                int64(10),
-								"MaxFailures":             int64(2),
-								"EnforcingConsecutive5xx": int64(60),
-								"MaxEjectionPercent":      int64(61),
-								"BaseEjectionTime":        uint64(62 * time.Second),
+								"Interval":                       int64(10),
+								"MaxFailures":                    int64(2),
+								"EnforcingConsecutive5xx":        int64(60),
+								"MaxEjectionPercent":             int64(61),
+								"BaseEjectionTime":               uint64(62 * time.Second),
+								"SplitExternalLocalOriginErrors": false,
+								"ConsecutiveLocalOriginFailure":  nil,
 							},
 							"mesh_gateway": map[string]interface{}{
 								"Mode": "local",
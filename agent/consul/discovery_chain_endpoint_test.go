@@ -250,8 +250,9 @@ func TestDiscoveryChainEndpoint_Get(t *testing.T) {
 						Type: structs.DiscoveryGraphNodeTypeResolver,
 						Name: "web.default.default.dc1",
 						Resolver: &structs.DiscoveryResolver{
-							ConnectTimeout: 33 * time.Second,
-							Target:         "web.default.default.dc1",
+							ConnectTimeout:         33 * time.Second,
+							Target:                 "web.default.default.dc1",
+							ExplicitConnectTimeout: true,
 						},
 					},
 				},
@@ -614,9 +614,10 @@ func (c *compiler) assembleChain() error {
 	routerID := structs.NewServiceID(router.Name, router.GetEnterpriseMeta())
 
 	routeNode := &structs.DiscoveryGraphNode{
-		Type:   structs.DiscoveryGraphNodeTypeRouter,
-		Name:   serviceIDString(routerID),
-		Routes: make([]*structs.DiscoveryRoute, 0, len(router.Routes)+1),
+		Type:                 structs.DiscoveryGraphNodeTypeRouter,
+		Name:                 serviceIDString(routerID),
+		Routes:               make([]*structs.DiscoveryRoute, 0, len(router.Routes)+1),
+		VirtualHostRateLimit: router.VirtualHostRateLimit,
 	}
 	c.usesAdvancedRoutingFeatures = true
 	if err := c.recordServiceProtocol(routerID); err != nil {
@@ -980,6 +981,7 @@ RESOLVE_AGAIN:
 	}
 
 	connectTimeout := resolver.ConnectTimeout
+	explicitConnectTimeout := connectTimeout > 0
 	if connectTimeout < 1 {
 		connectTimeout = 5 * time.Second
 	}
@@ -989,6 +991,7 @@ RESOLVE_AGAIN:
 			connectTimeout = c.overrideConnectTimeout
 			c.customizedBy.ConnectTimeout = true
 		}
+		explicitConnectTimeout = true
 	}
 
 	// Expose a copy of this on the targets for ease of access.
@@ -1003,10 +1006,28 @@ RESOLVE_AGAIN:
 			Target:         target.ID,
 			ConnectTimeout: connectTimeout,
 			RequestTimeout: resolver.RequestTimeout,
+
+			ExplicitConnectTimeout:        explicitConnectTimeout,
+			ClusterFilterChainMetadata:    resolver.ClusterFilterChainMetadata,
+			PerConnectionBufferLimitBytes: resolver.PerConnectionBufferLimitBytes,
+			GRPCHealthCheck:               resolver.GRPCHealthCheck,
+			PassiveHealthCheck:            resolver.PassiveHealthCheck,
 		},
 		LoadBalancer: resolver.LoadBalancer,
 	}
 
+	// Hashing on a request header only works for protocols that have HTTP
+	// routes to carry the hash policy.
+	if lb := resolver.LoadBalancer; lb != nil && lb.RingHashConfig != nil && lb.RingHashConfig.HeaderName != "" &&
+		target.Peer == "" && !enableAdvancedRoutingForProtocol(c.protocol) {
+		return nil, &structs.ConfigEntryGraphError{
+			Message: fmt.Sprintf(
+				"service %q uses a protocol %q that does not support a RingHashConfig HeaderName",
+				target.Service, c.protocol,
+			),
+		}
+	}
+
 	proxyDefault := c.entries.GetProxyDefaults(targetID.PartitionOrDefault())
 
 	// Only set PrioritizeByLocality for targets in the same partition.
@@ -1022,6 +1043,11 @@ RESOLVE_AGAIN:
 
 	target.Subset = resolver.Subsets[target.ServiceSubset]
 
+	// Peered targets are validated against the SPIFFE IDs exported by the peer.
+	if target.Peer == "" {
+		target.CustomSANs = resolver.CustomSANs
+	}
+
 	if serviceDefault := c.entries.GetService(targetID); serviceDefault != nil && serviceDefault.ExternalSNI != "" {
 		// Override the default SNI value.
 		target.SNI = serviceDefault.ExternalSNI
@@ -1105,6 +1131,7 @@ RESOLVE_AGAIN:
 	// Determine which failover definitions apply.
 	var failoverTargets []*structs.DiscoveryTarget
 	var failoverPolicy *structs.ServiceResolverFailoverPolicy
+	var overprovisioningFactor uint32
 
 	if proxyDefault != nil {
 		failoverPolicy = proxyDefault.FailoverPolicy
@@ -1135,6 +1162,7 @@ RESOLVE_AGAIN:
 		if failover.Policy != nil {
 			failoverPolicy = failover.Policy
 		}
+		overprovisioningFactor = failover.OverprovisioningFactor
 
 		if len(failover.Datacenters) > 0 {
 			opts := failover.ToDiscoveryTargetOpts()
@@ -1177,6 +1205,7 @@ RESOLVE_AGAIN:
 		node.Resolver.Failover = df
 
 		df.Policy = failoverPolicy
+		df.OverprovisioningFactor = overprovisioningFactor
 
 		// Take care of doing any redirects or configuration loading
 		// related to targets by cheating a bit and recursing into
@@ -49,6 +49,7 @@ func TestCompile(t *testing.T) {
 		"redirect to cluster peer http service-defaults":   testcase_PeerRedirectSvcDefHTTP(),
 		"datacenter redirect with mesh gateways":           testcase_DatacenterRedirect_WithMeshGateways(),
 		"service failover":                                 testcase_ServiceFailover(),
+		"service failover with overprovisioning factor":    testcase_ServiceFailoverOverprovisioningFactor(),
 		"service failover through redirect":                testcase_ServiceFailoverThroughRedirect(),
 		"circular resolver failover":                       testcase_Resolver_CircularFailover(),
 		"service and subset failover":                      testcase_ServiceAndSubsetFailover(),
@@ -78,6 +79,7 @@ func TestCompile(t *testing.T) {
 		"failover crosses protocols":              testcase_FailoverCrossesProtocols(),
 		"redirect crosses protocols":              testcase_RedirectCrossesProtocols(),
 		"redirect to missing subset":              testcase_RedirectToMissingSubset(),
+		"ring hash header requires http protocol": testcase_RingHashHeaderRequiresHTTPProtocol(),
 		"resolver with failover and external sni": testcase_Resolver_ExternalSNI_FailoverNotAllowed(),
 		"resolver with subsets and external sni":  testcase_Resolver_ExternalSNI_SubsetsNotAllowed(),
 		"resolver with redirect and external sni": testcase_Resolver_ExternalSNI_RedirectNotAllowed(),
@@ -304,6 +306,8 @@ func testcase_RouterWithDefaults_NoSplit_WithResolver() compileTestCase {
 				Resolver: &structs.DiscoveryResolver{
 					ConnectTimeout: 33 * time.Second,
 					Target:         "main.default.default.dc1",
+
+					ExplicitConnectTimeout: true,
 				},
 			},
 		},
@@ -511,6 +515,8 @@ func testcase_RouterWithDefaults_WithNoopSplit_WithResolver() compileTestCase {
 				Resolver: &structs.DiscoveryResolver{
 					ConnectTimeout: 33 * time.Second,
 					Target:         "main.default.default.dc1",
+
+					ExplicitConnectTimeout: true,
 				},
 			},
 		},
@@ -710,6 +716,8 @@ func testcase_NoopSplit_WithResolver() compileTestCase {
 				Resolver: &structs.DiscoveryResolver{
 					ConnectTimeout: 33 * time.Second,
 					Target:         "main.default.default.dc1",
+
+					ExplicitConnectTimeout: true,
 				},
 			},
 		},
@@ -1319,6 +1327,46 @@ func testcase_ServiceFailover() compileTestCase {
 	return compileTestCase{entries: entries, expect: expect}
 }
 
+func testcase_ServiceFailoverOverprovisioningFactor() compileTestCase {
+	entries := newEntries()
+	entries.AddResolvers(
+		&structs.ServiceResolverConfigEntry{
+			Kind: "service-resolver",
+			Name: "main",
+			Failover: map[string]structs.ServiceResolverFailover{
+				"*": {
+					Service:                "backup",
+					OverprovisioningFactor: 200,
+				},
+			},
+		},
+	)
+
+	expect := &structs.CompiledDiscoveryChain{
+		Protocol:  "tcp",
+		StartNode: "resolver:main.default.default.dc1",
+		Nodes: map[string]*structs.DiscoveryGraphNode{
+			"resolver:main.default.default.dc1": {
+				Type: structs.DiscoveryGraphNodeTypeResolver,
+				Name: "main.default.default.dc1",
+				Resolver: &structs.DiscoveryResolver{
+					ConnectTimeout: 5 * time.Second,
+					Target:         "main.default.default.dc1",
+					Failover: &structs.DiscoveryFailover{
+						Targets:                []string{"backup.default.default.dc1"},
+						OverprovisioningFactor: 200,
+					},
+				},
+			},
+		},
+		Targets: map[string]*structs.DiscoveryTarget{
+			"main.default.default.dc1":   newTarget(structs.DiscoveryTargetOpts{Service: "main"}, nil),
+			"backup.default.default.dc1": newTarget(structs.DiscoveryTargetOpts{Service: "backup"}, nil),
+		},
+	}
+	return compileTestCase{entries: entries, expect: expect}
+}
+
 func testcase_ServiceFailoverThroughRedirect() compileTestCase {
 	entries := newEntries()
 	entries.AddResolvers(
@@ -2205,6 +2253,8 @@ func testcase_MultiDatacenterCanary() compileTestCase {
 				Resolver: &structs.DiscoveryResolver{
 					ConnectTimeout: 33 * time.Second,
 					Target:         "main.default.default.dc2",
+
+					ExplicitConnectTimeout: true,
 				},
 			},
 			"resolver:main.default.default.dc3": {
@@ -2213,6 +2263,8 @@ func testcase_MultiDatacenterCanary() compileTestCase {
 				Resolver: &structs.DiscoveryResolver{
 					ConnectTimeout: 33 * time.Second,
 					Target:         "main.default.default.dc3",
+
+					ExplicitConnectTimeout: true,
 				},
 			},
 		},
@@ -2669,6 +2721,28 @@ func testcase_RouteToUnroutableProtocol() compileTestCase {
 	}
 }
 
+func testcase_RingHashHeaderRequiresHTTPProtocol() compileTestCase {
+	entries := newEntries()
+	setServiceProtocol(entries, "main", "tcp")
+
+	entries.AddResolvers(&structs.ServiceResolverConfigEntry{
+		Kind: "service-resolver",
+		Name: "main",
+		LoadBalancer: &structs.LoadBalancer{
+			Policy: structs.LBPolicyRingHash,
+			RingHashConfig: &structs.RingHashConfig{
+				HeaderName: "x-user-id",
+			},
+		},
+	})
+
+	return compileTestCase{
+		entries:        entries,
+		expectErr:      `service "main" uses a protocol "tcp" that does not support a RingHashConfig HeaderName`,
+		expectGraphErr: true,
+	}
+}
+
 func testcase_FailoverCrossesProtocols() compileTestCase {
 	entries := newEntries()
 	setServiceProtocol(entries, "main", "grpc")
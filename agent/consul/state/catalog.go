@@ -3765,6 +3765,8 @@ func terminatingConfigGatewayServices(
 			SNI:             svc.SNI,
 			ServiceKind:     kind,
 			AutoHostRewrite: !svc.DisableAutoHostRewrite,
+
+			ProxyProtocolVersion: svc.ProxyProtocolVersion,
 		}
 
 		gatewayServices = append(gatewayServices, mapping)
@@ -5565,6 +5565,64 @@ func TestStateStore_GatewayServices_Terminating(t *testing.T) {
 	assert.Len(t, out, 0)
 }
 
+func TestStateStore_GatewayServices_TerminatingProxyProtocol(t *testing.T) {
+	s := testStateStore(t)
+
+	assert.Nil(t, s.EnsureNode(10, &structs.Node{Node: "foo", Address: "127.0.0.1"}))
+	assert.Nil(t, s.EnsureService(11, "foo", &structs.NodeService{ID: "db", Service: "db", Port: 5000}))
+	assert.Nil(t, s.EnsureService(12, "foo", &structs.NodeService{ID: "redis", Service: "redis", Port: 6379}))
+	assert.Nil(t, s.EnsureService(13, "foo", &structs.NodeService{Kind: structs.ServiceKindTerminatingGateway, ID: "gateway", Service: "gateway", Port: 443}))
+
+	// The version set on a linked service, directly or through a wildcard,
+	// is carried on its gateway service mapping.
+	assert.Nil(t, s.EnsureConfigEntry(14, &structs.TerminatingGatewayConfigEntry{
+		Kind: "terminating-gateway",
+		Name: "gateway",
+		Services: []structs.LinkedService{
+			{
+				Name:                 "db",
+				ProxyProtocolVersion: "v2",
+			},
+			{
+				Name:                 "*",
+				ProxyProtocolVersion: "v1",
+			},
+		},
+	}))
+
+	idx, out, err := s.GatewayServices(nil, "gateway", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, idx, uint64(14))
+
+	expect := structs.GatewayServices{
+		{
+			Service:     structs.NewServiceName("db", nil),
+			Gateway:     structs.NewServiceName("gateway", nil),
+			GatewayKind: structs.ServiceKindTerminatingGateway,
+			RaftIndex: structs.RaftIndex{
+				CreateIndex: 14,
+				ModifyIndex: 14,
+			},
+			ServiceKind:          structs.GatewayServiceKindService,
+			AutoHostRewrite:      true,
+			ProxyProtocolVersion: "v2",
+		},
+		{
+			Service:      structs.NewServiceName("redis", nil),
+			Gateway:      structs.NewServiceName("gateway", nil),
+			GatewayKind:  structs.ServiceKindTerminatingGateway,
+			FromWildcard: true,
+			RaftIndex: structs.RaftIndex{
+				CreateIndex: 14,
+				ModifyIndex: 14,
+			},
+			AutoHostRewrite:      true,
+			ProxyProtocolVersion: "v1",
+		},
+	}
+	assert.Equal(t, expect, out)
+}
+
 func TestStateStore_ServiceGateways_Terminating(t *testing.T) {
 	s := testStateStore(t)
 
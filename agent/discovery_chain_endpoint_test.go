@@ -267,8 +267,9 @@ func TestDiscoveryChainRead(t *testing.T) {
 						Type: structs.DiscoveryGraphNodeTypeResolver,
 						Name: "web.default.default.dc1",
 						Resolver: &structs.DiscoveryResolver{
-							ConnectTimeout: 33 * time.Second,
-							Target:         "web.default.default.dc1",
+							ConnectTimeout:         33 * time.Second,
+							Target:                 "web.default.default.dc1",
+							ExplicitConnectTimeout: true,
 							Failover: &structs.DiscoveryFailover{
 								Targets: []string{"web.default.default.dc2"},
 							},
@@ -323,8 +324,9 @@ func TestDiscoveryChainRead(t *testing.T) {
 				Type: structs.DiscoveryGraphNodeTypeResolver,
 				Name: "web.default.default.dc1",
 				Resolver: &structs.DiscoveryResolver{
-					ConnectTimeout: 22 * time.Second,
-					Target:         "web.default.default.dc1",
+					ConnectTimeout:         22 * time.Second,
+					Target:                 "web.default.default.dc1",
+					ExplicitConnectTimeout: true,
 					Failover: &structs.DiscoveryFailover{
 						Targets: []string{"web.default.default.dc2"},
 					},
@@ -77,6 +77,36 @@ func (o *ConfigSnapshot) DeepCopy() *ConfigSnapshot {
 										copy(cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.TrustedCA.InlineBytes, v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.TrustedCA.InlineBytes)
 									}
 								}
+								if v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate != nil {
+									cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate = new(structs.JWKSTLSCertData)
+									*cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate = *v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate
+									if v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate.InlineBytes != nil {
+										cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate.InlineBytes = make([]byte, len(v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate.InlineBytes))
+										copy(cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate.InlineBytes, v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.ClientCertificate.InlineBytes)
+									}
+								}
+								if v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey != nil {
+									cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey = new(structs.JWKSTLSCertData)
+									*cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey = *v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey
+									if v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey.InlineBytes != nil {
+										cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey.InlineBytes = make([]byte, len(v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey.InlineBytes))
+										copy(cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey.InlineBytes, v2.JSONWebKeySet.Remote.JWKSCluster.TLSCertificates.PrivateKey.InlineBytes)
+									}
+								}
+							}
+							if v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions != nil {
+								cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions = make([]structs.SocketOption, len(v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions))
+								copy(cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions, v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions)
+								for i11 := range v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions {
+									if v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions[i11].BufferValue != nil {
+										cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions[i11].BufferValue = make([]byte, len(v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions[i11].BufferValue))
+										copy(cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions[i11].BufferValue, v2.JSONWebKeySet.Remote.JWKSCluster.SocketOptions[i11].BufferValue)
+									}
+								}
+							}
+							if v2.JSONWebKeySet.Remote.JWKSCluster.DNSRefreshRate != nil {
+								cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.DNSRefreshRate = new(time.Duration)
+								*cp_JWTProviders_v2.JSONWebKeySet.Remote.JWKSCluster.DNSRefreshRate = *v2.JSONWebKeySet.Remote.JWKSCluster.DNSRefreshRate
 							}
 						}
 					}
@@ -571,6 +601,10 @@ func (o *configSnapshotIngressGateway) DeepCopy() *configSnapshotIngressGateway
 			cp.Defaults.PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
 			*cp.Defaults.PassiveHealthCheck.BaseEjectionTime = *o.Defaults.PassiveHealthCheck.BaseEjectionTime
 		}
+		if o.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+			cp.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+			*cp.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure
+		}
 	}
 	return &cp
 }
@@ -743,6 +777,10 @@ func (o *configSnapshotMeshGateway) DeepCopy() *configSnapshotMeshGateway {
 			cp.Limits.MaxConcurrentRequests = new(int)
 			*cp.Limits.MaxConcurrentRequests = *o.Limits.MaxConcurrentRequests
 		}
+		if o.Limits.MaxRetries != nil {
+			cp.Limits.MaxRetries = new(int)
+			*cp.Limits.MaxRetries = *o.Limits.MaxRetries
+		}
 	}
 	return &cp
 }
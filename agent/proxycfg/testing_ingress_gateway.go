@@ -853,6 +853,73 @@ func TestConfigSnapshotIngress_HTTPMultipleServices(t testing.T) *ConfigSnapshot
 	})
 }
 
+// TestConfigSnapshotIngress_WildcardListener returns an ingress gateway with a
+// single HTTP listener on port 80 for the "*" wildcard service, which the
+// gateway-services watch resolves to three services.
+func TestConfigSnapshotIngress_WildcardListener(t testing.T) *ConfigSnapshot {
+	entries := []structs.ConfigEntry{
+		&structs.ProxyConfigEntry{
+			Kind: structs.ProxyDefaults,
+			Name: structs.ProxyConfigGlobal,
+			Config: map[string]interface{}{
+				"protocol": "http",
+			},
+		},
+	}
+
+	set := configentry.NewDiscoveryChainSet()
+	set.AddEntries(entries...)
+
+	var (
+		events   []UpdateEvent
+		services []*structs.GatewayService
+	)
+	for _, name := range []string{"foo", "bar", "baz"} {
+		sn := structs.NewServiceName(name, nil)
+		uid := NewUpstreamIDFromServiceName(sn)
+		chain := discoverychain.TestCompileConfigEntries(t, name, "default", "default", "dc1", connect.TestClusterID+".consul", nil, set)
+
+		services = append(services, &structs.GatewayService{
+			Service:  sn,
+			Port:     80,
+			Protocol: "http",
+		})
+		events = append(events,
+			UpdateEvent{
+				CorrelationID: "discovery-chain:" + uid.String(),
+				Result: &structs.DiscoveryChainResponse{
+					Chain: chain,
+				},
+			},
+			UpdateEvent{
+				CorrelationID: "upstream-target:" + chain.ID() + ":" + uid.String(),
+				Result: &structs.IndexedCheckServiceNodes{
+					Nodes: TestUpstreamNodes(t, name),
+				},
+			},
+		)
+	}
+
+	return TestConfigSnapshotIngressGateway(t, false, "http", "default", nil, func(entry *structs.IngressGatewayConfigEntry) {
+		entry.Listeners = []structs.IngressListener{
+			{
+				Port:     80,
+				Protocol: "http",
+				Services: []structs.IngressService{
+					{Name: structs.WildcardSpecifier},
+				},
+			},
+		}
+	}, append([]UpdateEvent{
+		{
+			CorrelationID: gatewayServicesWatchID,
+			Result: &structs.IndexedGatewayServices{
+				Services: services,
+			},
+		},
+	}, events...))
+}
+
 func TestConfigSnapshotIngress_GRPCMultipleServices(t testing.T) *ConfigSnapshot {
 	// We do not add baz/qux here so that we test the chain.IsDefault() case
 	entries := []structs.ConfigEntry{
@@ -1040,6 +1107,63 @@ func TestConfigSnapshotIngress_MultipleListenersDuplicateService(t testing.T) *C
 	})
 }
 
+func TestConfigSnapshotIngress_MultipleListenersSharedUpstream(t testing.T) *ConfigSnapshot {
+	var (
+		foo      = structs.NewServiceName("foo", nil)
+		fooUID   = NewUpstreamIDFromServiceName(foo)
+		fooChain = discoverychain.TestCompileConfigEntries(t, "foo", "default", "default", "dc1", connect.TestClusterID+".consul", nil, nil)
+	)
+
+	return TestConfigSnapshotIngressGateway(t, false, "tcp", "default", nil, func(entry *structs.IngressGatewayConfigEntry) {
+		entry.Listeners = []structs.IngressListener{
+			{
+				Port:     8080,
+				Protocol: "tcp",
+				Services: []structs.IngressService{
+					{Name: "foo"},
+				},
+			},
+			{
+				Port:     9090,
+				Protocol: "tcp",
+				Services: []structs.IngressService{
+					{Name: "foo"},
+				},
+			},
+		}
+	}, []UpdateEvent{
+		{
+			CorrelationID: gatewayServicesWatchID,
+			Result: &structs.IndexedGatewayServices{
+				Services: []*structs.GatewayService{
+					{
+						Service:  foo,
+						Port:     8080,
+						Protocol: "tcp",
+					},
+					{
+						Service:  foo,
+						Port:     9090,
+						Protocol: "tcp",
+					},
+				},
+			},
+		},
+		{
+			CorrelationID: "discovery-chain:" + fooUID.String(),
+			Result: &structs.DiscoveryChainResponse{
+				Chain: fooChain,
+			},
+		},
+		{
+			CorrelationID: "upstream-target:" + fooChain.ID() + ":" + fooUID.String(),
+			Result: &structs.IndexedCheckServiceNodes{
+				Nodes: TestUpstreamNodes(t, "foo"),
+			},
+		},
+	})
+}
+
 func TestConfigSnapshotIngressGatewayWithChain(
 	t testing.T,
 	variant string,
@@ -109,6 +109,33 @@ func TestConfigSnapshotMeshGateway(t testing.T, variant string, nsFn func(ns *st
 				},
 			},
 		})
+	case "empty-service-subset", "empty-service-subset-fallback":
+		var emptySubsetAction string
+		if variant == "empty-service-subset-fallback" {
+			emptySubsetAction = structs.EmptySubsetActionFallbackToDefault
+		}
+		extraUpdates = append(extraUpdates, UpdateEvent{
+			CorrelationID: serviceResolversWatchID,
+			Result: &structs.IndexedConfigEntries{
+				Kind: structs.ServiceResolver,
+				Entries: []structs.ConfigEntry{
+					&structs.ServiceResolverConfigEntry{
+						Kind:              structs.ServiceResolver,
+						Name:              "bar",
+						EmptySubsetAction: emptySubsetAction,
+						Subsets: map[string]structs.ServiceResolverSubset{
+							"v1": {
+								Filter: "Service.Meta.version == 1",
+							},
+							"v3": {
+								// No instances of bar have this version.
+								Filter: "Service.Meta.version == 3",
+							},
+						},
+					},
+				},
+			},
+		})
 	case "default-service-subsets2": // TODO(rb): rename to strip the 2 when the prior is merged with 'service-subsets'
 		extraUpdates = append(extraUpdates, UpdateEvent{
 			CorrelationID: serviceResolversWatchID,
@@ -15,18 +15,47 @@ import (
 )
 
 func TestConfigSnapshotPeering(t testing.T) *ConfigSnapshot {
-	return testConfigSnapshot(t, false, false, nil)
+	return testConfigSnapshot(t, testConfigSnapshotPeeringOpts{})
 }
 
 func TestConfigSnapshotPeeringWithEscapeOverrides(t testing.T) *ConfigSnapshot {
-	return testConfigSnapshot(t, true, false, nil)
+	return testConfigSnapshot(t, testConfigSnapshotPeeringOpts{escapeOverride: true})
 }
 
 func TestConfigSnapshotPeeringWithHTTP2(t testing.T, nsFn func(ns *structs.NodeService)) *ConfigSnapshot {
-	return testConfigSnapshot(t, false, true, nsFn)
+	return testConfigSnapshot(t, testConfigSnapshotPeeringOpts{useHTTP2: true, nsFn: nsFn})
 }
 
-func testConfigSnapshot(t testing.T, escapeOverride bool, useHTTP2 bool, nsFn func(ns *structs.NodeService)) *ConfigSnapshot {
+// TestConfigSnapshotPeeringWithServiceAccount returns a snapshot whose peered
+// payments upstream has a Kubernetes-style service account SPIFFE URI rather
+// than one derived from the upstream's service name.
+func TestConfigSnapshotPeeringWithServiceAccount(t testing.T) *ConfigSnapshot {
+	return testConfigSnapshot(t, testConfigSnapshotPeeringOpts{
+		paymentsSpiffeID: "spiffe://1c053652-8512-4373-90cf-5a7f6263a994.consul/ns/payments/sa/payments-api",
+	})
+}
+
+// TestConfigSnapshotPeeringTrustBundlePending returns a snapshot whose peered
+// upstreams have endpoints but whose peer trust bundle has not been fetched
+// yet.
+func TestConfigSnapshotPeeringTrustBundlePending(t testing.T) *ConfigSnapshot {
+	return testConfigSnapshot(t, testConfigSnapshotPeeringOpts{trustBundlePending: true})
+}
+
+type testConfigSnapshotPeeringOpts struct {
+	escapeOverride bool
+	useHTTP2       bool
+
+	// paymentsSpiffeID replaces the SPIFFE ID of the payments upstream.
+	paymentsSpiffeID string
+
+	// trustBundlePending leaves the peer trust bundle unset.
+	trustBundlePending bool
+
+	nsFn func(ns *structs.NodeService)
+}
+
+func testConfigSnapshot(t testing.T, opts testConfigSnapshotPeeringOpts) *ConfigSnapshot {
 	var (
 		paymentsUpstream = structs.Upstream{
 			DestinationName: "payments",
@@ -44,19 +73,29 @@ func testConfigSnapshot(t testing.T, escapeOverride bool, useHTTP2 bool, nsFn fu
 	)
 
 	protocol := "tcp"
-	if useHTTP2 {
+	if opts.useHTTP2 {
 		protocol = "http2"
 	}
 
 	const peerTrustDomain = "1c053652-8512-4373-90cf-5a7f6263a994.consul"
 
+	paymentsSpiffeID := "spiffe://" + peerTrustDomain + "/ns/default/dc/cloud-dc/svc/payments"
+	if opts.paymentsSpiffeID != "" {
+		paymentsSpiffeID = opts.paymentsSpiffeID
+	}
+
+	trustBundle := TestPeerTrustBundles(t).Bundles[0]
+	if opts.trustBundlePending {
+		trustBundle = nil
+	}
+
 	return TestConfigSnapshot(t, func(ns *structs.NodeService) {
 		ns.Proxy.Upstreams = structs.Upstreams{
 			paymentsUpstream,
 			refundsUpstream,
 		}
 
-		if escapeOverride {
+		if opts.escapeOverride {
 			if ns.Proxy.Upstreams[0].Config == nil {
 				ns.Proxy.Upstreams[0].Config = map[string]interface{}{}
 			}
@@ -72,14 +111,14 @@ func testConfigSnapshot(t testing.T, escapeOverride bool, useHTTP2 bool, nsFn fu
 					Name: uid.EnvoyID() + ":custom-upstream",
 				})
 		}
-		if nsFn != nil {
-			nsFn(ns)
+		if opts.nsFn != nil {
+			opts.nsFn(ns)
 		}
 	}, []UpdateEvent{
 		{
 			CorrelationID: peerTrustBundleIDPrefix + "cloud",
 			Result: &pbpeering.TrustBundleReadResponse{
-				Bundle: TestPeerTrustBundles(t).Bundles[0],
+				Bundle: trustBundle,
 			},
 		},
 		{
@@ -111,7 +150,7 @@ func testConfigSnapshot(t testing.T, escapeOverride bool, useHTTP2 bool, nsFn fu
 										"payments.default.default.cloud.external." + peerTrustDomain,
 									},
 									SpiffeID: []string{
-										"spiffe://" + peerTrustDomain + "/ns/default/dc/cloud-dc/svc/payments",
+										paymentsSpiffeID,
 									},
 									Protocol: protocol,
 								},
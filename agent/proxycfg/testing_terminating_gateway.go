@@ -643,6 +643,9 @@ func TestConfigSnapshotTerminatingGatewayLBConfig(t testing.T) *ConfigSnapshot {
 func TestConfigSnapshotTerminatingGatewayLBConfigNoHashPolicies(t testing.T) *ConfigSnapshot {
 	return testConfigSnapshotTerminatingGatewayLBConfig(t, "no-hash-policies")
 }
+func TestConfigSnapshotTerminatingGatewayLBConfigClusterProvided(t testing.T) *ConfigSnapshot {
+	return testConfigSnapshotTerminatingGatewayLBConfig(t, "cluster-provided")
+}
 func testConfigSnapshotTerminatingGatewayLBConfig(t testing.T, variant string) *ConfigSnapshot {
 	web := structs.NewServiceName("web", nil)
 
@@ -688,6 +691,11 @@ func testConfigSnapshotTerminatingGatewayLBConfig(t testing.T, variant string) *
 	case "default":
 	case "no-hash-policies":
 		entry.LoadBalancer.HashPolicies = nil
+	case "cluster-provided":
+		entry.LoadBalancer = &structs.LoadBalancer{
+			Policy:            structs.LBPolicyClusterProvided,
+			CustomClusterType: "envoy.clusters.redis",
+		}
 	default:
 		t.Fatalf("unknown variant %q", variant)
 		return nil
@@ -741,6 +749,30 @@ func TestConfigSnapshotTerminatingGatewaySNI(t testing.T) *ConfigSnapshot {
 	})
 }
 
+func TestConfigSnapshotTerminatingGatewayProxyProtocol(t testing.T) *ConfigSnapshot {
+	return TestConfigSnapshotTerminatingGateway(t, true, nil, []UpdateEvent{
+		{
+			CorrelationID: "gateway-services",
+			Result: &structs.IndexedGatewayServices{
+				Services: []*structs.GatewayService{
+					{
+						Service:              structs.NewServiceName("web", nil),
+						CAFile:               "ca.cert.pem",
+						SNI:                  "foo.com",
+						AutoHostRewrite:      true,
+						ProxyProtocolVersion: "v2",
+					},
+					{
+						Service:              structs.NewServiceName("api", nil),
+						AutoHostRewrite:      true,
+						ProxyProtocolVersion: "v1",
+					},
+				},
+			},
+		},
+	})
+}
+
 func TestConfigSnapshotTerminatingGatewayHTTP2(t testing.T) *ConfigSnapshot {
 	web := structs.NewServiceName("web", nil)
 
@@ -37,6 +37,14 @@ func setupTestVariationConfigEntriesAndSnapshot(
 			node.Service.Kind = structs.ServiceKindTerminatingGateway
 		}
 	}
+	if variation == "geo-locality" {
+		for i, zone := range []string{"us-east-1a", "us-east-1b"} {
+			nodes[i].Service.Meta = map[string]string{
+				structs.MetaGeoRegion: "us-east-1",
+				structs.MetaGeoZone:   zone,
+			}
+		}
+	}
 	events := []UpdateEvent{
 		{
 			CorrelationID: "discovery-chain:" + dbUID.String(),
@@ -66,10 +74,11 @@ func setupTestVariationConfigEntriesAndSnapshot(
 
 	switch variation {
 	case "default":
+	case "geo-locality":
 	case "simple-with-overrides":
 	case "simple":
 	case "external-sni":
-	case "failover":
+	case "failover", "failover-with-overprovisioning-factor":
 		chainID := makeChainID(structs.DiscoveryTargetOpts{Service: "fail"})
 		events = append(events, UpdateEvent{
 			CorrelationID: "upstream-target:" + chainID + ":" + dbUID.String(),
@@ -255,6 +264,7 @@ func setupTestVariationConfigEntriesAndSnapshot(
 	case "grpc-router":
 	case "chain-and-router":
 	case "lb-resolver":
+	case "lb-resolver-ring-hash-header":
 	case "register-to-terminating-gateway":
 	case "redirect-to-lb-node":
 	case "resolver-with-lb":
@@ -282,7 +292,7 @@ func setupTestVariationDiscoveryChain(
 	)
 
 	switch variation {
-	case "default":
+	case "default", "geo-locality":
 		// no config entries
 	case "register-to-terminating-gateway":
 	case "simple-with-overrides":
@@ -333,6 +343,22 @@ func setupTestVariationDiscoveryChain(
 				},
 			},
 		)
+	case "failover-with-overprovisioning-factor":
+		entries = append(entries,
+			&structs.ServiceResolverConfigEntry{
+				Kind:           structs.ServiceResolver,
+				Name:           "db",
+				EnterpriseMeta: entMeta,
+				ConnectTimeout: 33 * time.Second,
+				RequestTimeout: 33 * time.Second,
+				Failover: map[string]structs.ServiceResolverFailover{
+					"*": {
+						Service:                "fail",
+						OverprovisioningFactor: 200,
+					},
+				},
+			},
+		)
 	case "failover-through-remote-gateway-triggered":
 		fallthrough
 	case "failover-through-remote-gateway":
@@ -1036,6 +1062,29 @@ func setupTestVariationDiscoveryChain(
 					},
 				},
 			})
+	case "lb-resolver-ring-hash-header":
+		entries = append(entries,
+			&structs.ProxyConfigEntry{
+				Kind:           structs.ProxyDefaults,
+				Name:           structs.ProxyConfigGlobal,
+				EnterpriseMeta: entMeta,
+				Protocol:       "http",
+				Config: map[string]interface{}{
+					"protocol": "http",
+				},
+			},
+			&structs.ServiceResolverConfigEntry{
+				Kind:           structs.ServiceResolver,
+				Name:           "db",
+				EnterpriseMeta: entMeta,
+				LoadBalancer: &structs.LoadBalancer{
+					Policy: "ring_hash",
+					RingHashConfig: &structs.RingHashConfig{
+						HeaderName: "x-session-id",
+					},
+				},
+			},
+		)
 	case "redirect-to-lb-node":
 		entries = append(entries,
 			&structs.ProxyConfigEntry{
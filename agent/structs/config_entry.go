@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -54,6 +55,16 @@ const (
 	DefaultServiceProtocol = "tcp"
 
 	ConnectionExactBalance = "exact_balance"
+
+	UpstreamHeaderKeyFormatDefault         = "default"
+	UpstreamHeaderKeyFormatProperCaseWords = "proper_case_words"
+
+	UpstreamProxyProtocolV1 = "v1"
+	UpstreamProxyProtocolV2 = "v2"
+
+	ActiveHealthCheckTypeHTTP = "http"
+	ActiveHealthCheckTypeTCP  = "tcp"
+	ActiveHealthCheckTypeGRPC = "grpc"
 )
 
 var AllConfigEntryKinds = []string{
@@ -178,6 +189,7 @@ type ServiceConfigEntry struct {
 	LocalRequestTimeoutMs     int                    `json:",omitempty" alias:"local_request_timeout_ms"`
 	BalanceInboundConnections string                 `json:",omitempty" alias:"balance_inbound_connections"`
 	RateLimits                *RateLimits            `json:",omitempty" alias:"rate_limits"`
+	GRPCTranscoding           *GRPCTranscodingConfig `json:",omitempty" alias:"grpc_transcoding"`
 	EnvoyExtensions           EnvoyExtensions        `json:",omitempty" alias:"envoy_extensions"`
 
 	Meta               map[string]string `json:",omitempty"`
@@ -317,6 +329,15 @@ func (e *ServiceConfigEntry) Validate() error {
 		validationErr = multierror.Append(validationErr, err)
 	}
 
+	if e.GRPCTranscoding != nil {
+		if e.Protocol != "" && e.Protocol != "grpc" {
+			validationErr = multierror.Append(validationErr, fmt.Errorf("grpc_transcoding requires protocol \"grpc\", got %q", e.Protocol))
+		}
+		if err := e.GRPCTranscoding.Validate(); err != nil {
+			validationErr = multierror.Append(validationErr, fmt.Errorf("invalid grpc_transcoding: %w", err))
+		}
+	}
+
 	if err := envoyextensions.ValidateExtensions(e.EnvoyExtensions.ToAPI()); err != nil {
 		validationErr = multierror.Append(validationErr, err)
 	}
@@ -418,6 +439,34 @@ func IsIP(address string) bool {
 	return ip != nil
 }
 
+// GRPCTranscodingConfig lets a gRPC service accept HTTP/1.1 JSON requests. The
+// service's sidecar transcodes them to gRPC before they reach the application.
+type GRPCTranscodingConfig struct {
+	// ProtoDescriptor is the path, on the proxy's host, of a binary
+	// FileDescriptorSet describing Services and their google.api.http
+	// annotations.
+	ProtoDescriptor string `json:",omitempty" alias:"proto_descriptor"`
+
+	// Services are the fully qualified names of the gRPC services to
+	// transcode, such as "bookstore.Bookstore".
+	Services []string `json:",omitempty"`
+}
+
+func (c *GRPCTranscodingConfig) Validate() error {
+	if c.ProtoDescriptor == "" {
+		return errors.New("proto_descriptor is required")
+	}
+	if len(c.Services) == 0 {
+		return errors.New("at least one service is required")
+	}
+	for _, svc := range c.Services {
+		if svc == "" {
+			return errors.New("service names must not be empty")
+		}
+	}
+	return nil
+}
+
 // RateLimits is rate limiting configuration that is applied to
 // inbound traffic for a service.
 // Rate limiting is a Consul enterprise feature.
@@ -1013,12 +1062,150 @@ type UpstreamConfig struct {
 	// be monitored for removal from the load balancing pool.
 	PassiveHealthCheck *PassiveHealthCheck `json:",omitempty" alias:"passive_health_check"`
 
+	// ActiveHealthCheck configures the proxy to probe upstream proxy instances
+	// itself and remove the ones that fail from the load balancing pool.
+	ActiveHealthCheck *ActiveHealthCheck `json:",omitempty" alias:"active_health_check"`
+
 	// MeshGatewayConfig controls how Mesh Gateways are configured and used
 	MeshGateway MeshGatewayConfig `json:",omitempty" alias:"mesh_gateway" `
 
 	// BalanceOutboundConnections indicates how the proxy should attempt to distribute
 	// connections across worker threads. Only used by envoy proxies.
 	BalanceOutboundConnections string `json:",omitempty" alias:"balance_outbound_connections"`
+
+	// MaxHeaderCount limits the number of headers the proxy accepts on HTTP
+	// responses from this upstream. Only used by envoy proxies and only for
+	// HTTP-like protocols. If not set, Envoy's default of 100 applies.
+	MaxHeaderCount int `json:",omitempty" alias:"envoy_upstream_max_header_count"`
+
+	// ClusterWarmUpDurationMs is the number of milliseconds over which traffic
+	// to a newly added upstream endpoint is gradually increased. Only used by
+	// envoy proxies with the round_robin or least_request load balancer policies.
+	// This is distinct from waiting for the cluster to warm on initialization.
+	ClusterWarmUpDurationMs int `json:",omitempty" alias:"envoy_cluster_warm_up_duration_ms"`
+
+	// WarmupTimeout is how long a new cluster for this upstream waits for its
+	// endpoints before the proxy considers it warm. It replaces the proxy-wide
+	// xds_fetch_timeout_ms for this upstream, and zero waits indefinitely.
+	// Only used by envoy proxies.
+	WarmupTimeout *time.Duration `json:",omitempty" alias:"envoy_cluster_warmup_timeout"`
+
+	// WaitForWarmOnInit controls whether the proxy waits for the clusters of
+	// this upstream to warm before it finishes initializing. Setting it to
+	// false lets latency-sensitive proxies start serving before the upstream's
+	// endpoints are known. Only used by envoy proxies.
+	WaitForWarmOnInit *bool `json:",omitempty" alias:"envoy_cluster_wait_for_warm_on_init"`
+
+	// HTTP2InitialStreamWindowSize is the initial HTTP/2 flow control window
+	// size, in bytes, for each stream to this upstream. Only used by envoy
+	// proxies and only for the http2 and grpc protocols.
+	HTTP2InitialStreamWindowSize int `json:",omitempty" alias:"envoy_upstream_http2_initial_stream_window_size"`
+
+	// HTTP2InitialConnectionWindowSize is the initial HTTP/2 flow control
+	// window size, in bytes, for each connection to this upstream. Only used by
+	// envoy proxies and only for the http2 and grpc protocols.
+	HTTP2InitialConnectionWindowSize int `json:",omitempty" alias:"envoy_upstream_http2_initial_connection_window_size"`
+
+	// HTTP2OverrideStreamError makes the proxy reset only the offending stream,
+	// rather than the whole connection, when this upstream sends a malformed
+	// HTTP/2 message. Only used by envoy proxies and only for the http2 and
+	// grpc protocols.
+	HTTP2OverrideStreamError bool `json:",omitempty" alias:"envoy_upstream_override_stream_error"`
+
+	// HTTPVersionAuto makes the proxy negotiate HTTP/1.1 or HTTP/2 with this
+	// upstream using ALPN, instead of always using the version implied by the
+	// protocol. Unless ALPN protocols are configured, h2 and http/1.1 are
+	// offered. Only used by envoy proxies and only for the http and http2
+	// protocols.
+	HTTPVersionAuto bool `json:",omitempty" alias:"envoy_upstream_http_version_auto"`
+
+	// MaxStreamDurationMs is the maximum number of milliseconds a single
+	// request or stream to this upstream may last before it is reset. Only used
+	// by envoy proxies and only for HTTP-like protocols.
+	MaxStreamDurationMs int `json:",omitempty" alias:"envoy_upstream_max_stream_duration_ms"`
+
+	// IdleTimeout is how long a connection to this upstream may have no
+	// active requests before the proxy closes it. Zero leaves the proxy's
+	// default in place. Only used by envoy proxies and only for HTTP-like
+	// protocols.
+	IdleTimeout *time.Duration `json:",omitempty" alias:"envoy_upstream_idle_timeout"`
+
+	// MaxRequestsPerConnection is the number of requests the proxy sends on
+	// a single connection to this upstream before closing it, so that long
+	// lived connections are recycled. Only used by envoy proxies and only for
+	// HTTP-like protocols.
+	MaxRequestsPerConnection *uint32 `json:",omitempty" alias:"envoy_upstream_max_requests_per_connection"`
+
+	// LocalAddress is the source IP address the proxy binds to when opening
+	// connections to this upstream, for hosts with multiple network
+	// interfaces. Only used by envoy proxies.
+	LocalAddress string `json:",omitempty" alias:"envoy_upstream_local_address"`
+
+	// LocalPort is the source port the proxy binds to when opening connections
+	// to this upstream. It requires LocalAddress and defaults to an ephemeral
+	// port. Only used by envoy proxies.
+	LocalPort int `json:",omitempty" alias:"envoy_upstream_local_port"`
+
+	// ExtraSourceAddresses are further source addresses the proxy may bind
+	// to alongside LocalAddress. Envoy picks the one whose IP family matches
+	// the upstream host, so one address of the other family can be given
+	// for dual-stack upstreams. It requires LocalAddress. Only used by envoy
+	// proxies.
+	ExtraSourceAddresses []UpstreamSourceAddress `json:",omitempty" alias:"envoy_upstream_extra_source_addresses"`
+
+	// TCPFastOpen enables TCP Fast Open on connections to this upstream so
+	// that data can be sent in the SYN packet. It requires Envoy to run on
+	// Linux with TCP Fast Open enabled in the kernel. Only used by envoy
+	// proxies.
+	TCPFastOpen bool `json:",omitempty" alias:"envoy_upstream_tcp_fast_open"`
+
+	// HeaderKeyFormat controls the casing of header names the proxy sends to
+	// this upstream over HTTP/1.1. Set it to "proper_case_words" for upstreams
+	// that require canonical casing such as Content-Type. Only used by envoy
+	// proxies and only for the http protocol.
+	HeaderKeyFormat string `json:",omitempty" alias:"envoy_upstream_header_key_format"`
+
+	// TCPKeepalive enables TCP keepalives on connections to this upstream. It
+	// replaces the mesh-wide TCPKeepalive from the mesh config entry. Only
+	// used by envoy proxies.
+	TCPKeepalive *TCPKeepaliveConfig `json:",omitempty" alias:"envoy_upstream_tcp_keepalive"`
+
+	// PerDownstreamConnectionPool gives each downstream connection its own
+	// pool of connections to this upstream instead of sharing one pool, so
+	// that a slow HTTP/2 connection can't block requests from unrelated
+	// downstreams. Only used by envoy proxies.
+	PerDownstreamConnectionPool bool `json:",omitempty" alias:"envoy_upstream_per_downstream_connection_pool"`
+
+	// ClusterStatsPrefix replaces the cluster name in the stats envoy emits
+	// for the clusters of this upstream. It must not contain dots, which
+	// envoy uses to separate the levels of a stat name. The clusters of
+	// failover targets append their position in the failover order, such as
+	// "_1". Only used by envoy proxies.
+	ClusterStatsPrefix string `json:",omitempty" alias:"envoy_cluster_stats_prefix"`
+
+	// ALPNProtocols replaces the protocols advertised with ALPN on mTLS
+	// connections to this upstream, in order of preference. It takes
+	// precedence over the outgoing ALPNProtocols of the mesh config entry.
+	// Only used by envoy proxies.
+	ALPNProtocols []string `json:",omitempty" alias:"envoy_upstream_alpn_protocols"`
+
+	// SNI replaces the server name sent in the TLS handshake with this
+	// upstream, which otherwise is derived from the upstream's cluster name.
+	// Mesh gateways route on the server name, so it is ignored for targets
+	// reached through one. Only used by envoy proxies.
+	SNI string `json:",omitempty" alias:"envoy_upstream_sni"`
+
+	// ClusterMetadata is attached to the clusters of this upstream as filter
+	// metadata under the "envoy.lb" namespace, where access logs and envoy
+	// extensions can read it. Subset load balancing matches endpoint metadata,
+	// so it does not use these values. Only used by envoy proxies.
+	ClusterMetadata map[string]string `json:",omitempty" alias:"envoy_cluster_metadata"`
+
+	// RetryPolicy sets how the proxy retries requests to this upstream. It
+	// applies to every route to the upstream except those whose
+	// service-router destination configures its own retries. Only used by
+	// envoy proxies and only for HTTP-like protocols.
+	RetryPolicy *UpstreamRetryPolicy `json:",omitempty" alias:"envoy_upstream_retry_policy"`
 }
 
 func (cfg UpstreamConfig) Clone() UpstreamConfig {
@@ -1026,6 +1213,37 @@ func (cfg UpstreamConfig) Clone() UpstreamConfig {
 
 	cfg2.Limits = cfg.Limits.Clone()
 	cfg2.PassiveHealthCheck = cfg.PassiveHealthCheck.Clone()
+	cfg2.ActiveHealthCheck = cfg.ActiveHealthCheck.Clone()
+	cfg2.TCPKeepalive = cfg.TCPKeepalive.Clone()
+	if cfg.WarmupTimeout != nil {
+		warmupTimeout := *cfg.WarmupTimeout
+		cfg2.WarmupTimeout = &warmupTimeout
+	}
+	if cfg.WaitForWarmOnInit != nil {
+		waitForWarm := *cfg.WaitForWarmOnInit
+		cfg2.WaitForWarmOnInit = &waitForWarm
+	}
+	if cfg.IdleTimeout != nil {
+		idleTimeout := *cfg.IdleTimeout
+		cfg2.IdleTimeout = &idleTimeout
+	}
+	if cfg.MaxRequestsPerConnection != nil {
+		maxRequests := *cfg.MaxRequestsPerConnection
+		cfg2.MaxRequestsPerConnection = &maxRequests
+	}
+	if cfg.ALPNProtocols != nil {
+		cfg2.ALPNProtocols = append([]string(nil), cfg.ALPNProtocols...)
+	}
+	if cfg.ExtraSourceAddresses != nil {
+		cfg2.ExtraSourceAddresses = append([]UpstreamSourceAddress(nil), cfg.ExtraSourceAddresses...)
+	}
+	cfg2.RetryPolicy = cfg.RetryPolicy.Clone()
+	if cfg.ClusterMetadata != nil {
+		cfg2.ClusterMetadata = make(map[string]string, len(cfg.ClusterMetadata))
+		for k, v := range cfg.ClusterMetadata {
+			cfg2.ClusterMetadata[k] = v
+		}
+	}
 
 	return cfg2
 }
@@ -1063,9 +1281,81 @@ func (cfg UpstreamConfig) MergeInto(dst map[string]interface{}) {
 	if cfg.PassiveHealthCheck != nil {
 		dst["passive_health_check"] = cfg.PassiveHealthCheck
 	}
+	if cfg.ActiveHealthCheck != nil {
+		dst["active_health_check"] = cfg.ActiveHealthCheck
+	}
 	if cfg.BalanceOutboundConnections != "" {
 		dst["balance_outbound_connections"] = cfg.BalanceOutboundConnections
 	}
+	if cfg.MaxHeaderCount != 0 {
+		dst["envoy_upstream_max_header_count"] = cfg.MaxHeaderCount
+	}
+	if cfg.ClusterWarmUpDurationMs != 0 {
+		dst["envoy_cluster_warm_up_duration_ms"] = cfg.ClusterWarmUpDurationMs
+	}
+	if cfg.WarmupTimeout != nil {
+		dst["envoy_cluster_warmup_timeout"] = *cfg.WarmupTimeout
+	}
+	if cfg.WaitForWarmOnInit != nil {
+		dst["envoy_cluster_wait_for_warm_on_init"] = *cfg.WaitForWarmOnInit
+	}
+	if cfg.HTTP2InitialStreamWindowSize != 0 {
+		dst["envoy_upstream_http2_initial_stream_window_size"] = cfg.HTTP2InitialStreamWindowSize
+	}
+	if cfg.HTTP2InitialConnectionWindowSize != 0 {
+		dst["envoy_upstream_http2_initial_connection_window_size"] = cfg.HTTP2InitialConnectionWindowSize
+	}
+	if cfg.HTTP2OverrideStreamError {
+		dst["envoy_upstream_override_stream_error"] = cfg.HTTP2OverrideStreamError
+	}
+	if cfg.HTTPVersionAuto {
+		dst["envoy_upstream_http_version_auto"] = cfg.HTTPVersionAuto
+	}
+	if cfg.MaxStreamDurationMs != 0 {
+		dst["envoy_upstream_max_stream_duration_ms"] = cfg.MaxStreamDurationMs
+	}
+	if cfg.IdleTimeout != nil {
+		dst["envoy_upstream_idle_timeout"] = *cfg.IdleTimeout
+	}
+	if cfg.MaxRequestsPerConnection != nil {
+		dst["envoy_upstream_max_requests_per_connection"] = *cfg.MaxRequestsPerConnection
+	}
+	if cfg.LocalAddress != "" {
+		dst["envoy_upstream_local_address"] = cfg.LocalAddress
+	}
+	if cfg.LocalPort != 0 {
+		dst["envoy_upstream_local_port"] = cfg.LocalPort
+	}
+	if len(cfg.ExtraSourceAddresses) > 0 {
+		dst["envoy_upstream_extra_source_addresses"] = cfg.ExtraSourceAddresses
+	}
+	if cfg.TCPFastOpen {
+		dst["envoy_upstream_tcp_fast_open"] = cfg.TCPFastOpen
+	}
+	if cfg.HeaderKeyFormat != "" {
+		dst["envoy_upstream_header_key_format"] = cfg.HeaderKeyFormat
+	}
+	if cfg.TCPKeepalive != nil {
+		dst["envoy_upstream_tcp_keepalive"] = cfg.TCPKeepalive
+	}
+	if cfg.PerDownstreamConnectionPool {
+		dst["envoy_upstream_per_downstream_connection_pool"] = cfg.PerDownstreamConnectionPool
+	}
+	if cfg.ClusterStatsPrefix != "" {
+		dst["envoy_cluster_stats_prefix"] = cfg.ClusterStatsPrefix
+	}
+	if len(cfg.ALPNProtocols) > 0 {
+		dst["envoy_upstream_alpn_protocols"] = cfg.ALPNProtocols
+	}
+	if cfg.SNI != "" {
+		dst["envoy_upstream_sni"] = cfg.SNI
+	}
+	if len(cfg.ClusterMetadata) > 0 {
+		dst["envoy_cluster_metadata"] = cfg.ClusterMetadata
+	}
+	if cfg.RetryPolicy != nil {
+		dst["envoy_upstream_retry_policy"] = cfg.RetryPolicy
+	}
 }
 
 func (cfg *UpstreamConfig) NormalizeWithoutName() error {
@@ -1084,6 +1374,13 @@ func (cfg *UpstreamConfig) normalize(named bool, entMeta *acl.EnterpriseMeta) er
 
 	cfg.Protocol = strings.ToLower(cfg.Protocol)
 
+	// Allow IPv6 local addresses to be given in their bracketed form.
+	cfg.LocalAddress = strings.TrimSuffix(strings.TrimPrefix(cfg.LocalAddress, "["), "]")
+	for i := range cfg.ExtraSourceAddresses {
+		addr := &cfg.ExtraSourceAddresses[i]
+		addr.Address = strings.TrimSuffix(strings.TrimPrefix(addr.Address, "["), "]")
+	}
+
 	if cfg.ConnectTimeoutMs < 0 {
 		cfg.ConnectTimeoutMs = 0
 	}
@@ -1128,6 +1425,13 @@ func (cfg UpstreamConfig) validate(named bool) error {
 		}
 	}
 
+	if cfg.ActiveHealthCheck != nil {
+		err := cfg.ActiveHealthCheck.Validate()
+		if err != nil {
+			validationErr = multierror.Append(validationErr, err)
+		}
+	}
+
 	if cfg.Limits != nil {
 		err := cfg.Limits.Validate()
 		if err != nil {
@@ -1139,9 +1443,103 @@ func (cfg UpstreamConfig) validate(named bool) error {
 		validationErr = multierror.Append(validationErr, fmt.Errorf("invalid value for balance_outbound_connections: %v", cfg.BalanceOutboundConnections))
 	}
 
+	if cfg.MaxHeaderCount < 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_max_header_count must be a non-negative integer, got %d", cfg.MaxHeaderCount))
+	}
+
+	if cfg.ClusterWarmUpDurationMs < 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_cluster_warm_up_duration_ms must be a positive integer, got %d", cfg.ClusterWarmUpDurationMs))
+	}
+
+	if cfg.WarmupTimeout != nil && *cfg.WarmupTimeout < 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_cluster_warmup_timeout must be a positive duration, got %s", *cfg.WarmupTimeout))
+	}
+
+	if !isValidHTTP2WindowSize(cfg.HTTP2InitialStreamWindowSize) {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_http2_initial_stream_window_size must be between %d and %d, got %d",
+			MinHTTP2WindowSize, MaxHTTP2WindowSize, cfg.HTTP2InitialStreamWindowSize))
+	}
+
+	if !isValidHTTP2WindowSize(cfg.HTTP2InitialConnectionWindowSize) {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_http2_initial_connection_window_size must be between %d and %d, got %d",
+			MinHTTP2WindowSize, MaxHTTP2WindowSize, cfg.HTTP2InitialConnectionWindowSize))
+	}
+
+	if cfg.MaxStreamDurationMs < 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_max_stream_duration_ms must be a positive integer, got %d", cfg.MaxStreamDurationMs))
+	}
+
+	if cfg.IdleTimeout != nil && *cfg.IdleTimeout < 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_idle_timeout must be a positive duration, got %s", *cfg.IdleTimeout))
+	}
+
+	if cfg.MaxRequestsPerConnection != nil && *cfg.MaxRequestsPerConnection == 0 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_max_requests_per_connection must be greater than 0"))
+	}
+
+	if cfg.LocalAddress != "" && net.ParseIP(cfg.LocalAddress) == nil {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_local_address must be a valid IP address, got %q", cfg.LocalAddress))
+	}
+
+	if cfg.LocalPort < 0 || cfg.LocalPort > 65535 {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_local_port must be between 0 and 65535, got %d", cfg.LocalPort))
+	} else if cfg.LocalPort != 0 && cfg.LocalAddress == "" {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_local_port requires envoy_upstream_local_address to be set"))
+	}
+
+	if err := ValidateUpstreamExtraSourceAddresses(cfg.LocalAddress, cfg.ExtraSourceAddresses); err != nil {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("invalid envoy_upstream_extra_source_addresses: %w", err))
+	}
+
+	if !isValidUpstreamHeaderKeyFormat(cfg.HeaderKeyFormat) {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("invalid value for envoy_upstream_header_key_format: %q", cfg.HeaderKeyFormat))
+	}
+
+	if strings.Contains(cfg.ClusterStatsPrefix, ".") {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_cluster_stats_prefix must not contain dots, got %q", cfg.ClusterStatsPrefix))
+	}
+
+	if err := validateALPNProtocols(cfg.ALPNProtocols); err != nil {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("invalid envoy_upstream_alpn_protocols: %w", err))
+	}
+
+	if len(cfg.SNI) > maxUpstreamSNILength {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_sni must be at most %d bytes", maxUpstreamSNILength))
+	} else if strings.ContainsAny(cfg.SNI, " \t\r\n") {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_upstream_sni must not contain whitespace, got %q", cfg.SNI))
+	}
+
+	for k := range cfg.ClusterMetadata {
+		if k == "" {
+			validationErr = multierror.Append(validationErr, fmt.Errorf("envoy_cluster_metadata keys must not be empty"))
+			break
+		}
+	}
+
+	if err := cfg.RetryPolicy.Validate(); err != nil {
+		validationErr = multierror.Append(validationErr, fmt.Errorf("invalid envoy_upstream_retry_policy: %w", err))
+	}
+
 	return validationErr
 }
 
+// maxUpstreamSNILength is the longest server name Envoy accepts in an
+// upstream TLS context.
+const maxUpstreamSNILength = 255
+
+const (
+	// MinHTTP2WindowSize and MaxHTTP2WindowSize are the bounds Envoy accepts
+	// for the initial HTTP/2 stream and connection flow control window sizes.
+	MinHTTP2WindowSize = 65535
+	MaxHTTP2WindowSize = 2147483647
+)
+
+// isValidHTTP2WindowSize returns true if size is unset or within the range
+// Envoy accepts for an HTTP/2 flow control window.
+func isValidHTTP2WindowSize(size int) bool {
+	return size == 0 || (size >= MinHTTP2WindowSize && size <= MaxHTTP2WindowSize)
+}
+
 func ParseUpstreamConfigNoDefaults(m map[string]interface{}) (UpstreamConfig, error) {
 	var cfg UpstreamConfig
 	config := &mapstructure.DecoderConfig{
@@ -1208,6 +1606,17 @@ type PassiveHealthCheck struct {
 	// multiplied by the number of times the host has been ejected and is capped by
 	// max_ejection_time (Default 300s). Defaults to 30000ms or 30s.
 	BaseEjectionTime *time.Duration `json:",omitempty" alias:"base_ejection_time"`
+
+	// SplitExternalLocalOriginErrors counts errors that originate locally,
+	// such as connection timeouts and resets, separately from errors returned
+	// by the upstream host. When unset, both kinds of error count towards
+	// MaxFailures.
+	SplitExternalLocalOriginErrors bool `json:",omitempty" alias:"split_external_local_origin_errors"`
+
+	// ConsecutiveLocalOriginFailure is the count of consecutive locally
+	// originated failures that results in a host being removed from the pool.
+	// Requires SplitExternalLocalOriginErrors. Defaults to 5.
+	ConsecutiveLocalOriginFailure *uint32 `json:",omitempty" alias:"consecutive_local_origin_failure"`
 }
 
 func (chk *PassiveHealthCheck) Clone() *PassiveHealthCheck {
@@ -1236,9 +1645,208 @@ func (chk PassiveHealthCheck) Validate() error {
 	if chk.BaseEjectionTime != nil && *chk.BaseEjectionTime < 0*time.Second {
 		return fmt.Errorf("passive health check base_ejection_time cannot be negative")
 	}
+	if chk.ConsecutiveLocalOriginFailure != nil && !chk.SplitExternalLocalOriginErrors {
+		return fmt.Errorf("passive health check consecutive_local_origin_failure requires split_external_local_origin_errors")
+	}
+	return nil
+}
+
+// ActiveHealthCheck configures the proxy to send health check probes to each
+// instance of an upstream, independently of the checks in the catalog.
+type ActiveHealthCheck struct {
+	// Type is the kind of probe to send: "http", "tcp" or "grpc". gRPC probes
+	// use the gRPC health checking protocol and require an upstream protocol
+	// of http2 or grpc.
+	Type string `json:",omitempty"`
+
+	// Interval between probes of each upstream instance. Defaults to 10s.
+	Interval time.Duration `json:",omitempty"`
+
+	// Timeout is how long to wait for a response to a probe before counting it
+	// as failed. Defaults to 5s.
+	Timeout time.Duration `json:",omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed probes that
+	// marks an instance unhealthy. Defaults to 2.
+	UnhealthyThreshold uint32 `json:",omitempty" alias:"unhealthy_threshold"`
+
+	// HealthyThreshold is the number of consecutive successful probes that
+	// marks an unhealthy instance healthy again. Defaults to 2.
+	HealthyThreshold uint32 `json:",omitempty" alias:"healthy_threshold"`
+
+	// Path is the HTTP path requested by http probes. Defaults to "/".
+	Path string `json:",omitempty"`
+}
+
+func (chk *ActiveHealthCheck) Clone() *ActiveHealthCheck {
+	if chk == nil {
+		return nil
+	}
+	chk2 := *chk
+	return &chk2
+}
+
+func (chk ActiveHealthCheck) Validate() error {
+	switch chk.Type {
+	case ActiveHealthCheckTypeHTTP, ActiveHealthCheckTypeTCP, ActiveHealthCheckTypeGRPC:
+	default:
+		return fmt.Errorf("active health check type must be one of %q, %q or %q, got %q",
+			ActiveHealthCheckTypeHTTP, ActiveHealthCheckTypeTCP, ActiveHealthCheckTypeGRPC, chk.Type)
+	}
+	if chk.Interval < 0 {
+		return fmt.Errorf("active health check interval cannot be negative")
+	}
+	if chk.Timeout < 0 {
+		return fmt.Errorf("active health check timeout cannot be negative")
+	}
+	if chk.Path != "" {
+		if chk.Type != ActiveHealthCheckTypeHTTP {
+			return fmt.Errorf("active health check path is only supported for %q checks", ActiveHealthCheckTypeHTTP)
+		}
+		if !strings.HasPrefix(chk.Path, "/") {
+			return fmt.Errorf("active health check path must begin with a '/'")
+		}
+	}
+	return nil
+}
+
+// UpstreamSourceAddress is a source address, and optionally port, that the
+// proxy may bind to when connecting to an upstream.
+type UpstreamSourceAddress struct {
+	Address string `json:",omitempty"`
+
+	// Port defaults to an ephemeral port.
+	Port int `json:",omitempty"`
+}
+
+// ValidateUpstreamExtraSourceAddresses checks the extra source addresses
+// against what Envoy's default local address selector accepts: at most one
+// extra address, of the other IP family from the local address.
+func ValidateUpstreamExtraSourceAddresses(localAddress string, addrs []UpstreamSourceAddress) error {
+	if len(addrs) == 0 {
+		return nil
+	}
+	if localAddress == "" {
+		return fmt.Errorf("requires envoy_upstream_local_address to be set")
+	}
+	if len(addrs) > 1 {
+		return fmt.Errorf("at most one extra source address may be given, got %d", len(addrs))
+	}
+
+	addr := addrs[0]
+	ip := net.ParseIP(addr.Address)
+	if ip == nil {
+		return fmt.Errorf("address must be a valid IP address, got %q", addr.Address)
+	}
+	if addr.Port < 0 || addr.Port > 65535 {
+		return fmt.Errorf("port must be between 0 and 65535, got %d", addr.Port)
+	}
+	if local := net.ParseIP(localAddress); local != nil && (local.To4() == nil) == (ip.To4() == nil) {
+		return fmt.Errorf("address %q must be of a different IP family than envoy_upstream_local_address %q", addr.Address, localAddress)
+	}
 	return nil
 }
 
+// UpstreamRetryPolicy describes how the proxy retries failed requests to an
+// upstream.
+type UpstreamRetryPolicy struct {
+	// NumRetries is the number of times a request is retried. It requires
+	// RetryOn or RetriableStatusCodes to be set.
+	NumRetries uint32 `json:",omitempty" alias:"num_retries"`
+
+	// RetryOn is a comma separated list of the envoy retry conditions that
+	// trigger a retry, such as "5xx,reset".
+	RetryOn string `json:",omitempty" alias:"retry_on"`
+
+	// RetriableStatusCodes are HTTP response status codes that also trigger
+	// a retry.
+	RetriableStatusCodes []uint32 `json:",omitempty" alias:"retriable_status_codes"`
+
+	// PerTryTimeout is how long each attempt, including the first, may take.
+	// Zero leaves each attempt bound only by the route's request timeout.
+	PerTryTimeout *time.Duration `json:",omitempty" alias:"per_try_timeout"`
+}
+
+func (p *UpstreamRetryPolicy) Clone() *UpstreamRetryPolicy {
+	if p == nil {
+		return nil
+	}
+	p2 := *p
+	if p.RetriableStatusCodes != nil {
+		p2.RetriableStatusCodes = append([]uint32(nil), p.RetriableStatusCodes...)
+	}
+	if p.PerTryTimeout != nil {
+		perTryTimeout := *p.PerTryTimeout
+		p2.PerTryTimeout = &perTryTimeout
+	}
+	return &p2
+}
+
+// RetryConditions returns the retry conditions from RetryOn, with
+// "retriable-status-codes" added when RetriableStatusCodes is set, since
+// envoy only retries on those codes when that condition is present.
+func (p *UpstreamRetryPolicy) RetryConditions() []string {
+	var conditions []string
+	for _, c := range strings.Split(p.RetryOn, ",") {
+		if c = strings.TrimSpace(c); c != "" && !slices.Contains(conditions, c) {
+			conditions = append(conditions, c)
+		}
+	}
+	if len(p.RetriableStatusCodes) > 0 && !slices.Contains(conditions, upstreamRetryOnStatusCodes) {
+		conditions = append(conditions, upstreamRetryOnStatusCodes)
+	}
+	return conditions
+}
+
+const upstreamRetryOnStatusCodes = "retriable-status-codes"
+
+func (p *UpstreamRetryPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	conditions := p.RetryConditions()
+	if p.NumRetries > 0 && len(conditions) == 0 {
+		return fmt.Errorf("num_retries requires retry_on or retriable_status_codes to be set")
+	}
+	for _, c := range conditions {
+		if c != upstreamRetryOnStatusCodes && !isValidRetryCondition(c) {
+			return fmt.Errorf("invalid retry condition %q", c)
+		}
+	}
+	for _, code := range p.RetriableStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("retriable status code %d must be between 100 and 599", code)
+		}
+	}
+	if p.PerTryTimeout != nil && *p.PerTryTimeout < 0 {
+		return fmt.Errorf("per_try_timeout must not be negative, got %s", *p.PerTryTimeout)
+	}
+	return nil
+}
+
+// TCPKeepaliveConfig enables TCP keepalives on upstream connections. Any
+// field left as zero uses the operating system's default.
+type TCPKeepaliveConfig struct {
+	// Time is the number of seconds a connection must be idle before
+	// keepalive probes are sent.
+	Time uint32 `json:",omitempty" alias:"keepalive_time"`
+
+	// Interval is the number of seconds between keepalive probes.
+	Interval uint32 `json:",omitempty" alias:"keepalive_interval"`
+
+	// Probes is the number of unanswered probes after which the connection
+	// is considered dead.
+	Probes uint32 `json:",omitempty" alias:"keepalive_probes"`
+}
+
+func (k *TCPKeepaliveConfig) Clone() *TCPKeepaliveConfig {
+	if k == nil {
+		return nil
+	}
+	k2 := *k
+	return &k2
+}
+
 // UpstreamLimits describes the limits that are associated with a specific
 // upstream of a service instance.
 type UpstreamLimits struct {
@@ -1256,6 +1864,11 @@ type UpstreamLimits struct {
 	// to the upstream cluster at a point in time. This is mostly applicable to HTTP/2
 	// clusters since all HTTP/1.1 requests are limited by MaxConnections.
 	MaxConcurrentRequests *int `json:",omitempty" alias:"max_concurrent_requests"`
+
+	// MaxRetries is the maximum number of retries that can be outstanding to
+	// the upstream cluster at a point in time. It stops retries from piling
+	// more load onto an upstream that is already failing.
+	MaxRetries *int `json:",omitempty" alias:"max_retries"`
 }
 
 func (ul *UpstreamLimits) Clone() *UpstreamLimits {
@@ -1266,6 +1879,7 @@ func (ul *UpstreamLimits) Clone() *UpstreamLimits {
 		MaxConnections:        intPointerCopy(ul.MaxConnections),
 		MaxPendingRequests:    intPointerCopy(ul.MaxPendingRequests),
 		MaxConcurrentRequests: intPointerCopy(ul.MaxConcurrentRequests),
+		MaxRetries:            intPointerCopy(ul.MaxRetries),
 	}
 }
 
@@ -1292,6 +1906,9 @@ func (ul UpstreamLimits) Validate() error {
 	if ul.MaxConcurrentRequests != nil && *ul.MaxConcurrentRequests < 0 {
 		return fmt.Errorf("max concurrent requests cannot be negative")
 	}
+	if ul.MaxRetries != nil && *ul.MaxRetries < 0 {
+		return fmt.Errorf("max retries cannot be negative")
+	}
 	return nil
 }
 
@@ -1464,3 +2081,19 @@ type ConfigEntryDeleteResponse struct {
 func isValidConnectionBalance(s string) bool {
 	return s == "" || s == ConnectionExactBalance
 }
+
+func isValidUpstreamHeaderKeyFormat(s string) bool {
+	switch s {
+	case "", UpstreamHeaderKeyFormatDefault, UpstreamHeaderKeyFormatProperCaseWords:
+		return true
+	}
+	return false
+}
+
+func isValidUpstreamProxyProtocolVersion(s string) bool {
+	switch s {
+	case "", UpstreamProxyProtocolV1, UpstreamProxyProtocolV2:
+		return true
+	}
+	return false
+}
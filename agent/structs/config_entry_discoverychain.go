@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/mitchellh/copystructure"
 	"github.com/mitchellh/hashstructure"
+	"golang.org/x/net/http/httpguts"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/cache"
@@ -33,10 +35,17 @@ const (
 	LBPolicyLeastRequest = "least_request"
 	LBPolicyRoundRobin   = "round_robin"
 
+	// LBPolicyClusterProvided delegates load balancing to a custom cluster type
+	LBPolicyClusterProvided = "cluster_provided"
+
 	// Names of Envoy's LB policies
 	HashPolicyCookie     = "cookie"
 	HashPolicyHeader     = "header"
 	HashPolicyQueryParam = "query_parameter"
+
+	// Actions to take when a service-resolver subset matches no instances
+	EmptySubsetActionError             = "error"
+	EmptySubsetActionFallbackToDefault = "fallback_to_default"
 )
 
 var (
@@ -47,6 +56,8 @@ var (
 		LBPolicyLeastRequest: true,
 		LBPolicyRingHash:     true,
 		LBPolicyMaglev:       true,
+
+		LBPolicyClusterProvided: true,
 	}
 
 	validHashPolicies = map[string]bool{
@@ -54,6 +65,12 @@ var (
 		HashPolicyCookie:     true,
 		HashPolicyQueryParam: true,
 	}
+
+	validEmptySubsetActions = map[string]bool{
+		"":                                 true,
+		EmptySubsetActionError:             true,
+		EmptySubsetActionFallbackToDefault: true,
+	}
 )
 
 // ServiceRouterConfigEntry defines L7 (e.g. http) routing rules for a named
@@ -78,12 +95,47 @@ type ServiceRouterConfigEntry struct {
 	// the default service.
 	Routes []ServiceRoute
 
+	// VirtualHostRateLimit limits the rate of requests each downstream proxy
+	// sends to this service across all of its routes.
+	VirtualHostRateLimit *VirtualHostRateLimitConfig `json:",omitempty" alias:"virtual_host_rate_limit"`
+
 	Meta               map[string]string `json:",omitempty"`
 	Hash               uint64            `json:",omitempty" hash:"ignore"`
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 	RaftIndex          `hash:"ignore"`
 }
 
+// VirtualHostRateLimitConfig is a local, per-proxy token bucket rate limit
+// applied to every route of a virtual host.
+type VirtualHostRateLimitConfig struct {
+	// RequestsPerSecond is the average number of requests per second that can
+	// be made without being throttled.
+	RequestsPerSecond uint32 `json:",omitempty" alias:"requests_per_second"`
+
+	// RequestsMaxBurst is the maximum number of requests that can be sent in a
+	// burst. It must be greater than or equal to RequestsPerSecond and defaults
+	// to RequestsPerSecond if unset.
+	RequestsMaxBurst uint32 `json:",omitempty" alias:"requests_max_burst"`
+}
+
+// MaxBurst returns the token bucket size, defaulting to RequestsPerSecond.
+func (c *VirtualHostRateLimitConfig) MaxBurst() uint32 {
+	if c.RequestsMaxBurst == 0 {
+		return c.RequestsPerSecond
+	}
+	return c.RequestsMaxBurst
+}
+
+func (c *VirtualHostRateLimitConfig) validate() error {
+	if c.RequestsPerSecond == 0 {
+		return fmt.Errorf("VirtualHostRateLimit.RequestsPerSecond must be greater than 0")
+	}
+	if c.RequestsMaxBurst != 0 && c.RequestsMaxBurst < c.RequestsPerSecond {
+		return fmt.Errorf("VirtualHostRateLimit.RequestsMaxBurst must be greater than or equal to RequestsPerSecond")
+	}
+	return nil
+}
+
 func (e *ServiceRouterConfigEntry) SetHash(h uint64) {
 	e.Hash = h
 }
@@ -155,6 +207,12 @@ func (e *ServiceRouterConfigEntry) Validate() error {
 		return err
 	}
 
+	if e.VirtualHostRateLimit != nil {
+		if err := e.VirtualHostRateLimit.validate(); err != nil {
+			return err
+		}
+	}
+
 	// Technically you can have no explicit routes at all where just the
 	// catch-all is configured for you, but at that point maybe you should just
 	// delete it so it will default?
@@ -905,6 +963,46 @@ type ServiceResolverConfigEntry struct {
 	// issuing requests to this upstream service.
 	LoadBalancer *LoadBalancer `json:",omitempty" alias:"load_balancer"`
 
+	// CustomSANs is a list of URI SANs that upstream certificates for this
+	// service are validated against, instead of the SPIFFE ID Consul derives
+	// from the service name. This is useful for services whose certificates
+	// were issued outside of Consul, such as when migrating from another
+	// service mesh. It does not apply to peered services.
+	CustomSANs []string `json:",omitempty" alias:"custom_sans"`
+
+	// ClusterFilterChainMetadata is attached to the Envoy clusters for this
+	// service under the "envoy.network_policy" filter metadata namespace, for
+	// network policy extensions that enforce per-cluster firewall rules.
+	ClusterFilterChainMetadata map[string]string `json:",omitempty" alias:"cluster_filter_chain_metadata"`
+
+	// PerConnectionBufferLimitBytes is the soft limit on the size of each
+	// connection's read and write buffers for the Envoy clusters of this
+	// service. It overrides the mesh-wide default. If unset or zero, the
+	// mesh-wide default or Envoy's default of 1MiB is used.
+	PerConnectionBufferLimitBytes uint32 `json:",omitempty" alias:"per_connection_buffer_limit_bytes"`
+
+	// GRPCHealthCheck enables active gRPC health checking of this service's
+	// instances by the proxies calling it, using the grpc.health.v1.Health
+	// service. It only takes effect for the http2 and grpc protocols.
+	GRPCHealthCheck *GRPCHealthCheckConfig `json:",omitempty" alias:"grpc_health_check"`
+
+	// PassiveHealthCheck configures outlier detection for the Envoy clusters
+	// of this service. It is the default for every downstream; a downstream's
+	// upstream configuration in service-defaults or proxy-defaults takes
+	// precedence for each field it sets.
+	PassiveHealthCheck *PassiveHealthCheck `json:",omitempty" alias:"passive_health_check"`
+
+	// EmptySubsetAction determines what happens when a subset's filter
+	// matches no service instances. The default, "error", leaves the subset
+	// without endpoints so requests to it fail. "fallback_to_default" routes
+	// to all instances of the service instead.
+	//
+	// The fallback is only applied by terminating and mesh gateways, which
+	// filter subset endpoints themselves. Sidecars and ingress gateways get
+	// subset endpoints already filtered from the catalog and should use
+	// Failover for empty subsets instead.
+	EmptySubsetAction string `json:",omitempty" alias:"empty_subset_action"`
+
 	Meta               map[string]string `json:",omitempty"`
 	Hash               uint64            `json:",omitempty" hash:"ignore"`
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
@@ -919,6 +1017,12 @@ func (e *ServiceResolverConfigEntry) GetHash() uint64 {
 	return e.Hash
 }
 
+// FallbackOnEmptySubset returns true if subsets that match no service
+// instances should use all instances of the service instead.
+func (e *ServiceResolverConfigEntry) FallbackOnEmptySubset() bool {
+	return e != nil && e.EmptySubsetAction == EmptySubsetActionFallbackToDefault
+}
+
 func (e *ServiceResolverConfigEntry) RelatedPeers() []string {
 	peers := make(map[string]struct{})
 
@@ -1006,7 +1110,12 @@ func (e *ServiceResolverConfigEntry) IsDefault() bool {
 		e.ConnectTimeout == 0 &&
 		e.RequestTimeout == 0 &&
 		e.LoadBalancer == nil &&
-		e.PrioritizeByLocality == nil
+		e.PrioritizeByLocality == nil &&
+		len(e.CustomSANs) == 0 &&
+		len(e.ClusterFilterChainMetadata) == 0 &&
+		e.PerConnectionBufferLimitBytes == 0 &&
+		e.GRPCHealthCheck == nil &&
+		e.PassiveHealthCheck == nil
 }
 
 func (e *ServiceResolverConfigEntry) GetKind() string {
@@ -1082,10 +1191,29 @@ func (e *ServiceResolverConfigEntry) Validate() error {
 		return fmt.Errorf("DefaultSubset %q is not a valid subset", e.DefaultSubset)
 	}
 
+	if !validEmptySubsetActions[e.EmptySubsetAction] {
+		return fmt.Errorf("EmptySubsetAction %q is not supported, must be %q or %q",
+			e.EmptySubsetAction, EmptySubsetActionError, EmptySubsetActionFallbackToDefault)
+	}
+
+	if e.EmptySubsetAction != "" && len(e.Subsets) == 0 {
+		return fmt.Errorf("EmptySubsetAction requires at least one subset to be defined")
+	}
+
 	if err := e.PrioritizeByLocality.validate(); err != nil {
 		return err
 	}
 
+	if err := e.GRPCHealthCheck.validate(); err != nil {
+		return err
+	}
+
+	if e.PassiveHealthCheck != nil {
+		if err := e.PassiveHealthCheck.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if e.Redirect != nil {
 		if !e.InDefaultPartition() && e.Redirect.Datacenter != "" {
 			return fmt.Errorf("Cross-datacenter redirect is only supported in the default partition")
@@ -1242,6 +1370,19 @@ func (e *ServiceResolverConfigEntry) Validate() error {
 		return fmt.Errorf("Bad RequestTimeout '%s', must be >= 0", e.RequestTimeout)
 	}
 
+	for i, san := range e.CustomSANs {
+		u, err := url.Parse(san)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("Bad CustomSANs[%d] %q: must be a valid URI", i, san)
+		}
+	}
+
+	for k := range e.ClusterFilterChainMetadata {
+		if k == "" {
+			return fmt.Errorf("Bad ClusterFilterChainMetadata: keys must not be empty")
+		}
+	}
+
 	if e.LoadBalancer != nil {
 		lb := e.LoadBalancer
 
@@ -1253,6 +1394,32 @@ func (e *ServiceResolverConfigEntry) Validate() error {
 			return fmt.Errorf("Bad LoadBalancer configuration. "+
 				"RingHashConfig specified for incompatible load balancing policy %q", lb.Policy)
 		}
+		if lb.RingHashConfig != nil && lb.RingHashConfig.HeaderName != "" &&
+			!httpguts.ValidHeaderFieldName(lb.RingHashConfig.HeaderName) {
+			return fmt.Errorf("Bad LoadBalancer RingHashConfig: HeaderName %q is not a valid HTTP header name",
+				lb.RingHashConfig.HeaderName)
+		}
+		if lb.Policy == LBPolicyClusterProvided && lb.CustomClusterType == "" {
+			return fmt.Errorf("Bad LoadBalancer configuration: "+
+				"CustomClusterType is required for Policy %q", lb.Policy)
+		}
+		if lb.Policy != LBPolicyClusterProvided && lb.CustomClusterType != "" {
+			return fmt.Errorf("Bad LoadBalancer configuration. "+
+				"CustomClusterType specified for incompatible load balancing policy %q", lb.Policy)
+		}
+		if lb.CustomClusterConfigJSON != "" {
+			if lb.CustomClusterType == "" {
+				return fmt.Errorf("Bad LoadBalancer configuration: " +
+					"CustomClusterConfigJSON requires CustomClusterType")
+			}
+			var typedConfig map[string]interface{}
+			if err := json.Unmarshal([]byte(lb.CustomClusterConfigJSON), &typedConfig); err != nil {
+				return fmt.Errorf("Bad LoadBalancer CustomClusterConfigJSON: %w", err)
+			}
+			if _, ok := typedConfig["@type"]; !ok {
+				return fmt.Errorf("Bad LoadBalancer CustomClusterConfigJSON: missing \"@type\" field")
+			}
+		}
 		if lb.Policy != LBPolicyLeastRequest && lb.LeastRequestConfig != nil {
 			return fmt.Errorf("Bad LoadBalancer configuration. "+
 				"LeastRequestConfig specified for incompatible load balancing policy %q", lb.Policy)
@@ -1498,6 +1665,13 @@ type ServiceResolverFailover struct {
 
 	// SamenessGroup specifies the sameness group to failover to.
 	SamenessGroup string `json:",omitempty"`
+
+	// OverprovisioningFactor tunes how early traffic moves from the primary
+	// target to the failover targets as instances become unhealthy. A target
+	// is considered fully available while the percentage of healthy instances
+	// multiplied by this factor is at least 100. If zero, Envoy's default of
+	// 140 is used.
+	OverprovisioningFactor uint32 `json:",omitempty" alias:"overprovisioning_factor"`
 }
 
 func (f *ServiceResolverFailover) ToDiscoveryTargetOpts() DiscoveryTargetOpts {
@@ -1546,6 +1720,28 @@ type ServiceResolverPrioritizeByLocality struct {
 	Mode string `json:",omitempty"`
 }
 
+// GRPCHealthCheckConfig configures active health checks that call the
+// grpc.health.v1.Health/Check method on each instance of a service.
+type GRPCHealthCheckConfig struct {
+	// ServiceName is the name of the gRPC service reported in the health
+	// check request.
+	ServiceName string `json:",omitempty" alias:"service_name"`
+
+	// AuthorityHeader is the value of the :authority header sent with the
+	// health check request. If empty, the name of the cluster is used.
+	AuthorityHeader string `json:",omitempty" alias:"authority_header"`
+}
+
+func (c *GRPCHealthCheckConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.ServiceName == "" {
+		return fmt.Errorf("GRPCHealthCheck.ServiceName must be set")
+	}
+	return nil
+}
+
 type ServiceResolverFailoverTarget struct {
 	// Service specifies the name of the service to try during failover.
 	Service string `json:",omitempty"`
@@ -1595,6 +1791,16 @@ type LoadBalancer struct {
 	// If no hash policies are present, or none are successfully evaluated,
 	// then a random backend host will be selected.
 	HashPolicies []HashPolicy `json:",omitempty" alias:"hash_policies"`
+
+	// CustomClusterType is the name of an Envoy custom cluster type extension
+	// that provides its own load balancing. Required by, and only valid with,
+	// the "cluster_provided" policy.
+	CustomClusterType string `json:",omitempty" alias:"custom_cluster_type"`
+
+	// CustomClusterConfigJSON is the JSON encoded typed config of the custom
+	// cluster type, including its "@type" field. Most custom cluster types
+	// require one. Only valid with CustomClusterType.
+	CustomClusterConfigJSON string `json:",omitempty" alias:"custom_cluster_config_json"`
 }
 
 // RingHashConfig contains configuration for the "ring_hash" policy type
@@ -1604,6 +1810,16 @@ type RingHashConfig struct {
 
 	// MaximumRingSize determines the maximum number of entries in the hash ring
 	MaximumRingSize uint64 `json:",omitempty" alias:"maximum_ring_size"`
+
+	// HeaderName is the name of a request header to hash on. It is added to the
+	// route hash policies in addition to any configured HashPolicies.
+	HeaderName string `json:",omitempty" alias:"header_name"`
+}
+
+// OnlyHeaderName returns true if the config names a header to hash on and
+// sets no ring size, so the cluster keeps Envoy's default ring sizes.
+func (c *RingHashConfig) OnlyHeaderName() bool {
+	return c != nil && c.HeaderName != "" && c.MinimumRingSize == 0 && c.MaximumRingSize == 0
 }
 
 // LeastRequestConfig contains configuration for the "least_request" policy type
@@ -1663,6 +1879,64 @@ func (lb *LoadBalancer) IsHashBased() bool {
 	}
 }
 
+// IsClusterProvided returns true if the load balancer delegates host
+// discovery and load balancing to a custom cluster type.
+func (lb *LoadBalancer) IsClusterProvided() bool {
+	return lb != nil && lb.Policy == LBPolicyClusterProvided
+}
+
+// EffectiveHashPolicies returns the hash policies to apply to routes using
+// this load balancer. If a RingHashConfig HeaderName is set and no configured
+// policy already hashes on that header, a header policy for it is appended.
+func (lb *LoadBalancer) EffectiveHashPolicies() []HashPolicy {
+	if lb == nil {
+		return nil
+	}
+	if lb.RingHashConfig == nil || lb.RingHashConfig.HeaderName == "" {
+		return lb.HashPolicies
+	}
+
+	for _, hp := range lb.HashPolicies {
+		if hp.Field == HashPolicyHeader && strings.EqualFold(hp.FieldValue, lb.RingHashConfig.HeaderName) {
+			return lb.HashPolicies
+		}
+	}
+
+	policies := make([]HashPolicy, 0, len(lb.HashPolicies)+1)
+	policies = append(policies, lb.HashPolicies...)
+	return append(policies, HashPolicy{
+		Field:      HashPolicyHeader,
+		FieldValue: lb.RingHashConfig.HeaderName,
+	})
+}
+
+// WithDefaultRingHashSizes returns the load balancer with the ring sizes of
+// defaults filled in when it uses the ring_hash policy and sets no ring size
+// of its own. Any HeaderName is kept. The receiver is never modified; it is
+// returned as is when there is nothing to fill in.
+func (lb *LoadBalancer) WithDefaultRingHashSizes(defaults *RingHashConfig) *LoadBalancer {
+	if lb == nil || lb.Policy != LBPolicyRingHash || defaults == nil {
+		return lb
+	}
+	if defaults.MinimumRingSize == 0 && defaults.MaximumRingSize == 0 {
+		return lb
+	}
+	if rh := lb.RingHashConfig; rh != nil && (rh.MinimumRingSize != 0 || rh.MaximumRingSize != 0) {
+		return lb
+	}
+
+	var rh RingHashConfig
+	if lb.RingHashConfig != nil {
+		rh = *lb.RingHashConfig
+	}
+	rh.MinimumRingSize = defaults.MinimumRingSize
+	rh.MaximumRingSize = defaults.MaximumRingSize
+
+	merged := *lb
+	merged.RingHashConfig = &rh
+	return &merged
+}
+
 type discoveryChainConfigEntry interface {
 	ConfigEntry
 	// ListRelatedServices returns a list of other names of services referenced
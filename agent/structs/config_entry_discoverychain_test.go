@@ -1256,6 +1256,46 @@ func TestServiceResolverConfigEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "empty subset action error",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				Subsets: map[string]ServiceResolverSubset{
+					"v1": {Filter: "Service.Meta.version == v1"},
+				},
+				EmptySubsetAction: EmptySubsetActionError,
+			},
+		},
+		{
+			name: "empty subset action fallback to default",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				Subsets: map[string]ServiceResolverSubset{
+					"v1": {Filter: "Service.Meta.version == v1"},
+				},
+				EmptySubsetAction: EmptySubsetActionFallbackToDefault,
+			},
+		},
+		{
+			name: "empty subset action without subsets",
+			entry: &ServiceResolverConfigEntry{
+				Kind:              ServiceResolver,
+				Name:              "test",
+				EmptySubsetAction: EmptySubsetActionFallbackToDefault,
+			},
+			validateErr: "EmptySubsetAction requires at least one subset to be defined",
+		},
+		{
+			name: "invalid empty subset action",
+			entry: &ServiceResolverConfigEntry{
+				Kind:              ServiceResolver,
+				Name:              "test",
+				EmptySubsetAction: "drop",
+			},
+			validateErr: `EmptySubsetAction "drop" is not supported`,
+		},
 		{
 			name: "empty redirect",
 			entry: &ServiceResolverConfigEntry{
@@ -1580,6 +1620,106 @@ func TestServiceResolverConfigEntry(t *testing.T) {
 			},
 			validateErr: "Bad RequestTimeout",
 		},
+		{
+			name: "custom sans",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				CustomSANs: []string{
+					"spiffe://cluster.local/ns/default/sa/test",
+				},
+			},
+			check: func(t *testing.T, entry *ServiceResolverConfigEntry) {
+				require.False(t, entry.IsDefault())
+			},
+		},
+		{
+			name: "bad custom san",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				CustomSANs: []string{
+					"spiffe://cluster.local/ns/default/sa/test",
+					"not a uri",
+				},
+			},
+			validateErr: `Bad CustomSANs[1] "not a uri": must be a valid URI`,
+		},
+		{
+			name: "cluster filter chain metadata",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				ClusterFilterChainMetadata: map[string]string{
+					"zone": "restricted",
+				},
+			},
+			check: func(t *testing.T, entry *ServiceResolverConfigEntry) {
+				require.False(t, entry.IsDefault())
+			},
+		},
+		{
+			name: "bad cluster filter chain metadata key",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				ClusterFilterChainMetadata: map[string]string{
+					"": "restricted",
+				},
+			},
+			validateErr: "Bad ClusterFilterChainMetadata: keys must not be empty",
+		},
+		{
+			name: "grpc health check",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				GRPCHealthCheck: &GRPCHealthCheckConfig{
+					ServiceName:     "test.v1.Test",
+					AuthorityHeader: "test.internal",
+				},
+			},
+			check: func(t *testing.T, entry *ServiceResolverConfigEntry) {
+				require.False(t, entry.IsDefault())
+			},
+		},
+		{
+			name: "grpc health check without service name",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				GRPCHealthCheck: &GRPCHealthCheckConfig{
+					AuthorityHeader: "test.internal",
+				},
+			},
+			validateErr: "GRPCHealthCheck.ServiceName must be set",
+		},
+		{
+			name: "passive health check",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				PassiveHealthCheck: &PassiveHealthCheck{
+					MaxFailures:                    5,
+					SplitExternalLocalOriginErrors: true,
+					ConsecutiveLocalOriginFailure:  uintPointer(3),
+				},
+			},
+			check: func(t *testing.T, entry *ServiceResolverConfigEntry) {
+				require.False(t, entry.IsDefault())
+			},
+		},
+		{
+			name: "passive health check with invalid max ejection percent",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				PassiveHealthCheck: &PassiveHealthCheck{
+					MaxEjectionPercent: uintPointer(101),
+				},
+			},
+			validateErr: "max_ejection_percent must be a percentage",
+		},
 	}
 
 	// Bulk add a bunch of similar validation cases.
@@ -1717,6 +1857,100 @@ func TestServiceResolverConfigEntry_LoadBalancer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ring hash config with header name",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:         LBPolicyRingHash,
+					RingHashConfig: &RingHashConfig{HeaderName: "x-session-id"},
+				},
+			},
+		},
+		{
+			name: "ring hash config with invalid header name",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:         LBPolicyRingHash,
+					RingHashConfig: &RingHashConfig{HeaderName: "x session:id"},
+				},
+			},
+			validateErr: `HeaderName "x session:id" is not a valid HTTP header name`,
+		},
+		{
+			name: "cluster provided policy with custom cluster type",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:            LBPolicyClusterProvided,
+					CustomClusterType: "envoy.clusters.redis",
+				},
+			},
+		},
+		{
+			name: "cluster provided policy with custom cluster config",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:                  LBPolicyClusterProvided,
+					CustomClusterType:       "envoy.clusters.redis",
+					CustomClusterConfigJSON: `{"@type": "type.googleapis.com/envoy.extensions.clusters.redis.v3.RedisClusterConfig"}`,
+				},
+			},
+		},
+		{
+			name: "custom cluster config without custom cluster type",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:                  LBPolicyRoundRobin,
+					CustomClusterConfigJSON: `{"@type": "type.googleapis.com/envoy.extensions.clusters.redis.v3.RedisClusterConfig"}`,
+				},
+			},
+			validateErr: `CustomClusterConfigJSON requires CustomClusterType`,
+		},
+		{
+			name: "custom cluster config without type",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:                  LBPolicyClusterProvided,
+					CustomClusterType:       "envoy.clusters.redis",
+					CustomClusterConfigJSON: `{"clusterRefreshRate": "5s"}`,
+				},
+			},
+			validateErr: `Bad LoadBalancer CustomClusterConfigJSON: missing "@type" field`,
+		},
+		{
+			name: "cluster provided policy without custom cluster type",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy: LBPolicyClusterProvided,
+				},
+			},
+			validateErr: `CustomClusterType is required for Policy "cluster_provided"`,
+		},
+		{
+			name: "custom cluster type with incompatible policy",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				LoadBalancer: &LoadBalancer{
+					Policy:            LBPolicyRoundRobin,
+					CustomClusterType: "envoy.clusters.redis",
+				},
+			},
+			validateErr: `CustomClusterType specified for incompatible load balancing policy "round_robin"`,
+		},
 		{
 			name: "good policy for least request config",
 			entry: &ServiceResolverConfigEntry{
@@ -1978,6 +2212,129 @@ func TestServiceResolverConfigEntry_LoadBalancer(t *testing.T) {
 	}
 }
 
+func TestLoadBalancer_EffectiveHashPolicies(t *testing.T) {
+	cookie := HashPolicy{Field: HashPolicyCookie, FieldValue: "chocolate-chip"}
+	header := HashPolicy{Field: HashPolicyHeader, FieldValue: "x-session-id"}
+
+	cases := map[string]struct {
+		lb     *LoadBalancer
+		expect []HashPolicy
+	}{
+		"nil": {
+			lb:     nil,
+			expect: nil,
+		},
+		"no header name": {
+			lb: &LoadBalancer{
+				Policy:       LBPolicyRingHash,
+				HashPolicies: []HashPolicy{cookie},
+			},
+			expect: []HashPolicy{cookie},
+		},
+		"header name appended": {
+			lb: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{HeaderName: "x-session-id"},
+				HashPolicies:   []HashPolicy{cookie},
+			},
+			expect: []HashPolicy{cookie, header},
+		},
+		"header name already hashed": {
+			lb: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{HeaderName: "X-Session-ID"},
+				HashPolicies:   []HashPolicy{header},
+			},
+			expect: []HashPolicy{header},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expect, tc.lb.EffectiveHashPolicies())
+		})
+	}
+}
+
+func TestLoadBalancer_WithDefaultRingHashSizes(t *testing.T) {
+	defaults := &RingHashConfig{MinimumRingSize: 1024, MaximumRingSize: 8192}
+
+	cases := map[string]struct {
+		lb       *LoadBalancer
+		defaults *RingHashConfig
+		expect   *LoadBalancer
+	}{
+		"nil": {
+			lb:       nil,
+			defaults: defaults,
+			expect:   nil,
+		},
+		"no defaults": {
+			lb:       &LoadBalancer{Policy: LBPolicyRingHash},
+			defaults: nil,
+			expect:   &LoadBalancer{Policy: LBPolicyRingHash},
+		},
+		"empty defaults": {
+			lb:       &LoadBalancer{Policy: LBPolicyRingHash},
+			defaults: &RingHashConfig{},
+			expect:   &LoadBalancer{Policy: LBPolicyRingHash},
+		},
+		"other policy": {
+			lb:       &LoadBalancer{Policy: LBPolicyMaglev},
+			defaults: defaults,
+			expect:   &LoadBalancer{Policy: LBPolicyMaglev},
+		},
+		"no ring hash config": {
+			lb:       &LoadBalancer{Policy: LBPolicyRingHash},
+			defaults: defaults,
+			expect: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{MinimumRingSize: 1024, MaximumRingSize: 8192},
+			},
+		},
+		"header name kept": {
+			lb: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{HeaderName: "x-session-id"},
+			},
+			defaults: defaults,
+			expect: &LoadBalancer{
+				Policy: LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{
+					MinimumRingSize: 1024,
+					MaximumRingSize: 8192,
+					HeaderName:      "x-session-id",
+				},
+			},
+		},
+		"service sizes win": {
+			lb: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{MinimumRingSize: 20},
+			},
+			defaults: defaults,
+			expect: &LoadBalancer{
+				Policy:         LBPolicyRingHash,
+				RingHashConfig: &RingHashConfig{MinimumRingSize: 20},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var before *LoadBalancer
+			if tc.lb != nil {
+				before = tc.lb.DeepCopy()
+			}
+			require.Equal(t, tc.expect, tc.lb.WithDefaultRingHashSizes(tc.defaults))
+			// The service-resolver's config must not be modified.
+			require.Equal(t, before, tc.lb)
+		})
+	}
+}
+
 func TestServiceSplitterConfigEntry(t *testing.T) {
 
 	makesplitter := func(splits ...ServiceSplit) *ServiceSplitterConfigEntry {
@@ -2377,6 +2734,42 @@ func TestServiceRouterConfigEntry(t *testing.T) {
 			name:  "empty",
 			entry: makerouter(),
 		},
+		{
+			name: "virtual host rate limit",
+			entry: &ServiceRouterConfigEntry{
+				Kind: ServiceRouter,
+				Name: "test",
+				VirtualHostRateLimit: &VirtualHostRateLimitConfig{
+					RequestsPerSecond: 10,
+				},
+			},
+			check: func(t *testing.T, entry *ServiceRouterConfigEntry) {
+				require.Equal(t, uint32(10), entry.VirtualHostRateLimit.MaxBurst())
+			},
+		},
+		{
+			name: "virtual host rate limit without requests per second",
+			entry: &ServiceRouterConfigEntry{
+				Kind: ServiceRouter,
+				Name: "test",
+				VirtualHostRateLimit: &VirtualHostRateLimitConfig{
+					RequestsMaxBurst: 10,
+				},
+			},
+			validateErr: "VirtualHostRateLimit.RequestsPerSecond must be greater than 0",
+		},
+		{
+			name: "virtual host rate limit burst below requests per second",
+			entry: &ServiceRouterConfigEntry{
+				Kind: ServiceRouter,
+				Name: "test",
+				VirtualHostRateLimit: &VirtualHostRateLimitConfig{
+					RequestsPerSecond: 10,
+					RequestsMaxBurst:  5,
+				},
+			},
+			validateErr: "VirtualHostRateLimit.RequestsMaxBurst must be greater than or equal to RequestsPerSecond",
+		},
 		{
 			name: "1 empty route",
 			entry: makerouter(
@@ -521,6 +521,11 @@ type LinkedService struct {
 	//DisableAutoHostRewrite disables terminating gateways auto host rewrite feature when set to true.
 	DisableAutoHostRewrite bool `json:",omitempty"`
 
+	// ProxyProtocolVersion makes the gateway send a PROXY protocol header,
+	// either "v1" or "v2", at the start of each connection to the linked
+	// service so that it can see the original client address.
+	ProxyProtocolVersion string `json:",omitempty" alias:"proxy_protocol_version"`
+
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 }
 
@@ -600,6 +605,11 @@ func (e *TerminatingGatewayConfigEntry) Validate() error {
 
 			return fmt.Errorf("Service %q must have a CertFile, CAFile, and KeyFile specified for TLS origination", svc.Name)
 		}
+
+		if !isValidUpstreamProxyProtocolVersion(svc.ProxyProtocolVersion) {
+			return fmt.Errorf("Service %q has an invalid ProxyProtocolVersion %q, must be %q or %q",
+				svc.Name, svc.ProxyProtocolVersion, UpstreamProxyProtocolV1, UpstreamProxyProtocolV2)
+		}
 	}
 	return nil
 }
@@ -671,7 +681,8 @@ type GatewayService struct {
 	FromWildcard bool               `json:",omitempty"`
 	ServiceKind  GatewayServiceKind `json:",omitempty"`
 	RaftIndex
-	AutoHostRewrite bool `json:",omitempty"`
+	AutoHostRewrite      bool   `json:",omitempty"`
+	ProxyProtocolVersion string `json:",omitempty"`
 }
 
 type GatewayServices []*GatewayService
@@ -707,6 +718,7 @@ func (g *GatewayService) IsSame(o *GatewayService) bool {
 		g.CertFile == o.CertFile &&
 		g.KeyFile == o.KeyFile &&
 		g.SNI == o.SNI &&
+		g.ProxyProtocolVersion == o.ProxyProtocolVersion &&
 		g.ServiceKind == o.ServiceKind &&
 		g.FromWildcard == o.FromWildcard
 }
@@ -728,6 +740,8 @@ func (g *GatewayService) Clone() *GatewayService {
 		RaftIndex:       g.RaftIndex,
 		ServiceKind:     g.ServiceKind,
 		AutoHostRewrite: g.AutoHostRewrite,
+
+		ProxyProtocolVersion: g.ProxyProtocolVersion,
 	}
 }
 
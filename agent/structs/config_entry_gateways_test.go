@@ -1068,6 +1068,31 @@ func TestTerminatingGatewayConfigEntry(t *testing.T) {
 				},
 			},
 		},
+		"proxy protocol version": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:                 "web",
+						ProxyProtocolVersion: "v2",
+					},
+				},
+			},
+		},
+		"invalid proxy protocol version": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:                 "web",
+						ProxyProtocolVersion: "v3",
+					},
+				},
+			},
+			validateErr: `Service "web" has an invalid ProxyProtocolVersion "v3"`,
+		},
 	}
 	testConfigEntryNormalizeAndValidate(t, cases)
 }
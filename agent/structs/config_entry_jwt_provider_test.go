@@ -280,6 +280,112 @@ func TestJWTProviderConfigEntry_ValidateAndNormalize(t *testing.T) {
 			},
 			validateErr: "must specify exactly one of: Filename, EnvironmentVariable, InlineString or InlineBytes for JWKS' TrustedCA",
 		},
+		"invalid jwt-provider - Remote JWKS cluster client certificate without private key": {
+			entry: &JWTProviderConfigEntry{
+				Kind: JWTProvider,
+				Name: "okta",
+				JSONWebKeySet: &JSONWebKeySet{
+					Remote: &RemoteJWKS{
+						FetchAsynchronously: true,
+						URI:                 "https://example.com/.well-known/jwks.json",
+						JWKSCluster: &JWKSCluster{
+							TLSCertificates: &JWKSTLSCertificate{
+								TrustedCA: &JWKSTLSCertTrustedCA{
+									Filename: "myfile.cert",
+								},
+								ClientCertificate: &JWKSTLSCertData{
+									Filename: "client.crt",
+								},
+							},
+						},
+					},
+				},
+			},
+			validateErr: "must specify both ClientCertificate and PrivateKey for JWKS' TLSCertificates, or neither",
+		},
+		"invalid jwt-provider - Remote JWKS cluster private key with multiple options": {
+			entry: &JWTProviderConfigEntry{
+				Kind: JWTProvider,
+				Name: "okta",
+				JSONWebKeySet: &JSONWebKeySet{
+					Remote: &RemoteJWKS{
+						FetchAsynchronously: true,
+						URI:                 "https://example.com/.well-known/jwks.json",
+						JWKSCluster: &JWKSCluster{
+							TLSCertificates: &JWKSTLSCertificate{
+								TrustedCA: &JWKSTLSCertTrustedCA{
+									Filename: "myfile.cert",
+								},
+								ClientCertificate: &JWKSTLSCertData{
+									Filename: "client.crt",
+								},
+								PrivateKey: &JWKSTLSCertData{
+									Filename:            "client.key",
+									EnvironmentVariable: "CLIENT_KEY",
+								},
+							},
+						},
+					},
+				},
+			},
+			validateErr: "must specify exactly one of: Filename, EnvironmentVariable, InlineString or InlineBytes for JWKS' PrivateKey",
+		},
+		"invalid jwt-provider - Remote JWKS cluster socket option with both values": {
+			entry: &JWTProviderConfigEntry{
+				Kind: JWTProvider,
+				Name: "okta",
+				JSONWebKeySet: &JSONWebKeySet{
+					Remote: &RemoteJWKS{
+						FetchAsynchronously: true,
+						URI:                 "https://example.com/.well-known/jwks.json",
+						JWKSCluster: &JWKSCluster{
+							SocketOptions: []SocketOption{
+								{
+									Level:       0,
+									Name:        1,
+									IntValue:    136,
+									BufferValue: []byte("abc"),
+								},
+							},
+						},
+					},
+				},
+			},
+			validateErr: "SocketOptions[0]: must specify at most one of: IntValue or BufferValue",
+		},
+		"invalid jwt-provider - Remote JWKS cluster DNS refresh rate with static discovery": {
+			entry: &JWTProviderConfigEntry{
+				Kind: JWTProvider,
+				Name: "okta",
+				JSONWebKeySet: &JSONWebKeySet{
+					Remote: &RemoteJWKS{
+						FetchAsynchronously: true,
+						URI:                 "https://example.com/.well-known/jwks.json",
+						JWKSCluster: &JWKSCluster{
+							DiscoveryType:  DiscoveryTypeStatic,
+							DNSRefreshRate: durationPointer(30 * time.Second),
+						},
+					},
+				},
+			},
+			validateErr: "DNSRefreshRate is only supported with the STRICT_DNS or LOGICAL_DNS discovery types",
+		},
+		"invalid jwt-provider - Remote JWKS cluster DNS refresh rate too small": {
+			entry: &JWTProviderConfigEntry{
+				Kind: JWTProvider,
+				Name: "okta",
+				JSONWebKeySet: &JSONWebKeySet{
+					Remote: &RemoteJWKS{
+						FetchAsynchronously: true,
+						URI:                 "https://example.com/.well-known/jwks.json",
+						JWKSCluster: &JWKSCluster{
+							DNSRefreshRate: durationPointer(time.Millisecond),
+						},
+					},
+				},
+			},
+			validateErr: "DNSRefreshRate must be greater than 1ms",
+		},
 		"invalid jwt-provider - JWT location with 2 fields": {
 			entry: &JWTProviderConfigEntry{
 				Kind: JWTProvider,
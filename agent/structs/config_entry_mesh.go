@@ -6,6 +6,10 @@ package structs
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/net/http/httpguts"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/types"
@@ -26,6 +30,37 @@ type MeshConfigEntry struct {
 
 	Peering *PeeringMeshConfig `json:",omitempty"`
 
+	Telemetry *MeshTelemetryConfig `json:",omitempty"`
+
+	// ConnectTimeout is the default timeout for new network connections to
+	// upstream services. It is overridden by the ConnectTimeout of a
+	// service-resolver or the connect_timeout_ms of an upstream. If not set,
+	// a default value of 5s will be used.
+	ConnectTimeout *time.Duration `json:",omitempty" alias:"connect_timeout"`
+
+	// PerConnectionBufferLimitBytes is the default soft limit on the size of
+	// each upstream connection's read and write buffers for all clusters in
+	// the mesh. It is overridden by the PerConnectionBufferLimitBytes of a
+	// service-resolver. If not set, Envoy's default of 1MiB is used.
+	PerConnectionBufferLimitBytes *uint32 `json:",omitempty" alias:"per_connection_buffer_limit_bytes"`
+
+	// TCPKeepalive is the default TCP keepalive configuration for connections
+	// from sidecar proxies to their upstreams. It is replaced by the
+	// envoy_upstream_tcp_keepalive config of an individual upstream.
+	TCPKeepalive *TCPKeepaliveConfig `json:",omitempty" alias:"tcp_keepalive"`
+
+	// DefaultRingHashConfig is the default ring size for clusters that use
+	// the ring_hash load balancing policy. It applies to any service-resolver
+	// LoadBalancer that sets neither MinimumRingSize nor MaximumRingSize.
+	// HeaderName is not supported here.
+	DefaultRingHashConfig *RingHashConfig `json:",omitempty" alias:"default_ring_hash_config"`
+
+	// HTTPIdleTimeout is the default time a connection from a sidecar proxy
+	// to an HTTP upstream may have no active requests before it is closed. It
+	// is overridden by the envoy_upstream_idle_timeout config of an upstream.
+	// If not set, Envoy's default of one hour is used.
+	HTTPIdleTimeout *time.Duration `json:",omitempty" alias:"http_idle_timeout"`
+
 	Meta               map[string]string `json:",omitempty"`
 	Hash               uint64            `json:",omitempty" hash:"ignore"`
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
@@ -60,10 +95,22 @@ type MeshDirectionalTLSConfig struct {
 	// Define a subset of cipher suites to restrict
 	// Only applicable to connections negotiated via TLS 1.2 or earlier
 	CipherSuites []types.TLSCipherSuite `json:",omitempty" alias:"cipher_suites"`
+
+	// ALPNProtocols replaces the protocols advertised with ALPN during the
+	// mTLS handshake, in order of preference. For outgoing connections it is
+	// sent to upstreams. For incoming connections it replaces the list that
+	// the public listener of sidecars and mesh gateways otherwise derives
+	// from the service protocol.
+	ALPNProtocols []string `json:",omitempty" alias:"alpn_protocols"`
 }
 
 type MeshHTTPConfig struct {
 	SanitizeXForwardedClientCert bool `alias:"sanitize_x_forwarded_client_cert"`
+
+	// ResponseHeadersToRemove lists headers that every sidecar strips from
+	// the HTTP responses its local service sends, such as Server or
+	// X-Powered-By, so that callers cannot fingerprint the service.
+	ResponseHeadersToRemove []string `json:",omitempty" alias:"response_headers_to_remove"`
 }
 
 // PeeringMeshConfig contains cluster-wide options pertaining to peering.
@@ -76,6 +123,25 @@ type PeeringMeshConfig struct {
 	PeerThroughMeshGateways bool `alias:"peer_through_mesh_gateways"`
 }
 
+// MeshTelemetryConfig contains cluster-wide options pertaining to the
+// statistics emitted by proxies.
+type MeshTelemetryConfig struct {
+	// TrackClusterStats enables additional statistics for every cluster
+	// generated for the proxies in the mesh.
+	TrackClusterStats *ClusterStatsConfig `json:",omitempty" alias:"track_cluster_stats"`
+}
+
+// ClusterStatsConfig maps to Envoy's TrackClusterStats.
+type ClusterStatsConfig struct {
+	// RequestResponseSizes enables histograms of the request and response
+	// body sizes for each cluster.
+	RequestResponseSizes bool `json:",omitempty" alias:"request_response_sizes"`
+
+	// TimeoutBudgets enables histograms of the percentage of the request and
+	// per-try timeouts used by each request.
+	TimeoutBudgets bool `json:",omitempty" alias:"timeout_budgets"`
+}
+
 func (e *MeshConfigEntry) GetKind() string {
 	return MeshConfig
 }
@@ -120,6 +186,35 @@ func (e *MeshConfigEntry) Validate() error {
 		return err
 	}
 
+	if e.ConnectTimeout != nil && *e.ConnectTimeout <= 0 {
+		return fmt.Errorf("ConnectTimeout must be greater than 0")
+	}
+
+	if e.PerConnectionBufferLimitBytes != nil && *e.PerConnectionBufferLimitBytes == 0 {
+		return fmt.Errorf("PerConnectionBufferLimitBytes must be greater than 0")
+	}
+
+	if e.HTTPIdleTimeout != nil && *e.HTTPIdleTimeout <= 0 {
+		return fmt.Errorf("HTTPIdleTimeout must be greater than 0")
+	}
+
+	if rh := e.DefaultRingHashConfig; rh != nil {
+		if rh.HeaderName != "" {
+			return fmt.Errorf("DefaultRingHashConfig does not support HeaderName")
+		}
+		if rh.MaximumRingSize != 0 && rh.MinimumRingSize > rh.MaximumRingSize {
+			return fmt.Errorf("DefaultRingHashConfig MinimumRingSize must not be greater than MaximumRingSize")
+		}
+	}
+
+	if e.HTTP != nil {
+		for i, h := range e.HTTP.ResponseHeadersToRemove {
+			if !httpguts.ValidHeaderFieldName(h) {
+				return fmt.Errorf("HTTP.ResponseHeadersToRemove[%d] %q is not a valid header name", i, h)
+			}
+		}
+	}
+
 	if e.TLS != nil {
 		if e.TLS.Incoming != nil {
 			if err := validateMeshDirectionalTLSConfig(e.TLS.Incoming); err != nil {
@@ -163,21 +258,61 @@ func (e *MeshConfigEntry) GetEnterpriseMeta() *acl.EnterpriseMeta {
 }
 
 // MarshalJSON adds the Kind field so that the JSON can be decoded back into the
-// correct type.
+// correct type, and writes the timeouts as duration strings.
 // This method is implemented on the structs type (as apposed to the api type)
 // because that is what the API currently uses to return a response.
 func (e *MeshConfigEntry) MarshalJSON() ([]byte, error) {
 	type Alias MeshConfigEntry
 	source := &struct {
-		Kind string
+		Kind            string
+		ConnectTimeout  string `json:",omitempty"`
+		HTTPIdleTimeout string `json:",omitempty"`
 		*Alias
 	}{
 		Kind:  MeshConfig,
 		Alias: (*Alias)(e),
 	}
+	if e.ConnectTimeout != nil {
+		source.ConnectTimeout = e.ConnectTimeout.String()
+	}
+	if e.HTTPIdleTimeout != nil {
+		source.HTTPIdleTimeout = e.HTTPIdleTimeout.String()
+	}
 	return json.Marshal(source)
 }
 
+func (e *MeshConfigEntry) UnmarshalJSON(data []byte) error {
+	type Alias MeshConfigEntry
+	aux := &struct {
+		ConnectTimeout  string
+		HTTPIdleTimeout string
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var merr *multierror.Error
+	if aux.ConnectTimeout != "" {
+		d, err := time.ParseDuration(aux.ConnectTimeout)
+		if err != nil {
+			merr = multierror.Append(merr, err)
+		} else {
+			e.ConnectTimeout = &d
+		}
+	}
+	if aux.HTTPIdleTimeout != "" {
+		d, err := time.ParseDuration(aux.HTTPIdleTimeout)
+		if err != nil {
+			merr = multierror.Append(merr, err)
+		} else {
+			e.HTTPIdleTimeout = &d
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
 func (e *MeshConfigEntry) PeerThroughMeshGateways() bool {
 	if e == nil || e.Peering == nil {
 		return false
@@ -185,11 +320,77 @@ func (e *MeshConfigEntry) PeerThroughMeshGateways() bool {
 	return e.Peering.PeerThroughMeshGateways
 }
 
+// DefaultConnectTimeout returns the mesh-wide upstream connect timeout, or
+// zero if one is not configured.
+func (e *MeshConfigEntry) DefaultConnectTimeout() time.Duration {
+	if e == nil || e.ConnectTimeout == nil {
+		return 0
+	}
+	return *e.ConnectTimeout
+}
+
+// DefaultPerConnectionBufferLimitBytes returns the mesh-wide cluster
+// connection buffer limit, or zero if one is not configured.
+func (e *MeshConfigEntry) DefaultPerConnectionBufferLimitBytes() uint32 {
+	if e == nil || e.PerConnectionBufferLimitBytes == nil {
+		return 0
+	}
+	return *e.PerConnectionBufferLimitBytes
+}
+
+// DefaultTCPKeepalive returns the mesh-wide upstream TCP keepalive
+// configuration, or nil if one is not configured.
+func (e *MeshConfigEntry) DefaultTCPKeepalive() *TCPKeepaliveConfig {
+	if e == nil {
+		return nil
+	}
+	return e.TCPKeepalive
+}
+
+// DefaultRingHashSizes returns the mesh-wide ring_hash sizing, or nil if
+// one is not configured.
+func (e *MeshConfigEntry) DefaultRingHashSizes() *RingHashConfig {
+	if e == nil {
+		return nil
+	}
+	return e.DefaultRingHashConfig
+}
+
+// DefaultHTTPIdleTimeout returns the mesh-wide idle timeout for connections
+// to HTTP upstreams, or zero if one is not configured.
+func (e *MeshConfigEntry) DefaultHTTPIdleTimeout() time.Duration {
+	if e == nil || e.HTTPIdleTimeout == nil {
+		return 0
+	}
+	return *e.HTTPIdleTimeout
+}
+
+func (e *MeshConfigEntry) TrackClusterStats() *ClusterStatsConfig {
+	if e == nil || e.Telemetry == nil {
+		return nil
+	}
+	return e.Telemetry.TrackClusterStats
+}
+
 func validateMeshDirectionalTLSConfig(cfg *MeshDirectionalTLSConfig) error {
 	if cfg == nil {
 		return nil
 	}
-	return validateTLSConfig(cfg.TLSMinVersion, cfg.TLSMaxVersion, cfg.CipherSuites)
+	if err := validateTLSConfig(cfg.TLSMinVersion, cfg.TLSMaxVersion, cfg.CipherSuites); err != nil {
+		return err
+	}
+	return validateALPNProtocols(cfg.ALPNProtocols)
+}
+
+// validateALPNProtocols checks that each protocol is a valid ALPN protocol
+// ID, which must be between 1 and 255 bytes long.
+func validateALPNProtocols(protocols []string) error {
+	for _, p := range protocols {
+		if len(p) == 0 || len(p) > 255 {
+			return fmt.Errorf("ALPN protocol %q must be between 1 and 255 bytes long", p)
+		}
+	}
+	return nil
 }
 
 func validateTLSConfig(
@@ -4,9 +4,12 @@
 package structs
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMeshConfigEntry_PeerThroughMeshGateways(t *testing.T) {
@@ -47,3 +50,248 @@ func TestMeshConfigEntry_PeerThroughMeshGateways(t *testing.T) {
 		})
 	}
 }
+
+func TestMeshConfigEntry_DefaultConnectTimeout(t *testing.T) {
+	tests := map[string]struct {
+		input       *MeshConfigEntry
+		want        time.Duration
+		validateErr string
+	}{
+		"nil entry": {
+			input: nil,
+			want:  0,
+		},
+		"unset": {
+			input: &MeshConfigEntry{},
+			want:  0,
+		},
+		"set": {
+			input: &MeshConfigEntry{
+				ConnectTimeout: DurationPointer(12 * time.Second),
+			},
+			want: 12 * time.Second,
+		},
+		"negative": {
+			input: &MeshConfigEntry{
+				ConnectTimeout: DurationPointer(-1 * time.Second),
+			},
+			want:        -1 * time.Second,
+			validateErr: "ConnectTimeout must be greater than 0",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.DefaultConnectTimeout())
+			if tc.input == nil {
+				return
+			}
+			err := tc.input.Validate()
+			if tc.validateErr != "" {
+				assert.ErrorContains(t, err, tc.validateErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfigEntry_ValidateResponseHeadersToRemove(t *testing.T) {
+	tests := map[string]struct {
+		input       []string
+		validateErr string
+	}{
+		"empty": {
+			input: nil,
+		},
+		"valid": {
+			input: []string{"Server", "X-Powered-By"},
+		},
+		"empty name": {
+			input:       []string{"Server", ""},
+			validateErr: `HTTP.ResponseHeadersToRemove[1] "" is not a valid header name`,
+		},
+		"invalid name": {
+			input:       []string{"X Powered By"},
+			validateErr: `HTTP.ResponseHeadersToRemove[0] "X Powered By" is not a valid header name`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			entry := &MeshConfigEntry{
+				HTTP: &MeshHTTPConfig{
+					ResponseHeadersToRemove: tc.input,
+				},
+			}
+			err := entry.Validate()
+			if tc.validateErr != "" {
+				assert.ErrorContains(t, err, tc.validateErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfigEntry_DefaultPerConnectionBufferLimitBytes(t *testing.T) {
+	tests := map[string]struct {
+		input       *MeshConfigEntry
+		want        uint32
+		validateErr string
+	}{
+		"nil entry": {
+			input: nil,
+			want:  0,
+		},
+		"unset": {
+			input: &MeshConfigEntry{},
+			want:  0,
+		},
+		"set": {
+			input: &MeshConfigEntry{
+				PerConnectionBufferLimitBytes: uintPointer(32768),
+			},
+			want: 32768,
+		},
+		"zero": {
+			input: &MeshConfigEntry{
+				PerConnectionBufferLimitBytes: uintPointer(0),
+			},
+			want:        0,
+			validateErr: "PerConnectionBufferLimitBytes must be greater than 0",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.DefaultPerConnectionBufferLimitBytes())
+			if tc.input == nil {
+				return
+			}
+			err := tc.input.Validate()
+			if tc.validateErr != "" {
+				assert.ErrorContains(t, err, tc.validateErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfigEntry_DefaultRingHashSizes(t *testing.T) {
+	tests := map[string]struct {
+		input       *MeshConfigEntry
+		want        *RingHashConfig
+		validateErr string
+	}{
+		"nil entry": {
+			input: nil,
+			want:  nil,
+		},
+		"unset": {
+			input: &MeshConfigEntry{},
+			want:  nil,
+		},
+		"set": {
+			input: &MeshConfigEntry{
+				DefaultRingHashConfig: &RingHashConfig{MinimumRingSize: 1024, MaximumRingSize: 8192},
+			},
+			want: &RingHashConfig{MinimumRingSize: 1024, MaximumRingSize: 8192},
+		},
+		"minimum only": {
+			input: &MeshConfigEntry{
+				DefaultRingHashConfig: &RingHashConfig{MinimumRingSize: 1024},
+			},
+			want: &RingHashConfig{MinimumRingSize: 1024},
+		},
+		"minimum greater than maximum": {
+			input: &MeshConfigEntry{
+				DefaultRingHashConfig: &RingHashConfig{MinimumRingSize: 8192, MaximumRingSize: 1024},
+			},
+			want:        &RingHashConfig{MinimumRingSize: 8192, MaximumRingSize: 1024},
+			validateErr: "MinimumRingSize must not be greater than MaximumRingSize",
+		},
+		"header name": {
+			input: &MeshConfigEntry{
+				DefaultRingHashConfig: &RingHashConfig{HeaderName: "x-session-id"},
+			},
+			want:        &RingHashConfig{HeaderName: "x-session-id"},
+			validateErr: "DefaultRingHashConfig does not support HeaderName",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.DefaultRingHashSizes())
+			if tc.input == nil {
+				return
+			}
+			err := tc.input.Validate()
+			if tc.validateErr != "" {
+				assert.ErrorContains(t, err, tc.validateErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfigEntry_DefaultHTTPIdleTimeout(t *testing.T) {
+	tests := map[string]struct {
+		input       *MeshConfigEntry
+		want        time.Duration
+		validateErr string
+	}{
+		"nil entry": {
+			input: nil,
+			want:  0,
+		},
+		"unset": {
+			input: &MeshConfigEntry{},
+			want:  0,
+		},
+		"set": {
+			input: &MeshConfigEntry{
+				HTTPIdleTimeout: DurationPointer(2 * time.Minute),
+			},
+			want: 2 * time.Minute,
+		},
+		"zero": {
+			input: &MeshConfigEntry{
+				HTTPIdleTimeout: DurationPointer(0),
+			},
+			want:        0,
+			validateErr: "HTTPIdleTimeout must be greater than 0",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.DefaultHTTPIdleTimeout())
+			if tc.input == nil {
+				return
+			}
+			err := tc.input.Validate()
+			if tc.validateErr != "" {
+				assert.ErrorContains(t, err, tc.validateErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfigEntry_MarshalJSONDurations(t *testing.T) {
+	entry := &MeshConfigEntry{
+		ConnectTimeout:  DurationPointer(12 * time.Second),
+		HTTPIdleTimeout: DurationPointer(5 * time.Minute),
+	}
+
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "12s", raw["ConnectTimeout"])
+	assert.Equal(t, "5m0s", raw["HTTPIdleTimeout"])
+
+	var decoded MeshConfigEntry
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, entry, &decoded)
+}
@@ -286,6 +286,30 @@ func TestDecodeConfigEntry_ServiceDefaults(t *testing.T) {
 				MaxInboundConnections: 14,
 			},
 		},
+		{
+			// PROXY protocol is configured on terminating gateway linked
+			// services, since mesh proxies don't accept the header.
+			name: "service-defaults-with-upstream-proxy-protocol",
+			snake: `
+				kind = "service-defaults"
+				name = "web"
+				upstream_config {
+					defaults {
+						envoy_upstream_proxy_protocol_version = "v2"
+					}
+				}
+			`,
+			camel: `
+				Kind = "service-defaults"
+				Name = "web"
+				UpstreamConfig {
+					Defaults {
+						ProxyProtocolVersion = "v2"
+					}
+				}
+			`,
+			expectErr: "invalid config key",
+		},
 	} {
 		tc := tc
 
@@ -2161,14 +2185,23 @@ func TestDecodeConfigEntry(t *testing.T) {
 							"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
 							"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"
 						]
+						alpn_protocols = ["h2", "http/1.1"]
 					}
 				}
 				http {
 					sanitize_x_forwarded_client_cert = true
+					response_headers_to_remove = ["Server", "X-Powered-By"]
 				}
 				peering {
 					peer_through_mesh_gateways = true
 				}
+				connect_timeout = "12s"
+				per_connection_buffer_limit_bytes = 32768
+				tcp_keepalive {
+					keepalive_time = 60
+					keepalive_interval = 10
+					keepalive_probes = 3
+				}
 			`,
 			camel: `
 				Kind = "mesh"
@@ -2196,14 +2229,23 @@ func TestDecodeConfigEntry(t *testing.T) {
 							"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
 							"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"
 						]
+						ALPNProtocols = ["h2", "http/1.1"]
 					}
 				}
 				HTTP {
 					SanitizeXForwardedClientCert = true
+					ResponseHeadersToRemove = ["Server", "X-Powered-By"]
 				}
 				Peering {
 					PeerThroughMeshGateways = true
 				}
+				ConnectTimeout = "12s"
+				PerConnectionBufferLimitBytes = 32768
+				TCPKeepalive {
+					Time = 60
+					Interval = 10
+					Probes = 3
+				}
 			`,
 			expect: &MeshConfigEntry{
 				Meta: map[string]string{
@@ -2230,14 +2272,23 @@ func TestDecodeConfigEntry(t *testing.T) {
 							types.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
 							types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
 						},
+						ALPNProtocols: []string{"h2", "http/1.1"},
 					},
 				},
 				HTTP: &MeshHTTPConfig{
 					SanitizeXForwardedClientCert: true,
+					ResponseHeadersToRemove:      []string{"Server", "X-Powered-By"},
 				},
 				Peering: &PeeringMeshConfig{
 					PeerThroughMeshGateways: true,
 				},
+				ConnectTimeout:                DurationPointer(12 * time.Second),
+				PerConnectionBufferLimitBytes: uintPointer(32768),
+				TCPKeepalive: &TCPKeepaliveConfig{
+					Time:     60,
+					Interval: 10,
+					Probes:   3,
+				},
 			},
 		},
 		{
@@ -2711,6 +2762,92 @@ func TestPassiveHealthCheck_Validate(t *testing.T) {
 			wantErr: true,
 			wantMsg: "cannot be negative",
 		},
+		{
+			name: "valid consecutive_local_origin_failure",
+			input: PassiveHealthCheck{
+				SplitExternalLocalOriginErrors: true,
+				ConsecutiveLocalOriginFailure:  uintPointer(3),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "consecutive_local_origin_failure without split mode",
+			input:   PassiveHealthCheck{ConsecutiveLocalOriginFailure: uintPointer(3)},
+			wantErr: true,
+			wantMsg: "requires split_external_local_origin_errors",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			if err == nil {
+				require.False(t, tc.wantErr)
+				return
+			}
+			require.Contains(t, err.Error(), tc.wantMsg)
+		})
+	}
+}
+
+func TestActiveHealthCheck_Validate(t *testing.T) {
+	tt := []struct {
+		name    string
+		input   ActiveHealthCheck
+		wantErr bool
+		wantMsg string
+	}{
+		{
+			name:    "valid http with path",
+			input:   ActiveHealthCheck{Type: "http", Path: "/health"},
+			wantErr: false,
+		},
+		{
+			name:    "valid tcp",
+			input:   ActiveHealthCheck{Type: "tcp", Interval: 5 * time.Second, Timeout: time.Second},
+			wantErr: false,
+		},
+		{
+			name:    "valid grpc",
+			input:   ActiveHealthCheck{Type: "grpc", UnhealthyThreshold: 3, HealthyThreshold: 1},
+			wantErr: false,
+		},
+		{
+			name:    "missing type",
+			input:   ActiveHealthCheck{},
+			wantErr: true,
+			wantMsg: "type must be one of",
+		},
+		{
+			name:    "invalid type",
+			input:   ActiveHealthCheck{Type: "udp"},
+			wantErr: true,
+			wantMsg: "type must be one of",
+		},
+		{
+			name:    "negative interval",
+			input:   ActiveHealthCheck{Type: "tcp", Interval: -1 * time.Second},
+			wantErr: true,
+			wantMsg: "interval cannot be negative",
+		},
+		{
+			name:    "negative timeout",
+			input:   ActiveHealthCheck{Type: "tcp", Timeout: -1 * time.Second},
+			wantErr: true,
+			wantMsg: "timeout cannot be negative",
+		},
+		{
+			name:    "path on tcp",
+			input:   ActiveHealthCheck{Type: "tcp", Path: "/health"},
+			wantErr: true,
+			wantMsg: "path is only supported",
+		},
+		{
+			name:    "relative path",
+			input:   ActiveHealthCheck{Type: "http", Path: "health"},
+			wantErr: true,
+			wantMsg: "must begin with a '/'",
+		},
 	}
 
 	for _, tc := range tt {
@@ -2720,6 +2857,7 @@ func TestPassiveHealthCheck_Validate(t *testing.T) {
 				require.False(t, tc.wantErr)
 				return
 			}
+			require.True(t, tc.wantErr, err.Error())
 			require.Contains(t, err.Error(), tc.wantMsg)
 		})
 	}
@@ -2754,6 +2892,17 @@ func TestUpstreamLimits_Validate(t *testing.T) {
 			wantErr: true,
 			wantMsg: "cannot be negative",
 		},
+		{
+			name:    "valid-max-retries",
+			input:   UpstreamLimits{MaxRetries: intPointer(0)},
+			wantErr: false,
+		},
+		{
+			name:    "negative-max-retries",
+			input:   UpstreamLimits{MaxRetries: intPointer(-1)},
+			wantErr: true,
+			wantMsg: "cannot be negative",
+		},
 		{
 			name:    "valid-max-pending",
 			input:   UpstreamLimits{MaxPendingRequests: intPointer(0)},
@@ -3141,6 +3290,51 @@ func TestServiceConfigEntry(t *testing.T) {
 			},
 			validateErr: "invalid value for balance_inbound_connections",
 		},
+		"validate: grpc transcoding": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "bookstore",
+				Protocol: "grpc",
+				GRPCTranscoding: &GRPCTranscodingConfig{
+					ProtoDescriptor: "/etc/envoy/bookstore.pb",
+					Services:        []string{"bookstore.Bookstore"},
+				},
+			},
+		},
+		"validate: grpc transcoding requires grpc protocol": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "bookstore",
+				Protocol: "http",
+				GRPCTranscoding: &GRPCTranscodingConfig{
+					ProtoDescriptor: "/etc/envoy/bookstore.pb",
+					Services:        []string{"bookstore.Bookstore"},
+				},
+			},
+			validateErr: `grpc_transcoding requires protocol "grpc", got "http"`,
+		},
+		"validate: grpc transcoding missing proto descriptor": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "bookstore",
+				Protocol: "grpc",
+				GRPCTranscoding: &GRPCTranscodingConfig{
+					Services: []string{"bookstore.Bookstore"},
+				},
+			},
+			validateErr: "proto_descriptor is required",
+		},
+		"validate: grpc transcoding missing services": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "bookstore",
+				Protocol: "grpc",
+				GRPCTranscoding: &GRPCTranscodingConfig{
+					ProtoDescriptor: "/etc/envoy/bookstore.pb",
+				},
+			},
+			validateErr: "at least one service is required",
+		},
 		"validate: invalid default outbound connection balance": {
 			entry: &ServiceConfigEntry{
 				Kind:     ServiceDefaults,
@@ -3170,144 +3364,558 @@ func TestServiceConfigEntry(t *testing.T) {
 			},
 			validateErr: "invalid value for balance_outbound_connections",
 		},
-		"validate: invalid extension": {
+		"validate: invalid default max header count": {
 			entry: &ServiceConfigEntry{
 				Kind:     ServiceDefaults,
 				Name:     "external",
 				Protocol: "http",
-				EnvoyExtensions: []EnvoyExtension{
-					{},
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						MaxHeaderCount: -1,
+					},
 				},
 			},
-			validateErr: "invalid EnvoyExtensions[0]: Name is required",
+			validateErr: "envoy_upstream_max_header_count must be a non-negative integer",
 		},
-		"validate: invalid extension name": {
+		"validate: zero default max header count": {
 			entry: &ServiceConfigEntry{
 				Kind:     ServiceDefaults,
 				Name:     "external",
 				Protocol: "http",
-				EnvoyExtensions: []EnvoyExtension{
-					{
-						Name: "not-a-builtin",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						MaxHeaderCount: 0,
 					},
 				},
 			},
-			validateErr: `name "not-a-builtin" is not a built-in extension`,
 		},
-		"validate: valid extension": {
+		"validate: invalid default header key format": {
 			entry: &ServiceConfigEntry{
 				Kind:     ServiceDefaults,
 				Name:     "external",
 				Protocol: "http",
-				EnvoyExtensions: []EnvoyExtension{
-					{
-						Name: api.BuiltinAWSLambdaExtension,
-						Arguments: map[string]interface{}{
-							"ARN": "some-arn",
-						},
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						HeaderKeyFormat: "upper_case",
 					},
 				},
 			},
+			validateErr: `invalid value for envoy_upstream_header_key_format: "upper_case"`,
 		},
-		"validate: invalid MutualTLSMode in service-defaults": {
+		"validate: invalid default cluster stats prefix": {
 			entry: &ServiceConfigEntry{
-				Kind:          ServiceDefaults,
-				Name:          "web",
-				MutualTLSMode: MutualTLSMode("invalid-mtls-mode"),
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "tcp",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ClusterStatsPrefix: "db.upstream",
+					},
+				},
 			},
-			validateErr: `Invalid MutualTLSMode "invalid-mtls-mode". Must be one of "", "strict", or "permissive".`,
+			validateErr: `envoy_cluster_stats_prefix must not contain dots, got "db.upstream"`,
 		},
-		"validate: invalid MutualTLSMode in proxy-defaults": {
+		"validate: invalid default alpn protocols": {
 			entry: &ServiceConfigEntry{
-				Kind:          ProxyDefaults,
-				Name:          ProxyConfigGlobal,
-				MutualTLSMode: MutualTLSMode("invalid-mtls-mode"),
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "tcp",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ALPNProtocols: []string{"h2", ""},
+					},
+				},
 			},
-			validateErr: `Invalid MutualTLSMode "invalid-mtls-mode". Must be one of "", "strict", or "permissive".`,
+			validateErr: `invalid envoy_upstream_alpn_protocols: ALPN protocol "" must be between 1 and 255 bytes long`,
 		},
-	}
-	testConfigEntryNormalizeAndValidate(t, cases)
-}
-
-func TestUpstreamConfig_MergeInto(t *testing.T) {
-	tt := []struct {
-		name        string
-		source      UpstreamConfig
-		destination map[string]interface{}
-		want        map[string]interface{}
-	}{
-		{
-			name: "kitchen sink",
-			source: UpstreamConfig{
-				BalanceOutboundConnections: "exact_balance",
-				EnvoyListenerJSON:          "foo",
-				EnvoyClusterJSON:           "bar",
-				ConnectTimeoutMs:           5,
-				Protocol:                   "http",
-				Limits: &UpstreamLimits{
-					MaxConnections:        intPointer(3),
-					MaxPendingRequests:    intPointer(4),
-					MaxConcurrentRequests: intPointer(5),
-				},
-				PassiveHealthCheck: &PassiveHealthCheck{
-					Interval:                2 * time.Second,
-					MaxFailures:             3,
-					EnforcingConsecutive5xx: uintPointer(4),
-					MaxEjectionPercent:      uintPointer(5),
-					BaseEjectionTime:        durationPointer(6 * time.Second),
+		"validate: invalid default sni": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "tcp",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						SNI: "db tenant-a",
+					},
 				},
-				MeshGateway: MeshGatewayConfig{Mode: MeshGatewayModeRemote},
 			},
-			destination: make(map[string]interface{}),
-			want: map[string]interface{}{
-				"balance_outbound_connections": "exact_balance",
-				"envoy_listener_json":          "foo",
-				"envoy_cluster_json":           "bar",
-				"connect_timeout_ms":           5,
-				"protocol":                     "http",
-				"limits": &UpstreamLimits{
-					MaxConnections:        intPointer(3),
-					MaxPendingRequests:    intPointer(4),
-					MaxConcurrentRequests: intPointer(5),
-				},
-				"passive_health_check": &PassiveHealthCheck{
-					Interval:                2 * time.Second,
-					MaxFailures:             3,
-					EnforcingConsecutive5xx: uintPointer(4),
-					MaxEjectionPercent:      uintPointer(5),
-					BaseEjectionTime:        durationPointer(6 * time.Second),
+			validateErr: `envoy_upstream_sni must not contain whitespace, got "db tenant-a"`,
+		},
+		"validate: too long default sni": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "tcp",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						SNI: strings.Repeat("a", 256),
+					},
 				},
-				"mesh_gateway": MeshGatewayConfig{Mode: MeshGatewayModeRemote},
 			},
+			validateErr: "envoy_upstream_sni must be at most 255 bytes",
 		},
-		{
-			name: "kitchen sink override of destination",
-			source: UpstreamConfig{
-				BalanceOutboundConnections: "exact_balance",
-				EnvoyListenerJSON:          "foo",
-				EnvoyClusterJSON:           "bar",
-				ConnectTimeoutMs:           5,
-				Protocol:                   "http",
-				Limits: &UpstreamLimits{
-					MaxConnections:        intPointer(3),
-					MaxPendingRequests:    intPointer(4),
-					MaxConcurrentRequests: intPointer(5),
+		"validate: empty default cluster metadata key": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "tcp",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ClusterMetadata: map[string]string{"": "v2"},
+					},
 				},
-				PassiveHealthCheck: &PassiveHealthCheck{
-					Interval:                2 * time.Second,
-					MaxFailures:             3,
-					EnforcingConsecutive5xx: uintPointer(4),
-					MaxEjectionPercent:      uintPointer(5),
-					BaseEjectionTime:        durationPointer(6 * time.Second),
+			},
+			validateErr: "envoy_cluster_metadata keys must not be empty",
+		},
+		"validate: invalid default retry condition": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						RetryPolicy: &UpstreamRetryPolicy{RetryOn: "5xx,sometimes"},
+					},
 				},
-				MeshGateway: MeshGatewayConfig{Mode: MeshGatewayModeRemote},
 			},
-			destination: map[string]interface{}{
-				"balance_outbound_connections": "",
-				"envoy_listener_json":          "zip",
-				"envoy_cluster_json":           "zap",
-				"connect_timeout_ms":           10,
-				"protocol":                     "grpc",
+			validateErr: `invalid envoy_upstream_retry_policy: invalid retry condition "sometimes"`,
+		},
+		"validate: default retries without retry condition": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						RetryPolicy: &UpstreamRetryPolicy{NumRetries: 3},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_retry_policy: num_retries requires retry_on or retriable_status_codes to be set",
+		},
+		"validate: invalid default retriable status code": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						RetryPolicy: &UpstreamRetryPolicy{RetriableStatusCodes: []uint32{503, 700}},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_retry_policy: retriable status code 700 must be between 100 and 599",
+		},
+		"validate: negative default per try timeout": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						RetryPolicy: &UpstreamRetryPolicy{PerTryTimeout: durationPointer(-time.Second)},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_retry_policy: per_try_timeout must not be negative, got -1s",
+		},
+		"validate: invalid default cluster warm up duration": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ClusterWarmUpDurationMs: -1,
+					},
+				},
+			},
+			validateErr: "envoy_cluster_warm_up_duration_ms must be a positive integer",
+		},
+		"validate: http2 initial stream window size too small": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "grpc",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						HTTP2InitialStreamWindowSize: 65534,
+					},
+				},
+			},
+			validateErr: "envoy_upstream_http2_initial_stream_window_size must be between 65535 and 2147483647, got 65534",
+		},
+		"validate: http2 initial connection window size too small": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "grpc",
+				UpstreamConfig: &UpstreamConfiguration{
+					Overrides: []*UpstreamConfig{
+						{
+							Name:                             "redis",
+							HTTP2InitialConnectionWindowSize: 1024,
+						},
+					},
+				},
+			},
+			validateErr: "envoy_upstream_http2_initial_connection_window_size must be between 65535 and 2147483647, got 1024",
+		},
+		"validate: invalid default max stream duration": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "grpc",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						MaxStreamDurationMs: -1,
+					},
+				},
+			},
+			validateErr: "envoy_upstream_max_stream_duration_ms must be a positive integer",
+		},
+		"validate: invalid default idle timeout": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						IdleTimeout: durationPointer(-1 * time.Second),
+					},
+				},
+			},
+			validateErr: "envoy_upstream_idle_timeout must be a positive duration",
+		},
+		"validate: invalid default warmup timeout": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						WarmupTimeout: durationPointer(-1 * time.Second),
+					},
+				},
+			},
+			validateErr: "envoy_cluster_warmup_timeout must be a positive duration",
+		},
+		"validate: invalid default max requests per connection": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						MaxRequestsPerConnection: uintPointer(0),
+					},
+				},
+			},
+			validateErr: "envoy_upstream_max_requests_per_connection must be greater than 0",
+		},
+		"validate: invalid default active health check": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ActiveHealthCheck: &ActiveHealthCheck{Type: "tcp", Path: "/health"},
+					},
+				},
+			},
+			validateErr: "active health check path is only supported for \"http\" checks",
+		},
+		"validate: invalid default local address": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalAddress: "not-an-ip",
+					},
+				},
+			},
+			validateErr: `envoy_upstream_local_address must be a valid IP address, got "not-an-ip"`,
+		},
+		"validate: local port out of range": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalAddress: "10.0.0.5",
+						LocalPort:    70000,
+					},
+				},
+			},
+			validateErr: "envoy_upstream_local_port must be between 0 and 65535, got 70000",
+		},
+		"validate: local port without local address": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalPort: 9000,
+					},
+				},
+			},
+			validateErr: "envoy_upstream_local_port requires envoy_upstream_local_address to be set",
+		},
+		"validate: extra source address without local address": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						ExtraSourceAddresses: []UpstreamSourceAddress{{Address: "2001:db8::5"}},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_extra_source_addresses: requires envoy_upstream_local_address to be set",
+		},
+		"validate: too many extra source addresses": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalAddress: "10.0.0.5",
+						ExtraSourceAddresses: []UpstreamSourceAddress{
+							{Address: "2001:db8::5"},
+							{Address: "2001:db8::6"},
+						},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_extra_source_addresses: at most one extra source address may be given, got 2",
+		},
+		"validate: extra source address of the same ip family": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalAddress:         "10.0.0.5",
+						ExtraSourceAddresses: []UpstreamSourceAddress{{Address: "10.0.0.6"}},
+					},
+				},
+			},
+			validateErr: `invalid envoy_upstream_extra_source_addresses: address "10.0.0.6" must be of a different IP family than envoy_upstream_local_address "10.0.0.5"`,
+		},
+		"validate: extra source address port out of range": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				UpstreamConfig: &UpstreamConfiguration{
+					Defaults: &UpstreamConfig{
+						LocalAddress:         "10.0.0.5",
+						ExtraSourceAddresses: []UpstreamSourceAddress{{Address: "2001:db8::5", Port: 70000}},
+					},
+				},
+			},
+			validateErr: "invalid envoy_upstream_extra_source_addresses: port must be between 0 and 65535, got 70000",
+		},
+		"validate: invalid extension": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				EnvoyExtensions: []EnvoyExtension{
+					{},
+				},
+			},
+			validateErr: "invalid EnvoyExtensions[0]: Name is required",
+		},
+		"validate: invalid extension name": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				EnvoyExtensions: []EnvoyExtension{
+					{
+						Name: "not-a-builtin",
+					},
+				},
+			},
+			validateErr: `name "not-a-builtin" is not a built-in extension`,
+		},
+		"validate: valid extension": {
+			entry: &ServiceConfigEntry{
+				Kind:     ServiceDefaults,
+				Name:     "external",
+				Protocol: "http",
+				EnvoyExtensions: []EnvoyExtension{
+					{
+						Name: api.BuiltinAWSLambdaExtension,
+						Arguments: map[string]interface{}{
+							"ARN": "some-arn",
+						},
+					},
+				},
+			},
+		},
+		"validate: invalid MutualTLSMode in service-defaults": {
+			entry: &ServiceConfigEntry{
+				Kind:          ServiceDefaults,
+				Name:          "web",
+				MutualTLSMode: MutualTLSMode("invalid-mtls-mode"),
+			},
+			validateErr: `Invalid MutualTLSMode "invalid-mtls-mode". Must be one of "", "strict", or "permissive".`,
+		},
+		"validate: invalid MutualTLSMode in proxy-defaults": {
+			entry: &ServiceConfigEntry{
+				Kind:          ProxyDefaults,
+				Name:          ProxyConfigGlobal,
+				MutualTLSMode: MutualTLSMode("invalid-mtls-mode"),
+			},
+			validateErr: `Invalid MutualTLSMode "invalid-mtls-mode". Must be one of "", "strict", or "permissive".`,
+		},
+	}
+	testConfigEntryNormalizeAndValidate(t, cases)
+}
+
+func TestUpstreamConfig_MergeInto(t *testing.T) {
+	tt := []struct {
+		name        string
+		source      UpstreamConfig
+		destination map[string]interface{}
+		want        map[string]interface{}
+	}{
+		{
+			name: "kitchen sink",
+			source: UpstreamConfig{
+				BalanceOutboundConnections: "exact_balance",
+				EnvoyListenerJSON:          "foo",
+				EnvoyClusterJSON:           "bar",
+				ConnectTimeoutMs:           5,
+				Protocol:                   "http",
+				Limits: &UpstreamLimits{
+					MaxConnections:        intPointer(3),
+					MaxPendingRequests:    intPointer(4),
+					MaxConcurrentRequests: intPointer(5),
+				},
+				PassiveHealthCheck: &PassiveHealthCheck{
+					Interval:                2 * time.Second,
+					MaxFailures:             3,
+					EnforcingConsecutive5xx: uintPointer(4),
+					MaxEjectionPercent:      uintPointer(5),
+					BaseEjectionTime:        durationPointer(6 * time.Second),
+				},
+				ActiveHealthCheck: &ActiveHealthCheck{
+					Type: "http",
+					Path: "/health",
+				},
+				MeshGateway:                      MeshGatewayConfig{Mode: MeshGatewayModeRemote},
+				MaxHeaderCount:                   64,
+				ClusterWarmUpDurationMs:          30000,
+				WarmupTimeout:                    durationPointer(15 * time.Second),
+				WaitForWarmOnInit:                boolPointer(false),
+				HTTP2InitialStreamWindowSize:     1048576,
+				HTTP2InitialConnectionWindowSize: 4194304,
+				HTTP2OverrideStreamError:         true,
+				HTTPVersionAuto:                  true,
+				MaxStreamDurationMs:              60000,
+				IdleTimeout:                      durationPointer(90 * time.Second),
+				MaxRequestsPerConnection:         uintPointer(1000),
+				LocalAddress:                     "10.0.0.5",
+				LocalPort:                        9000,
+				ExtraSourceAddresses:             []UpstreamSourceAddress{{Address: "2001:db8::5"}},
+				TCPFastOpen:                      true,
+				HeaderKeyFormat:                  "proper_case_words",
+				TCPKeepalive:                     &TCPKeepaliveConfig{Time: 30},
+				PerDownstreamConnectionPool:      true,
+				ClusterStatsPrefix:               "db_upstream",
+				ALPNProtocols:                    []string{"h2", "http/1.1"},
+				SNI:                              "db.tenant-a.example.com",
+				ClusterMetadata:                  map[string]string{"version": "v2"},
+				RetryPolicy:                      &UpstreamRetryPolicy{NumRetries: 3, RetryOn: "5xx"},
+			},
+			destination: make(map[string]interface{}),
+			want: map[string]interface{}{
+				"envoy_upstream_max_header_count":                     64,
+				"envoy_cluster_warm_up_duration_ms":                   30000,
+				"envoy_cluster_warmup_timeout":                        15 * time.Second,
+				"envoy_cluster_wait_for_warm_on_init":                 false,
+				"envoy_upstream_http2_initial_stream_window_size":     1048576,
+				"envoy_upstream_http2_initial_connection_window_size": 4194304,
+				"envoy_upstream_override_stream_error":                true,
+				"envoy_upstream_http_version_auto":                    true,
+				"envoy_upstream_max_stream_duration_ms":               60000,
+				"envoy_upstream_idle_timeout":                         90 * time.Second,
+				"envoy_upstream_max_requests_per_connection":          uint32(1000),
+				"envoy_upstream_local_address":                        "10.0.0.5",
+				"envoy_upstream_local_port":                           9000,
+				"envoy_upstream_extra_source_addresses":               []UpstreamSourceAddress{{Address: "2001:db8::5"}},
+				"envoy_upstream_tcp_fast_open":                        true,
+				"envoy_upstream_header_key_format":                    "proper_case_words",
+				"envoy_upstream_tcp_keepalive":                        &TCPKeepaliveConfig{Time: 30},
+				"envoy_upstream_per_downstream_connection_pool":       true,
+				"envoy_cluster_stats_prefix":                          "db_upstream",
+				"envoy_upstream_alpn_protocols":                       []string{"h2", "http/1.1"},
+				"envoy_upstream_sni":                                  "db.tenant-a.example.com",
+				"envoy_cluster_metadata":                              map[string]string{"version": "v2"},
+				"envoy_upstream_retry_policy":                         &UpstreamRetryPolicy{NumRetries: 3, RetryOn: "5xx"},
+				"balance_outbound_connections":                        "exact_balance",
+				"envoy_listener_json":                                 "foo",
+				"envoy_cluster_json":                                  "bar",
+				"connect_timeout_ms":                                  5,
+				"protocol":                                            "http",
+				"limits": &UpstreamLimits{
+					MaxConnections:        intPointer(3),
+					MaxPendingRequests:    intPointer(4),
+					MaxConcurrentRequests: intPointer(5),
+				},
+				"passive_health_check": &PassiveHealthCheck{
+					Interval:                2 * time.Second,
+					MaxFailures:             3,
+					EnforcingConsecutive5xx: uintPointer(4),
+					MaxEjectionPercent:      uintPointer(5),
+					BaseEjectionTime:        durationPointer(6 * time.Second),
+				},
+				"active_health_check": &ActiveHealthCheck{
+					Type: "http",
+					Path: "/health",
+				},
+				"mesh_gateway": MeshGatewayConfig{Mode: MeshGatewayModeRemote},
+			},
+		},
+		{
+			name: "kitchen sink override of destination",
+			source: UpstreamConfig{
+				BalanceOutboundConnections: "exact_balance",
+				EnvoyListenerJSON:          "foo",
+				EnvoyClusterJSON:           "bar",
+				ConnectTimeoutMs:           5,
+				Protocol:                   "http",
+				Limits: &UpstreamLimits{
+					MaxConnections:        intPointer(3),
+					MaxPendingRequests:    intPointer(4),
+					MaxConcurrentRequests: intPointer(5),
+				},
+				PassiveHealthCheck: &PassiveHealthCheck{
+					Interval:                2 * time.Second,
+					MaxFailures:             3,
+					EnforcingConsecutive5xx: uintPointer(4),
+					MaxEjectionPercent:      uintPointer(5),
+					BaseEjectionTime:        durationPointer(6 * time.Second),
+				},
+				MeshGateway: MeshGatewayConfig{Mode: MeshGatewayModeRemote},
+			},
+			destination: map[string]interface{}{
+				"balance_outbound_connections": "",
+				"envoy_listener_json":          "zip",
+				"envoy_cluster_json":           "zap",
+				"connect_timeout_ms":           10,
+				"protocol":                     "grpc",
 				"limits": &UpstreamLimits{
 					MaxConnections:        intPointer(10),
 					MaxPendingRequests:    intPointer(11),
@@ -3445,6 +4053,272 @@ func TestParseUpstreamConfig(t *testing.T) {
 				Protocol:         "http",
 			},
 		},
+		{
+			name: "max header count",
+			input: map[string]interface{}{
+				"protocol":                        "http",
+				"envoy_upstream_max_header_count": 64,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "http",
+				MaxHeaderCount:   64,
+			},
+		},
+		{
+			name: "cluster warm up duration",
+			input: map[string]interface{}{
+				"envoy_cluster_warm_up_duration_ms": 30000,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:        5000,
+				Protocol:                "tcp",
+				ClusterWarmUpDurationMs: 30000,
+			},
+		},
+		{
+			name: "http2 window sizes",
+			input: map[string]interface{}{
+				"protocol": "grpc",
+				"envoy_upstream_http2_initial_stream_window_size":     1048576,
+				"envoy_upstream_http2_initial_connection_window_size": "4194304",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:                 5000,
+				Protocol:                         "grpc",
+				HTTP2InitialStreamWindowSize:     1048576,
+				HTTP2InitialConnectionWindowSize: 4194304,
+			},
+		},
+		{
+			name: "http2 override stream error",
+			input: map[string]interface{}{
+				"protocol":                             "http2",
+				"envoy_upstream_override_stream_error": true,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:         5000,
+				Protocol:                 "http2",
+				HTTP2OverrideStreamError: true,
+			},
+		},
+		{
+			name: "http version auto",
+			input: map[string]interface{}{
+				"protocol":                         "http",
+				"envoy_upstream_http_version_auto": "true",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "http",
+				HTTPVersionAuto:  true,
+			},
+		},
+		{
+			name: "max stream duration",
+			input: map[string]interface{}{
+				"protocol":                              "grpc",
+				"envoy_upstream_max_stream_duration_ms": 60000,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:    5000,
+				Protocol:            "grpc",
+				MaxStreamDurationMs: 60000,
+			},
+		},
+		{
+			name: "idle timeout",
+			input: map[string]interface{}{
+				"protocol":                    "http",
+				"envoy_upstream_idle_timeout": "90s",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "http",
+				IdleTimeout:      durationPointer(90 * time.Second),
+			},
+		},
+		{
+			name: "cluster warming",
+			input: map[string]interface{}{
+				"envoy_cluster_warmup_timeout":        "0s",
+				"envoy_cluster_wait_for_warm_on_init": false,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:  5000,
+				Protocol:          "tcp",
+				WarmupTimeout:     durationPointer(0),
+				WaitForWarmOnInit: boolPointer(false),
+			},
+		},
+		{
+			name: "max requests per connection",
+			input: map[string]interface{}{
+				"protocol": "http",
+				"envoy_upstream_max_requests_per_connection": 1000,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:         5000,
+				Protocol:                 "http",
+				MaxRequestsPerConnection: uintPointer(1000),
+			},
+		},
+		{
+			name: "local address, bracketed ipv6",
+			input: map[string]interface{}{
+				"envoy_upstream_local_address": "[2001:db8::5]",
+				"envoy_upstream_local_port":    "9000",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				LocalAddress:     "2001:db8::5",
+				LocalPort:        9000,
+			},
+		},
+		{
+			name: "extra source addresses",
+			input: map[string]interface{}{
+				"envoy_upstream_local_address": "10.0.0.5",
+				"envoy_upstream_extra_source_addresses": []interface{}{
+					map[string]interface{}{
+						"address": "[2001:db8::5]",
+						"port":    "9001",
+					},
+				},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				LocalAddress:     "10.0.0.5",
+				ExtraSourceAddresses: []UpstreamSourceAddress{
+					{Address: "2001:db8::5", Port: 9001},
+				},
+			},
+		},
+		{
+			name: "tcp fast open, string",
+			input: map[string]interface{}{
+				"envoy_upstream_tcp_fast_open": "true",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				TCPFastOpen:      true,
+			},
+		},
+		{
+			name: "per downstream connection pool",
+			input: map[string]interface{}{
+				"envoy_upstream_per_downstream_connection_pool": true,
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:            5000,
+				Protocol:                    "tcp",
+				PerDownstreamConnectionPool: true,
+			},
+		},
+		{
+			name: "cluster stats prefix",
+			input: map[string]interface{}{
+				"envoy_cluster_stats_prefix": "db_upstream",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs:   5000,
+				Protocol:           "tcp",
+				ClusterStatsPrefix: "db_upstream",
+			},
+		},
+		{
+			name: "alpn protocols",
+			input: map[string]interface{}{
+				"envoy_upstream_alpn_protocols": []interface{}{"h2", "http/1.1"},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				ALPNProtocols:    []string{"h2", "http/1.1"},
+			},
+		},
+		{
+			name: "sni",
+			input: map[string]interface{}{
+				"envoy_upstream_sni": "db.tenant-a.example.com",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				SNI:              "db.tenant-a.example.com",
+			},
+		},
+		{
+			name: "cluster metadata",
+			input: map[string]interface{}{
+				"envoy_cluster_metadata": map[string]interface{}{
+					"version": "v2",
+					"stage":   "canary",
+				},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				ClusterMetadata: map[string]string{
+					"version": "v2",
+					"stage":   "canary",
+				},
+			},
+		},
+		{
+			name: "retry policy",
+			input: map[string]interface{}{
+				"envoy_upstream_retry_policy": map[string]interface{}{
+					"num_retries":            "3",
+					"retry_on":               "5xx,reset",
+					"retriable_status_codes": []interface{}{503, 504},
+					"per_try_timeout":        "2s",
+				},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				RetryPolicy: &UpstreamRetryPolicy{
+					NumRetries:           3,
+					RetryOn:              "5xx,reset",
+					RetriableStatusCodes: []uint32{503, 504},
+					PerTryTimeout:        durationPointer(2 * time.Second),
+				},
+			},
+		},
+		{
+			name: "header key format",
+			input: map[string]interface{}{
+				"envoy_upstream_header_key_format": "proper_case_words",
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				HeaderKeyFormat:  "proper_case_words",
+			},
+		},
+		{
+			name: "tcp keepalive",
+			input: map[string]interface{}{
+				"envoy_upstream_tcp_keepalive": map[string]interface{}{
+					"keepalive_time":     60,
+					"keepalive_interval": 10,
+					"keepalive_probes":   3,
+				},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				Protocol:         "tcp",
+				TCPKeepalive: &TCPKeepaliveConfig{
+					Time:     60,
+					Interval: 10,
+					Probes:   3,
+				},
+			},
+		},
 		{
 			name: "connect timeout override, string",
 			input: map[string]interface{}{
@@ -3482,6 +4356,7 @@ func TestParseUpstreamConfig(t *testing.T) {
 					"max_connections":         50,
 					"max_pending_requests":    60,
 					"max_concurrent_requests": 70,
+					"max_retries":             80,
 				},
 			},
 			want: UpstreamConfig{
@@ -3490,6 +4365,7 @@ func TestParseUpstreamConfig(t *testing.T) {
 					MaxConnections:        intPointer(50),
 					MaxPendingRequests:    intPointer(60),
 					MaxConcurrentRequests: intPointer(70),
+					MaxRetries:            intPointer(80),
 				},
 				Protocol: "tcp",
 			},
@@ -3536,6 +4412,31 @@ func TestParseUpstreamConfig(t *testing.T) {
 				Protocol: "tcp",
 			},
 		},
+		{
+			name: "active health check map",
+			input: map[string]interface{}{
+				"active_health_check": map[string]interface{}{
+					"type":                "http",
+					"interval":            "15s",
+					"timeout":             "2s",
+					"unhealthy_threshold": 4,
+					"healthy_threshold":   1,
+					"path":                "/health",
+				},
+			},
+			want: UpstreamConfig{
+				ConnectTimeoutMs: 5000,
+				ActiveHealthCheck: &ActiveHealthCheck{
+					Type:               "http",
+					Interval:           15 * time.Second,
+					Timeout:            2 * time.Second,
+					UnhealthyThreshold: 4,
+					HealthyThreshold:   1,
+					Path:               "/health",
+				},
+				Protocol: "tcp",
+			},
+		},
 		{
 			name: "mesh gateway map",
 			input: map[string]interface{}{
@@ -3658,6 +4559,101 @@ func TestProxyConfigEntry(t *testing.T) {
 			},
 			validateErr: "invalid access log json for JSON format",
 		},
+		"proxy config has invalid access log filter - no filter type set": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled:          true,
+					AccessLogFilters: []AccessLogFilter{{}},
+				},
+			},
+			validateErr: "exactly one of status_code_range, grpc_status_code or runtime_filter must be set",
+		},
+		"proxy config has invalid access log filter - two filter types set": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{{
+						StatusCodeRange: &AccessLogStatusCodeRange{Min: 400},
+						RuntimeFilter:   &AccessLogRuntimeFilter{RuntimeKey: "access_log.sample", PercentSampled: 10},
+					}},
+				},
+			},
+			validateErr: "exactly one of status_code_range, grpc_status_code or runtime_filter must be set",
+		},
+		"proxy config has invalid access log filter - inverted status code range": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{{
+						StatusCodeRange: &AccessLogStatusCodeRange{Min: 500, Max: 400},
+					}},
+				},
+			},
+			validateErr: "min 500 must not be greater than max 400",
+		},
+		"proxy config has invalid access log filter - status code out of range": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{{
+						StatusCodeRange: &AccessLogStatusCodeRange{Max: 600},
+					}},
+				},
+			},
+			validateErr: "status code 600 must be between 100 and 599",
+		},
+		"proxy config has invalid access log filter - unknown grpc status": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{{
+						GRPCStatusCode: &AccessLogGRPCStatusFilter{Statuses: []string{"CANCELLED"}},
+					}},
+				},
+			},
+			validateErr: `unknown gRPC status "CANCELLED"`,
+		},
+		"proxy config has invalid access log filter - runtime filter without key": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{{
+						RuntimeFilter: &AccessLogRuntimeFilter{PercentSampled: 10},
+					}},
+				},
+			},
+			validateErr: "runtime_key must be set",
+		},
+		"proxy config with valid access log filters": {
+			entry: &ProxyConfigEntry{
+				Name: "global",
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{
+						{StatusCodeRange: &AccessLogStatusCodeRange{Min: 400, Max: 599}},
+						{GRPCStatusCode: &AccessLogGRPCStatusFilter{Statuses: []string{"OK"}, Exclude: true}},
+					},
+				},
+			},
+			expected: &ProxyConfigEntry{
+				Name: ProxyConfigGlobal,
+				Kind: ProxyDefaults,
+				AccessLogs: AccessLogsConfig{
+					Enabled: true,
+					AccessLogFilters: []AccessLogFilter{
+						{StatusCodeRange: &AccessLogStatusCodeRange{Min: 400, Max: 599}},
+						{GRPCStatusCode: &AccessLogGRPCStatusFilter{Statuses: []string{"OK"}, Exclude: true}},
+					},
+				},
+				EnterpriseMeta: *acl.DefaultEnterpriseMeta(),
+			},
+		},
 	}
 	testConfigEntryNormalizeAndValidate(t, cases)
 }
@@ -3816,6 +4812,10 @@ func durationPointer(d time.Duration) *time.Duration {
 	return &d
 }
 
+func boolPointer(b bool) *bool {
+	return &b
+}
+
 func TestValidateOpaqueConfigMap(t *testing.T) {
 	tt := map[string]struct {
 		input     map[string]interface{}
@@ -179,6 +179,134 @@ type AccessLogsConfig struct {
 	// Defining Both is invalid.
 	JSONFormat string `json:",omitempty" alias:"json_format"`
 	TextFormat string `json:",omitempty" alias:"text_format"`
+
+	// AccessLogFilters restricts which requests are logged. A request is only
+	// logged when it matches every filter. Filters do not apply to listener
+	// logs for rejected connections.
+	AccessLogFilters []AccessLogFilter `json:",omitempty" alias:"access_log_filters"`
+}
+
+// AccessLogFilter is a single access log filter. Exactly one of its fields
+// must be set.
+type AccessLogFilter struct {
+	// StatusCodeRange matches requests whose HTTP response code is within
+	// the range.
+	StatusCodeRange *AccessLogStatusCodeRange `json:",omitempty" alias:"status_code_range"`
+
+	// GRPCStatusCode matches requests by their gRPC status.
+	GRPCStatusCode *AccessLogGRPCStatusFilter `json:",omitempty" alias:"grpc_status_code"`
+
+	// RuntimeFilter matches a percentage of requests that can be adjusted
+	// at runtime through an Envoy runtime key.
+	RuntimeFilter *AccessLogRuntimeFilter `json:",omitempty" alias:"runtime_filter"`
+}
+
+// AccessLogStatusCodeRange is an inclusive range of HTTP response codes.
+// Leaving Min or Max at zero leaves that end of the range open.
+type AccessLogStatusCodeRange struct {
+	Min int `json:",omitempty"`
+	Max int `json:",omitempty"`
+}
+
+// AccessLogGRPCStatusFilter matches requests that completed with one of
+// Statuses, or with any other status when Exclude is set.
+type AccessLogGRPCStatusFilter struct {
+	// Statuses are gRPC status names as Envoy spells them, such as "OK",
+	// "CANCELED" or "UNAVAILABLE".
+	Statuses []string `json:",omitempty"`
+	Exclude  bool     `json:",omitempty"`
+}
+
+// AccessLogRuntimeFilter samples PercentSampled percent of requests. The
+// percentage can be overridden through the RuntimeKey Envoy runtime value.
+type AccessLogRuntimeFilter struct {
+	RuntimeKey     string `json:",omitempty" alias:"runtime_key"`
+	PercentSampled int    `json:",omitempty" alias:"percent_sampled"`
+}
+
+// accessLogGRPCStatuses are the gRPC status names Envoy accepts in a gRPC
+// status access log filter.
+var accessLogGRPCStatuses = map[string]bool{
+	"OK":                  true,
+	"CANCELED":            true,
+	"UNKNOWN":             true,
+	"INVALID_ARGUMENT":    true,
+	"DEADLINE_EXCEEDED":   true,
+	"NOT_FOUND":           true,
+	"ALREADY_EXISTS":      true,
+	"PERMISSION_DENIED":   true,
+	"RESOURCE_EXHAUSTED":  true,
+	"FAILED_PRECONDITION": true,
+	"ABORTED":             true,
+	"OUT_OF_RANGE":        true,
+	"UNIMPLEMENTED":       true,
+	"INTERNAL":            true,
+	"UNAVAILABLE":         true,
+	"DATA_LOSS":           true,
+	"UNAUTHENTICATED":     true,
+}
+
+func (f AccessLogFilter) Validate() error {
+	set := 0
+	if f.StatusCodeRange != nil {
+		set++
+		if err := f.StatusCodeRange.Validate(); err != nil {
+			return fmt.Errorf("invalid status_code_range: %w", err)
+		}
+	}
+	if f.GRPCStatusCode != nil {
+		set++
+		if err := f.GRPCStatusCode.Validate(); err != nil {
+			return fmt.Errorf("invalid grpc_status_code: %w", err)
+		}
+	}
+	if f.RuntimeFilter != nil {
+		set++
+		if err := f.RuntimeFilter.Validate(); err != nil {
+			return fmt.Errorf("invalid runtime_filter: %w", err)
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of status_code_range, grpc_status_code or runtime_filter must be set")
+	}
+	return nil
+}
+
+func (r *AccessLogStatusCodeRange) Validate() error {
+	if r.Min == 0 && r.Max == 0 {
+		return errors.New("at least one of min or max must be set")
+	}
+	for _, code := range []int{r.Min, r.Max} {
+		if code != 0 && (code < 100 || code > 599) {
+			return fmt.Errorf("status code %d must be between 100 and 599", code)
+		}
+	}
+	if r.Max != 0 && r.Min > r.Max {
+		return fmt.Errorf("min %d must not be greater than max %d", r.Min, r.Max)
+	}
+	return nil
+}
+
+func (g *AccessLogGRPCStatusFilter) Validate() error {
+	if len(g.Statuses) == 0 {
+		return errors.New("at least one status must be set")
+	}
+	for _, status := range g.Statuses {
+		if !accessLogGRPCStatuses[status] {
+			return fmt.Errorf("unknown gRPC status %q", status)
+		}
+	}
+	return nil
+}
+
+func (r *AccessLogRuntimeFilter) Validate() error {
+	if r.RuntimeKey == "" {
+		return errors.New("runtime_key must be set")
+	}
+	if r.PercentSampled < 0 || r.PercentSampled > 100 {
+		return fmt.Errorf("percent_sampled %d must be between 0 and 100", r.PercentSampled)
+	}
+	return nil
 }
 
 func (c *AccessLogsConfig) GetEnabled() bool {
@@ -218,8 +346,15 @@ func (c *AccessLogsConfig) IsZero() bool {
 	if c == nil {
 		return true
 	}
-	zeroVal := AccessLogsConfig{}
-	return *c == zeroVal
+	return !c.Enabled && !c.DisableListenerLogs && c.Type == DefaultLogSinkType &&
+		c.Path == "" && c.JSONFormat == "" && c.TextFormat == "" &&
+		len(c.AccessLogFilters) == 0
+}
+
+// GetAccessLogFilters returns the configured filters. It is not part of the
+// AccessLogs interface because the v2 access log config has no filters yet.
+func (c *AccessLogsConfig) GetAccessLogFilters() []AccessLogFilter {
+	return c.AccessLogFilters
 }
 
 func (c *AccessLogsConfig) ToAPI() *api.AccessLogsConfig {
@@ -233,7 +368,38 @@ func (c *AccessLogsConfig) ToAPI() *api.AccessLogsConfig {
 		Path:                c.Path,
 		JSONFormat:          c.JSONFormat,
 		TextFormat:          c.TextFormat,
+		AccessLogFilters:    accessLogFiltersToAPI(c.AccessLogFilters),
+	}
+}
+
+func accessLogFiltersToAPI(filters []AccessLogFilter) []api.AccessLogFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+	out := make([]api.AccessLogFilter, 0, len(filters))
+	for _, f := range filters {
+		var a api.AccessLogFilter
+		if f.StatusCodeRange != nil {
+			a.StatusCodeRange = &api.AccessLogStatusCodeRange{
+				Min: f.StatusCodeRange.Min,
+				Max: f.StatusCodeRange.Max,
+			}
+		}
+		if f.GRPCStatusCode != nil {
+			a.GRPCStatusCode = &api.AccessLogGRPCStatusFilter{
+				Statuses: f.GRPCStatusCode.Statuses,
+				Exclude:  f.GRPCStatusCode.Exclude,
+			}
+		}
+		if f.RuntimeFilter != nil {
+			a.RuntimeFilter = &api.AccessLogRuntimeFilter{
+				RuntimeKey:     f.RuntimeFilter.RuntimeKey,
+				PercentSampled: f.RuntimeFilter.PercentSampled,
+			}
+		}
+		out = append(out, a)
 	}
+	return out
 }
 
 func (c *AccessLogsConfig) Validate() error {
@@ -262,6 +428,12 @@ func (c *AccessLogsConfig) Validate() error {
 			return fmt.Errorf("invalid access log json for JSON format: %w", err)
 		}
 	}
+
+	for i, f := range c.AccessLogFilters {
+		if err := f.Validate(); err != nil {
+			return fmt.Errorf("invalid access log filter %d: %w", i, err)
+		}
+	}
 	return nil
 }
 
@@ -390,6 +562,9 @@ func (t *ConnectProxyConfig) UnmarshalJSON(data []byte) (err error) {
 	if t.AccessLogs.TextFormat == "" {
 		t.AccessLogs.TextFormat = aux.AccessLogsSnake.TextFormat
 	}
+	if t.AccessLogs.AccessLogFilters == nil {
+		t.AccessLogs.AccessLogFilters = aux.AccessLogsSnake.AccessLogFilters
+	}
 	return nil
 }
 
@@ -602,6 +777,9 @@ func (u *Upstream) Validate() error {
 	if u.DestinationPeer != "" && u.Datacenter != "" {
 		return fmt.Errorf("upstream cannot specify both destination peer and datacenter")
 	}
+	if u.Datacenter == WildcardSpecifier {
+		return fmt.Errorf("upstream datacenter cannot be a wildcard")
+	}
 
 	if u.LocalBindPort == 0 && u.LocalBindSocketPath == "" && !u.CentrallyConfigured {
 		return fmt.Errorf("upstream local bind port or local socket path must be defined and nonzero")
@@ -91,7 +91,8 @@ type DiscoveryGraphNode struct {
 	Name string // this is NOT necessarily a service
 
 	// fields for Type==router
-	Routes []*DiscoveryRoute `json:",omitempty"`
+	Routes               []*DiscoveryRoute           `json:",omitempty"`
+	VirtualHostRateLimit *VirtualHostRateLimitConfig `json:",omitempty"`
 
 	// fields for Type==splitter
 	Splits []*DiscoverySplit `json:",omitempty"`
@@ -126,6 +127,28 @@ type DiscoveryResolver struct {
 	RequestTimeout time.Duration      `json:",omitempty"`
 	Target         string             `json:",omitempty"`
 	Failover       *DiscoveryFailover `json:",omitempty"`
+
+	// ExplicitConnectTimeout is true when ConnectTimeout was set by the
+	// service-resolver or the upstream configuration rather than defaulted
+	// by the compiler.
+	ExplicitConnectTimeout bool `json:",omitempty"`
+
+	// ClusterFilterChainMetadata is the network policy metadata from the
+	// service-resolver to attach to the clusters generated for this resolver.
+	ClusterFilterChainMetadata map[string]string `json:",omitempty"`
+
+	// PerConnectionBufferLimitBytes is the connection buffer limit from the
+	// service-resolver for the clusters generated for this resolver. Zero
+	// means the mesh-wide default applies.
+	PerConnectionBufferLimitBytes uint32 `json:",omitempty"`
+
+	// GRPCHealthCheck is the active gRPC health check from the
+	// service-resolver to add to the clusters generated for this resolver.
+	GRPCHealthCheck *GRPCHealthCheckConfig `json:",omitempty"`
+
+	// PassiveHealthCheck is the outlier detection configuration from the
+	// service-resolver for the clusters generated for this resolver.
+	PassiveHealthCheck *PassiveHealthCheck `json:",omitempty"`
 }
 
 func (r *DiscoveryResolver) MarshalJSON() ([]byte, error) {
@@ -185,9 +208,10 @@ type DiscoverySplit struct {
 
 // compiled form of ServiceResolverFailover
 type DiscoveryFailover struct {
-	Targets []string                       `json:",omitempty"`
-	Policy  *ServiceResolverFailoverPolicy `json:",omitempty"`
-	Regions []string                       `json:",omitempty"`
+	Targets                []string                       `json:",omitempty"`
+	Policy                 *ServiceResolverFailoverPolicy `json:",omitempty"`
+	Regions                []string                       `json:",omitempty"`
+	OverprovisioningFactor uint32                         `json:",omitempty"`
 }
 
 // compiled form of ServiceResolverPrioritizeByLocality
@@ -239,6 +263,10 @@ type DiscoveryTarget struct {
 	Name string `json:",omitempty"`
 
 	PrioritizeByLocality *DiscoveryPrioritizeByLocality `json:",omitempty"`
+
+	// CustomSANs, if set, are the URI SANs to validate upstream certificates
+	// against instead of the SPIFFE ID derived from the service name.
+	CustomSANs []string `json:",omitempty"`
 }
 
 func (t *DiscoveryTarget) MarshalJSON() ([]byte, error) {
@@ -370,3 +398,18 @@ func (t *DiscoveryTarget) ServiceID() ServiceID {
 func (t *DiscoveryTarget) ServiceName() ServiceName {
 	return NewServiceName(t.Service, t.GetEnterpriseMetadata())
 }
+
+// ViaMeshGateway returns true if a proxy in the given datacenter and partition
+// reaches the target through a mesh gateway. Peered targets are always
+// reached through the peer's mesh gateways.
+func (t *DiscoveryTarget) ViaMeshGateway(datacenter, partition string) bool {
+	if t.Peer != "" {
+		return true
+	}
+	switch t.MeshGateway.Mode {
+	case MeshGatewayModeLocal, MeshGatewayModeRemote:
+		return t.Datacenter != datacenter || !acl.EqualPartitions(t.Partition, partition)
+	default:
+		return false
+	}
+}
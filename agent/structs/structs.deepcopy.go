@@ -147,6 +147,10 @@ func (o *CompiledDiscoveryChain) DeepCopy() *CompiledDiscoveryChain {
 					cp_Targets_v2.PrioritizeByLocality = new(DiscoveryPrioritizeByLocality)
 					*cp_Targets_v2.PrioritizeByLocality = *v2.PrioritizeByLocality
 				}
+				if v2.CustomSANs != nil {
+					cp_Targets_v2.CustomSANs = make([]string, len(v2.CustomSANs))
+					copy(cp_Targets_v2.CustomSANs, v2.CustomSANs)
+				}
 			}
 			cp.Targets[k2] = cp_Targets_v2
 		}
@@ -197,6 +201,28 @@ func (o *ConnectProxyConfig) DeepCopy() *ConnectProxyConfig {
 		retV := o.Expose.DeepCopy()
 		cp.Expose = *retV
 	}
+	if o.AccessLogs.AccessLogFilters != nil {
+		cp.AccessLogs.AccessLogFilters = make([]AccessLogFilter, len(o.AccessLogs.AccessLogFilters))
+		copy(cp.AccessLogs.AccessLogFilters, o.AccessLogs.AccessLogFilters)
+		for i3 := range o.AccessLogs.AccessLogFilters {
+			if o.AccessLogs.AccessLogFilters[i3].StatusCodeRange != nil {
+				cp.AccessLogs.AccessLogFilters[i3].StatusCodeRange = new(AccessLogStatusCodeRange)
+				*cp.AccessLogs.AccessLogFilters[i3].StatusCodeRange = *o.AccessLogs.AccessLogFilters[i3].StatusCodeRange
+			}
+			if o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode != nil {
+				cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode = new(AccessLogGRPCStatusFilter)
+				*cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode = *o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode
+				if o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses != nil {
+					cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses = make([]string, len(o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses))
+					copy(cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses, o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses)
+				}
+			}
+			if o.AccessLogs.AccessLogFilters[i3].RuntimeFilter != nil {
+				cp.AccessLogs.AccessLogFilters[i3].RuntimeFilter = new(AccessLogRuntimeFilter)
+				*cp.AccessLogs.AccessLogFilters[i3].RuntimeFilter = *o.AccessLogs.AccessLogFilters[i3].RuntimeFilter
+			}
+		}
+	}
 	return &cp
 }
 
@@ -234,6 +260,10 @@ func (o *DiscoveryGraphNode) DeepCopy() *DiscoveryGraphNode {
 			}
 		}
 	}
+	if o.VirtualHostRateLimit != nil {
+		cp.VirtualHostRateLimit = new(VirtualHostRateLimitConfig)
+		*cp.VirtualHostRateLimit = *o.VirtualHostRateLimit
+	}
 	if o.Splits != nil {
 		cp.Splits = make([]*DiscoverySplit, len(o.Splits))
 		copy(cp.Splits, o.Splits)
@@ -258,6 +288,36 @@ func (o *DiscoveryResolver) DeepCopy() *DiscoveryResolver {
 	if o.Failover != nil {
 		cp.Failover = o.Failover.DeepCopy()
 	}
+	if o.ClusterFilterChainMetadata != nil {
+		cp.ClusterFilterChainMetadata = make(map[string]string, len(o.ClusterFilterChainMetadata))
+		for k2, v2 := range o.ClusterFilterChainMetadata {
+			cp.ClusterFilterChainMetadata[k2] = v2
+		}
+	}
+	if o.GRPCHealthCheck != nil {
+		cp.GRPCHealthCheck = new(GRPCHealthCheckConfig)
+		*cp.GRPCHealthCheck = *o.GRPCHealthCheck
+	}
+	if o.PassiveHealthCheck != nil {
+		cp.PassiveHealthCheck = new(PassiveHealthCheck)
+		*cp.PassiveHealthCheck = *o.PassiveHealthCheck
+		if o.PassiveHealthCheck.EnforcingConsecutive5xx != nil {
+			cp.PassiveHealthCheck.EnforcingConsecutive5xx = new(uint32)
+			*cp.PassiveHealthCheck.EnforcingConsecutive5xx = *o.PassiveHealthCheck.EnforcingConsecutive5xx
+		}
+		if o.PassiveHealthCheck.MaxEjectionPercent != nil {
+			cp.PassiveHealthCheck.MaxEjectionPercent = new(uint32)
+			*cp.PassiveHealthCheck.MaxEjectionPercent = *o.PassiveHealthCheck.MaxEjectionPercent
+		}
+		if o.PassiveHealthCheck.BaseEjectionTime != nil {
+			cp.PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
+			*cp.PassiveHealthCheck.BaseEjectionTime = *o.PassiveHealthCheck.BaseEjectionTime
+		}
+		if o.PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+			cp.PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+			*cp.PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.PassiveHealthCheck.ConsecutiveLocalOriginFailure
+		}
+	}
 	return &cp
 }
 
@@ -653,6 +713,10 @@ func (o *IngressListener) DeepCopy() *IngressListener {
 					cp.Services[i2].PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
 					*cp.Services[i2].PassiveHealthCheck.BaseEjectionTime = *o.Services[i2].PassiveHealthCheck.BaseEjectionTime
 				}
+				if o.Services[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+					cp.Services[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+					*cp.Services[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.Services[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure
+				}
 			}
 			if o.Services[i2].Meta != nil {
 				cp.Services[i2].Meta = make(map[string]string, len(o.Services[i2].Meta))
@@ -809,11 +873,43 @@ func (o *MeshConfigEntry) DeepCopy() *MeshConfigEntry {
 	if o.HTTP != nil {
 		cp.HTTP = new(MeshHTTPConfig)
 		*cp.HTTP = *o.HTTP
+		if o.HTTP.ResponseHeadersToRemove != nil {
+			cp.HTTP.ResponseHeadersToRemove = make([]string, len(o.HTTP.ResponseHeadersToRemove))
+			copy(cp.HTTP.ResponseHeadersToRemove, o.HTTP.ResponseHeadersToRemove)
+		}
 	}
 	if o.Peering != nil {
 		cp.Peering = new(PeeringMeshConfig)
 		*cp.Peering = *o.Peering
 	}
+	if o.Telemetry != nil {
+		cp.Telemetry = new(MeshTelemetryConfig)
+		*cp.Telemetry = *o.Telemetry
+		if o.Telemetry.TrackClusterStats != nil {
+			cp.Telemetry.TrackClusterStats = new(ClusterStatsConfig)
+			*cp.Telemetry.TrackClusterStats = *o.Telemetry.TrackClusterStats
+		}
+	}
+	if o.ConnectTimeout != nil {
+		cp.ConnectTimeout = new(time.Duration)
+		*cp.ConnectTimeout = *o.ConnectTimeout
+	}
+	if o.PerConnectionBufferLimitBytes != nil {
+		cp.PerConnectionBufferLimitBytes = new(uint32)
+		*cp.PerConnectionBufferLimitBytes = *o.PerConnectionBufferLimitBytes
+	}
+	if o.TCPKeepalive != nil {
+		cp.TCPKeepalive = new(TCPKeepaliveConfig)
+		*cp.TCPKeepalive = *o.TCPKeepalive
+	}
+	if o.DefaultRingHashConfig != nil {
+		cp.DefaultRingHashConfig = new(RingHashConfig)
+		*cp.DefaultRingHashConfig = *o.DefaultRingHashConfig
+	}
+	if o.HTTPIdleTimeout != nil {
+		cp.HTTPIdleTimeout = new(time.Duration)
+		*cp.HTTPIdleTimeout = *o.HTTPIdleTimeout
+	}
 	if o.Meta != nil {
 		cp.Meta = make(map[string]string, len(o.Meta))
 		for k2, v2 := range o.Meta {
@@ -830,6 +926,10 @@ func (o *MeshDirectionalTLSConfig) DeepCopy() *MeshDirectionalTLSConfig {
 		cp.CipherSuites = make([]types.TLSCipherSuite, len(o.CipherSuites))
 		copy(cp.CipherSuites, o.CipherSuites)
 	}
+	if o.ALPNProtocols != nil {
+		cp.ALPNProtocols = make([]string, len(o.ALPNProtocols))
+		copy(cp.ALPNProtocols, o.ALPNProtocols)
+	}
 	return &cp
 }
 
@@ -945,6 +1045,14 @@ func (o *ServiceConfigEntry) DeepCopy() *ServiceConfigEntry {
 			copy(cp.RateLimits.InstanceLevel.Routes, o.RateLimits.InstanceLevel.Routes)
 		}
 	}
+	if o.GRPCTranscoding != nil {
+		cp.GRPCTranscoding = new(GRPCTranscodingConfig)
+		*cp.GRPCTranscoding = *o.GRPCTranscoding
+		if o.GRPCTranscoding.Services != nil {
+			cp.GRPCTranscoding.Services = make([]string, len(o.GRPCTranscoding.Services))
+			copy(cp.GRPCTranscoding.Services, o.GRPCTranscoding.Services)
+		}
+	}
 	if o.EnvoyExtensions != nil {
 		cp.EnvoyExtensions = make([]EnvoyExtension, len(o.EnvoyExtensions))
 		copy(cp.EnvoyExtensions, o.EnvoyExtensions)
@@ -995,6 +1103,28 @@ func (o *ServiceConfigResponse) DeepCopy() *ServiceConfigResponse {
 		cp.Destination.Addresses = make([]string, len(o.Destination.Addresses))
 		copy(cp.Destination.Addresses, o.Destination.Addresses)
 	}
+	if o.AccessLogs.AccessLogFilters != nil {
+		cp.AccessLogs.AccessLogFilters = make([]AccessLogFilter, len(o.AccessLogs.AccessLogFilters))
+		copy(cp.AccessLogs.AccessLogFilters, o.AccessLogs.AccessLogFilters)
+		for i3 := range o.AccessLogs.AccessLogFilters {
+			if o.AccessLogs.AccessLogFilters[i3].StatusCodeRange != nil {
+				cp.AccessLogs.AccessLogFilters[i3].StatusCodeRange = new(AccessLogStatusCodeRange)
+				*cp.AccessLogs.AccessLogFilters[i3].StatusCodeRange = *o.AccessLogs.AccessLogFilters[i3].StatusCodeRange
+			}
+			if o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode != nil {
+				cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode = new(AccessLogGRPCStatusFilter)
+				*cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode = *o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode
+				if o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses != nil {
+					cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses = make([]string, len(o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses))
+					copy(cp.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses, o.AccessLogs.AccessLogFilters[i3].GRPCStatusCode.Statuses)
+				}
+			}
+			if o.AccessLogs.AccessLogFilters[i3].RuntimeFilter != nil {
+				cp.AccessLogs.AccessLogFilters[i3].RuntimeFilter = new(AccessLogRuntimeFilter)
+				*cp.AccessLogs.AccessLogFilters[i3].RuntimeFilter = *o.AccessLogs.AccessLogFilters[i3].RuntimeFilter
+			}
+		}
+	}
 	if o.RateLimits.InstanceLevel.Routes != nil {
 		cp.RateLimits.InstanceLevel.Routes = make([]InstanceLevelRouteRateLimits, len(o.RateLimits.InstanceLevel.Routes))
 		copy(cp.RateLimits.InstanceLevel.Routes, o.RateLimits.InstanceLevel.Routes)
@@ -1112,6 +1242,40 @@ func (o *ServiceResolverConfigEntry) DeepCopy() *ServiceResolverConfigEntry {
 	if o.LoadBalancer != nil {
 		cp.LoadBalancer = o.LoadBalancer.DeepCopy()
 	}
+	if o.CustomSANs != nil {
+		cp.CustomSANs = make([]string, len(o.CustomSANs))
+		copy(cp.CustomSANs, o.CustomSANs)
+	}
+	if o.ClusterFilterChainMetadata != nil {
+		cp.ClusterFilterChainMetadata = make(map[string]string, len(o.ClusterFilterChainMetadata))
+		for k2, v2 := range o.ClusterFilterChainMetadata {
+			cp.ClusterFilterChainMetadata[k2] = v2
+		}
+	}
+	if o.GRPCHealthCheck != nil {
+		cp.GRPCHealthCheck = new(GRPCHealthCheckConfig)
+		*cp.GRPCHealthCheck = *o.GRPCHealthCheck
+	}
+	if o.PassiveHealthCheck != nil {
+		cp.PassiveHealthCheck = new(PassiveHealthCheck)
+		*cp.PassiveHealthCheck = *o.PassiveHealthCheck
+		if o.PassiveHealthCheck.EnforcingConsecutive5xx != nil {
+			cp.PassiveHealthCheck.EnforcingConsecutive5xx = new(uint32)
+			*cp.PassiveHealthCheck.EnforcingConsecutive5xx = *o.PassiveHealthCheck.EnforcingConsecutive5xx
+		}
+		if o.PassiveHealthCheck.MaxEjectionPercent != nil {
+			cp.PassiveHealthCheck.MaxEjectionPercent = new(uint32)
+			*cp.PassiveHealthCheck.MaxEjectionPercent = *o.PassiveHealthCheck.MaxEjectionPercent
+		}
+		if o.PassiveHealthCheck.BaseEjectionTime != nil {
+			cp.PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
+			*cp.PassiveHealthCheck.BaseEjectionTime = *o.PassiveHealthCheck.BaseEjectionTime
+		}
+		if o.PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+			cp.PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+			*cp.PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.PassiveHealthCheck.ConsecutiveLocalOriginFailure
+		}
+	}
 	if o.Meta != nil {
 		cp.Meta = make(map[string]string, len(o.Meta))
 		for k2, v2 := range o.Meta {
@@ -1278,6 +1442,10 @@ func (o *UpstreamConfiguration) DeepCopy() *UpstreamConfiguration {
 						cp.Overrides[i2].Limits.MaxConcurrentRequests = new(int)
 						*cp.Overrides[i2].Limits.MaxConcurrentRequests = *o.Overrides[i2].Limits.MaxConcurrentRequests
 					}
+					if o.Overrides[i2].Limits.MaxRetries != nil {
+						cp.Overrides[i2].Limits.MaxRetries = new(int)
+						*cp.Overrides[i2].Limits.MaxRetries = *o.Overrides[i2].Limits.MaxRetries
+					}
 				}
 				if o.Overrides[i2].PassiveHealthCheck != nil {
 					cp.Overrides[i2].PassiveHealthCheck = new(PassiveHealthCheck)
@@ -1294,6 +1462,60 @@ func (o *UpstreamConfiguration) DeepCopy() *UpstreamConfiguration {
 						cp.Overrides[i2].PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
 						*cp.Overrides[i2].PassiveHealthCheck.BaseEjectionTime = *o.Overrides[i2].PassiveHealthCheck.BaseEjectionTime
 					}
+					if o.Overrides[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+						cp.Overrides[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+						*cp.Overrides[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.Overrides[i2].PassiveHealthCheck.ConsecutiveLocalOriginFailure
+					}
+				}
+				if o.Overrides[i2].ActiveHealthCheck != nil {
+					cp.Overrides[i2].ActiveHealthCheck = new(ActiveHealthCheck)
+					*cp.Overrides[i2].ActiveHealthCheck = *o.Overrides[i2].ActiveHealthCheck
+				}
+				if o.Overrides[i2].WarmupTimeout != nil {
+					cp.Overrides[i2].WarmupTimeout = new(time.Duration)
+					*cp.Overrides[i2].WarmupTimeout = *o.Overrides[i2].WarmupTimeout
+				}
+				if o.Overrides[i2].WaitForWarmOnInit != nil {
+					cp.Overrides[i2].WaitForWarmOnInit = new(bool)
+					*cp.Overrides[i2].WaitForWarmOnInit = *o.Overrides[i2].WaitForWarmOnInit
+				}
+				if o.Overrides[i2].IdleTimeout != nil {
+					cp.Overrides[i2].IdleTimeout = new(time.Duration)
+					*cp.Overrides[i2].IdleTimeout = *o.Overrides[i2].IdleTimeout
+				}
+				if o.Overrides[i2].MaxRequestsPerConnection != nil {
+					cp.Overrides[i2].MaxRequestsPerConnection = new(uint32)
+					*cp.Overrides[i2].MaxRequestsPerConnection = *o.Overrides[i2].MaxRequestsPerConnection
+				}
+				if o.Overrides[i2].TCPKeepalive != nil {
+					cp.Overrides[i2].TCPKeepalive = new(TCPKeepaliveConfig)
+					*cp.Overrides[i2].TCPKeepalive = *o.Overrides[i2].TCPKeepalive
+				}
+				if o.Overrides[i2].ALPNProtocols != nil {
+					cp.Overrides[i2].ALPNProtocols = make([]string, len(o.Overrides[i2].ALPNProtocols))
+					copy(cp.Overrides[i2].ALPNProtocols, o.Overrides[i2].ALPNProtocols)
+				}
+				if o.Overrides[i2].ExtraSourceAddresses != nil {
+					cp.Overrides[i2].ExtraSourceAddresses = make([]UpstreamSourceAddress, len(o.Overrides[i2].ExtraSourceAddresses))
+					copy(cp.Overrides[i2].ExtraSourceAddresses, o.Overrides[i2].ExtraSourceAddresses)
+				}
+				if o.Overrides[i2].ClusterMetadata != nil {
+					cp.Overrides[i2].ClusterMetadata = make(map[string]string, len(o.Overrides[i2].ClusterMetadata))
+					for k4, v4 := range o.Overrides[i2].ClusterMetadata {
+						cp.Overrides[i2].ClusterMetadata[k4] = v4
+					}
+				}
+				if o.Overrides[i2].RetryPolicy != nil {
+					cp.Overrides[i2].RetryPolicy = new(UpstreamRetryPolicy)
+					*cp.Overrides[i2].RetryPolicy = *o.Overrides[i2].RetryPolicy
+					if o.Overrides[i2].RetryPolicy.RetriableStatusCodes != nil {
+						cp.Overrides[i2].RetryPolicy.RetriableStatusCodes = make([]uint32, len(o.Overrides[i2].RetryPolicy.RetriableStatusCodes))
+						copy(cp.Overrides[i2].RetryPolicy.RetriableStatusCodes, o.Overrides[i2].RetryPolicy.RetriableStatusCodes)
+					}
+					if o.Overrides[i2].RetryPolicy.PerTryTimeout != nil {
+						cp.Overrides[i2].RetryPolicy.PerTryTimeout = new(time.Duration)
+						*cp.Overrides[i2].RetryPolicy.PerTryTimeout = *o.Overrides[i2].RetryPolicy.PerTryTimeout
+					}
 				}
 			}
 		}
@@ -1316,6 +1538,10 @@ func (o *UpstreamConfiguration) DeepCopy() *UpstreamConfiguration {
 				cp.Defaults.Limits.MaxConcurrentRequests = new(int)
 				*cp.Defaults.Limits.MaxConcurrentRequests = *o.Defaults.Limits.MaxConcurrentRequests
 			}
+			if o.Defaults.Limits.MaxRetries != nil {
+				cp.Defaults.Limits.MaxRetries = new(int)
+				*cp.Defaults.Limits.MaxRetries = *o.Defaults.Limits.MaxRetries
+			}
 		}
 		if o.Defaults.PassiveHealthCheck != nil {
 			cp.Defaults.PassiveHealthCheck = new(PassiveHealthCheck)
@@ -1332,6 +1558,60 @@ func (o *UpstreamConfiguration) DeepCopy() *UpstreamConfiguration {
 				cp.Defaults.PassiveHealthCheck.BaseEjectionTime = new(time.Duration)
 				*cp.Defaults.PassiveHealthCheck.BaseEjectionTime = *o.Defaults.PassiveHealthCheck.BaseEjectionTime
 			}
+			if o.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure != nil {
+				cp.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure = new(uint32)
+				*cp.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure = *o.Defaults.PassiveHealthCheck.ConsecutiveLocalOriginFailure
+			}
+		}
+		if o.Defaults.ActiveHealthCheck != nil {
+			cp.Defaults.ActiveHealthCheck = new(ActiveHealthCheck)
+			*cp.Defaults.ActiveHealthCheck = *o.Defaults.ActiveHealthCheck
+		}
+		if o.Defaults.WarmupTimeout != nil {
+			cp.Defaults.WarmupTimeout = new(time.Duration)
+			*cp.Defaults.WarmupTimeout = *o.Defaults.WarmupTimeout
+		}
+		if o.Defaults.WaitForWarmOnInit != nil {
+			cp.Defaults.WaitForWarmOnInit = new(bool)
+			*cp.Defaults.WaitForWarmOnInit = *o.Defaults.WaitForWarmOnInit
+		}
+		if o.Defaults.IdleTimeout != nil {
+			cp.Defaults.IdleTimeout = new(time.Duration)
+			*cp.Defaults.IdleTimeout = *o.Defaults.IdleTimeout
+		}
+		if o.Defaults.MaxRequestsPerConnection != nil {
+			cp.Defaults.MaxRequestsPerConnection = new(uint32)
+			*cp.Defaults.MaxRequestsPerConnection = *o.Defaults.MaxRequestsPerConnection
+		}
+		if o.Defaults.TCPKeepalive != nil {
+			cp.Defaults.TCPKeepalive = new(TCPKeepaliveConfig)
+			*cp.Defaults.TCPKeepalive = *o.Defaults.TCPKeepalive
+		}
+		if o.Defaults.ALPNProtocols != nil {
+			cp.Defaults.ALPNProtocols = make([]string, len(o.Defaults.ALPNProtocols))
+			copy(cp.Defaults.ALPNProtocols, o.Defaults.ALPNProtocols)
+		}
+		if o.Defaults.ExtraSourceAddresses != nil {
+			cp.Defaults.ExtraSourceAddresses = make([]UpstreamSourceAddress, len(o.Defaults.ExtraSourceAddresses))
+			copy(cp.Defaults.ExtraSourceAddresses, o.Defaults.ExtraSourceAddresses)
+		}
+		if o.Defaults.ClusterMetadata != nil {
+			cp.Defaults.ClusterMetadata = make(map[string]string, len(o.Defaults.ClusterMetadata))
+			for k3, v3 := range o.Defaults.ClusterMetadata {
+				cp.Defaults.ClusterMetadata[k3] = v3
+			}
+		}
+		if o.Defaults.RetryPolicy != nil {
+			cp.Defaults.RetryPolicy = new(UpstreamRetryPolicy)
+			*cp.Defaults.RetryPolicy = *o.Defaults.RetryPolicy
+			if o.Defaults.RetryPolicy.RetriableStatusCodes != nil {
+				cp.Defaults.RetryPolicy.RetriableStatusCodes = make([]uint32, len(o.Defaults.RetryPolicy.RetriableStatusCodes))
+				copy(cp.Defaults.RetryPolicy.RetriableStatusCodes, o.Defaults.RetryPolicy.RetriableStatusCodes)
+			}
+			if o.Defaults.RetryPolicy.PerTryTimeout != nil {
+				cp.Defaults.RetryPolicy.PerTryTimeout = new(time.Duration)
+				*cp.Defaults.RetryPolicy.PerTryTimeout = *o.Defaults.RetryPolicy.PerTryTimeout
+			}
 		}
 	}
 	return &cp
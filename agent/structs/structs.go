@@ -198,6 +198,11 @@ const (
 	// MetaExternalSource is the metadata key used when a resource is managed by a source outside Consul like nomad/k8s
 	MetaExternalSource = "external-source"
 
+	// MetaGeoRegion and MetaGeoZone are the service metadata keys used to
+	// describe the geographic locality of a service instance.
+	MetaGeoRegion = "consul.hashicorp.com/geo.region"
+	MetaGeoZone   = "consul.hashicorp.com/geo.zone"
+
 	// TaggedAddressVirtualIP is the key used to store tagged virtual IPs generated by Consul.
 	TaggedAddressVirtualIP = "consul-virtual"
 
@@ -2099,6 +2104,19 @@ func (csn *CheckServiceNode) BestAddress(wan bool) (uint64, string, int) {
 	return idx, addr, port
 }
 
+// GeoLocality returns the locality described by the MetaGeoRegion and
+// MetaGeoZone service metadata, or nil if neither is set.
+func (csn *CheckServiceNode) GeoLocality() *Locality {
+	if csn.Service == nil {
+		return nil
+	}
+	region, zone := csn.Service.Meta[MetaGeoRegion], csn.Service.Meta[MetaGeoZone]
+	if region == "" && zone == "" {
+		return nil
+	}
+	return &Locality{Region: region, Zone: zone}
+}
+
 func (csn *CheckServiceNode) CanRead(authz acl.Authorizer) acl.EnforcementDecision {
 	if csn.Node == nil || csn.Service == nil {
 		return acl.Deny
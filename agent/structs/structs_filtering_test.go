@@ -143,6 +143,59 @@ var expectedFieldConfigAccessLogsConfig bexpr.FieldConfigurations = bexpr.FieldC
 		CoerceFn:            bexpr.CoerceString,
 		SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual, bexpr.MatchIn, bexpr.MatchNotIn, bexpr.MatchMatches, bexpr.MatchNotMatches},
 	},
+	"AccessLogFilters": &bexpr.FieldConfiguration{
+		StructFieldName:     "AccessLogFilters",
+		SupportedOperations: []bexpr.MatchOperator{bexpr.MatchIsEmpty, bexpr.MatchIsNotEmpty},
+		SubFields:           expectedFieldConfigAccessLogFilter,
+	},
+}
+
+var expectedFieldConfigAccessLogFilter bexpr.FieldConfigurations = bexpr.FieldConfigurations{
+	"StatusCodeRange": &bexpr.FieldConfiguration{
+		StructFieldName: "StatusCodeRange",
+		SubFields: bexpr.FieldConfigurations{
+			"Min": &bexpr.FieldConfiguration{
+				StructFieldName:     "Min",
+				CoerceFn:            bexpr.CoerceInt,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual},
+			},
+			"Max": &bexpr.FieldConfiguration{
+				StructFieldName:     "Max",
+				CoerceFn:            bexpr.CoerceInt,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual},
+			},
+		},
+	},
+	"GRPCStatusCode": &bexpr.FieldConfiguration{
+		StructFieldName: "GRPCStatusCode",
+		SubFields: bexpr.FieldConfigurations{
+			"Statuses": &bexpr.FieldConfiguration{
+				StructFieldName:     "Statuses",
+				CoerceFn:            bexpr.CoerceString,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchIn, bexpr.MatchNotIn, bexpr.MatchIsEmpty, bexpr.MatchIsNotEmpty},
+			},
+			"Exclude": &bexpr.FieldConfiguration{
+				StructFieldName:     "Exclude",
+				CoerceFn:            bexpr.CoerceBool,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual},
+			},
+		},
+	},
+	"RuntimeFilter": &bexpr.FieldConfiguration{
+		StructFieldName: "RuntimeFilter",
+		SubFields: bexpr.FieldConfigurations{
+			"RuntimeKey": &bexpr.FieldConfiguration{
+				StructFieldName:     "RuntimeKey",
+				CoerceFn:            bexpr.CoerceString,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual, bexpr.MatchIn, bexpr.MatchNotIn, bexpr.MatchMatches, bexpr.MatchNotMatches},
+			},
+			"PercentSampled": &bexpr.FieldConfiguration{
+				StructFieldName:     "PercentSampled",
+				CoerceFn:            bexpr.CoerceInt,
+				SupportedOperations: []bexpr.MatchOperator{bexpr.MatchEqual, bexpr.MatchNotEqual},
+			},
+		},
+	},
 }
 
 var expectedFieldConfigExposeConfig bexpr.FieldConfigurations = bexpr.FieldConfigurations{
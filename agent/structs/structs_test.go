@@ -951,6 +951,18 @@ func TestStructs_NodeService_ValidateConnectProxy(t *testing.T) {
 			},
 			"upstream destination name cannot be a wildcard",
 		},
+		{
+			"connect-proxy: upstream wildcard datacenter",
+			func(x *NodeService) {
+				x.Proxy.Upstreams = Upstreams{{
+					DestinationType: UpstreamDestTypeService,
+					DestinationName: "foo",
+					Datacenter:      WildcardSpecifier,
+					LocalBindPort:   5000,
+				}}
+			},
+			"upstream datacenter cannot be a wildcard",
+		},
 		{
 			"connect-proxy: upstream can have wildcard name when centrally configured",
 			func(x *NodeService) {
@@ -1771,6 +1783,22 @@ func TestCheckServiceNodes_Filter(t *testing.T) {
 	}
 }
 
+func TestCheckServiceNode_GeoLocality(t *testing.T) {
+	csn := CheckServiceNode{Service: &NodeService{}}
+	require.Nil(t, csn.GeoLocality())
+
+	csn.Service.Meta = map[string]string{"other": "value"}
+	require.Nil(t, csn.GeoLocality())
+
+	csn.Service.Meta = map[string]string{
+		MetaGeoRegion: "us-east-1",
+		MetaGeoZone:   "us-east-1a",
+	}
+	require.Equal(t, &Locality{Region: "us-east-1", Zone: "us-east-1a"}, csn.GeoLocality())
+
+	require.Nil(t, (&CheckServiceNode{}).GeoLocality())
+}
+
 func TestCheckServiceNode_CanRead(t *testing.T) {
 	type testCase struct {
 		name     string
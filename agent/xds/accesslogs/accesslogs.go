@@ -10,6 +10,7 @@ import (
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_fileaccesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	envoy_streamaccesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	pbmesh "github.com/hashicorp/consul/proto-public/pbmesh/v2beta1"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -49,6 +50,12 @@ const (
 `
 )
 
+// filteredAccessLogs is implemented by access log configs that support
+// user-defined access log filters.
+type filteredAccessLogs interface {
+	GetAccessLogFilters() []structs.AccessLogFilter
+}
+
 // MakeAccessLogs returns a fully-hydrated slice of Envoy Access log configurations based
 // on the proxy-defaults settings. Currently only one access logger is supported.
 // Listeners (as opposed to listener filters) can trigger an access log filter with the boolean.
@@ -72,6 +79,8 @@ func MakeAccessLogs(logs structs.AccessLogs, isListener bool) ([]*envoy_accesslo
 	if isListener {
 		name = "Consul Listener Log"
 		filter = getListenerAccessLogFilter()
+	} else if f, ok := logs.(filteredAccessLogs); ok {
+		filter = makeAccessLogFilter(f.GetAccessLogFilters())
 	}
 
 	newFilter := &envoy_accesslog_v3.AccessLog{
@@ -186,3 +195,102 @@ func getListenerAccessLogFilter() *envoy_accesslog_v3.AccessLogFilter {
 		},
 	}
 }
+
+// makeAccessLogFilter returns the Envoy filter for the user-defined filters.
+// It returns nil when there are none, and ANDs them together when there is
+// more than one.
+func makeAccessLogFilter(filters []structs.AccessLogFilter) *envoy_accesslog_v3.AccessLogFilter {
+	var out []*envoy_accesslog_v3.AccessLogFilter
+	for _, f := range filters {
+		switch {
+		case f.StatusCodeRange != nil:
+			out = append(out, makeStatusCodeRangeFilter(f.StatusCodeRange))
+		case f.GRPCStatusCode != nil:
+			out = append(out, makeGRPCStatusFilter(f.GRPCStatusCode))
+		case f.RuntimeFilter != nil:
+			out = append(out, makeRuntimeFilter(f.RuntimeFilter))
+		}
+	}
+	return andAccessLogFilters(out)
+}
+
+// makeStatusCodeRangeFilter returns a filter matching response codes between
+// Min and Max inclusive. Envoy only compares against a single value, so a
+// closed range is the AND of a >= and a <= comparison.
+//
+// Each bound is a runtime value under a fixed key, either
+// access_log.status_code_range.min or access_log.status_code_range.max. The
+// keys are shared by every status code range filter on the proxy, so a
+// runtime override moves that bound for all of them.
+func makeStatusCodeRangeFilter(r *structs.AccessLogStatusCodeRange) *envoy_accesslog_v3.AccessLogFilter {
+	var out []*envoy_accesslog_v3.AccessLogFilter
+	if r.Min != 0 {
+		out = append(out, makeStatusCodeFilter(envoy_accesslog_v3.ComparisonFilter_GE, r.Min, "access_log.status_code_range.min"))
+	}
+	if r.Max != 0 {
+		out = append(out, makeStatusCodeFilter(envoy_accesslog_v3.ComparisonFilter_LE, r.Max, "access_log.status_code_range.max"))
+	}
+	return andAccessLogFilters(out)
+}
+
+func makeStatusCodeFilter(op envoy_accesslog_v3.ComparisonFilter_Op, code int, runtimeKey string) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_StatusCodeFilter{
+			StatusCodeFilter: &envoy_accesslog_v3.StatusCodeFilter{
+				Comparison: &envoy_accesslog_v3.ComparisonFilter{
+					Op: op,
+					Value: &envoy_core_v3.RuntimeUInt32{
+						DefaultValue: uint32(code),
+						RuntimeKey:   runtimeKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeGRPCStatusFilter(g *structs.AccessLogGRPCStatusFilter) *envoy_accesslog_v3.AccessLogFilter {
+	statuses := make([]envoy_accesslog_v3.GrpcStatusFilter_Status, 0, len(g.Statuses))
+	for _, s := range g.Statuses {
+		statuses = append(statuses, envoy_accesslog_v3.GrpcStatusFilter_Status(envoy_accesslog_v3.GrpcStatusFilter_Status_value[s]))
+	}
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_GrpcStatusFilter{
+			GrpcStatusFilter: &envoy_accesslog_v3.GrpcStatusFilter{
+				Statuses: statuses,
+				Exclude:  g.Exclude,
+			},
+		},
+	}
+}
+
+func makeRuntimeFilter(r *structs.AccessLogRuntimeFilter) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_RuntimeFilter{
+			RuntimeFilter: &envoy_accesslog_v3.RuntimeFilter{
+				RuntimeKey: r.RuntimeKey,
+				PercentSampled: &envoy_type_v3.FractionalPercent{
+					Numerator:   uint32(r.PercentSampled),
+					Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+				},
+			},
+		},
+	}
+}
+
+// andAccessLogFilters returns nil for no filters, the filter itself for one,
+// and an AND filter otherwise.
+func andAccessLogFilters(filters []*envoy_accesslog_v3.AccessLogFilter) *envoy_accesslog_v3.AccessLogFilter {
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	default:
+		return &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_AndFilter{
+				AndFilter: &envoy_accesslog_v3.AndFilter{Filters: filters},
+			},
+		}
+	}
+}
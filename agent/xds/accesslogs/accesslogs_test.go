@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accesslogs
+
+import (
+	"testing"
+
+	envoy_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestMakeAccessLogs_Filters(t *testing.T) {
+	statusRange := structs.AccessLogFilter{
+		StatusCodeRange: &structs.AccessLogStatusCodeRange{Min: 400, Max: 499},
+	}
+	grpcStatus := structs.AccessLogFilter{
+		GRPCStatusCode: &structs.AccessLogGRPCStatusFilter{Statuses: []string{"UNAVAILABLE"}},
+	}
+
+	tests := map[string]struct {
+		filters    []structs.AccessLogFilter
+		isListener bool
+		check      func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter)
+	}{
+		"nil filters": {
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				require.Nil(t, filter)
+			},
+		},
+		"empty filters": {
+			filters: []structs.AccessLogFilter{},
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				require.Nil(t, filter)
+			},
+		},
+		"open status code range": {
+			filters: []structs.AccessLogFilter{
+				{StatusCodeRange: &structs.AccessLogStatusCodeRange{Max: 299}},
+			},
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				cmp := filter.GetStatusCodeFilter().GetComparison()
+				require.Equal(t, envoy_accesslog_v3.ComparisonFilter_LE, cmp.GetOp())
+				require.Equal(t, uint32(299), cmp.GetValue().GetDefaultValue())
+			},
+		},
+		"closed status code range": {
+			filters: []structs.AccessLogFilter{statusRange},
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				and := filter.GetAndFilter().GetFilters()
+				require.Len(t, and, 2)
+				require.Equal(t, envoy_accesslog_v3.ComparisonFilter_GE, and[0].GetStatusCodeFilter().GetComparison().GetOp())
+				require.Equal(t, "access_log.status_code_range.min", and[0].GetStatusCodeFilter().GetComparison().GetValue().GetRuntimeKey())
+				require.Equal(t, envoy_accesslog_v3.ComparisonFilter_LE, and[1].GetStatusCodeFilter().GetComparison().GetOp())
+				require.Equal(t, "access_log.status_code_range.max", and[1].GetStatusCodeFilter().GetComparison().GetValue().GetRuntimeKey())
+			},
+		},
+		"combined filters": {
+			filters: []structs.AccessLogFilter{statusRange, grpcStatus},
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				and := filter.GetAndFilter().GetFilters()
+				require.Len(t, and, 2)
+				require.NotNil(t, and[0].GetAndFilter())
+				require.Equal(t,
+					[]envoy_accesslog_v3.GrpcStatusFilter_Status{envoy_accesslog_v3.GrpcStatusFilter_UNAVAILABLE},
+					and[1].GetGrpcStatusFilter().GetStatuses())
+			},
+		},
+		"listener logs ignore filters": {
+			filters:    []structs.AccessLogFilter{statusRange},
+			isListener: true,
+			check: func(t *testing.T, filter *envoy_accesslog_v3.AccessLogFilter) {
+				require.Equal(t, []string{"NR"}, filter.GetResponseFlagFilter().GetFlags())
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			logs, err := MakeAccessLogs(&structs.AccessLogsConfig{
+				Enabled:          true,
+				AccessLogFilters: tc.filters,
+			}, tc.isListener)
+			require.NoError(t, err)
+			require.Len(t, logs, 1)
+			require.NoError(t, logs[0].Validate())
+			tc.check(t, logs[0].GetFilter())
+		})
+	}
+}
@@ -6,7 +6,10 @@ package xds
 import (
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +18,9 @@ import (
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_aggregate_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	envoy_cares_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/network/dns_resolver/cares/v3"
+	envoy_proxy_protocol_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
+	envoy_raw_buffer_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
@@ -25,8 +31,10 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/hashicorp/consul/agent/connect"
@@ -47,36 +55,98 @@ func (s *ResourceGenerator) clustersFromSnapshot(cfgSnap *proxycfg.ConfigSnapsho
 		return nil, errors.New("nil config given")
 	}
 
+	var (
+		clusters []proto.Message
+		err      error
+	)
 	switch cfgSnap.Kind {
 	case structs.ServiceKindConnectProxy:
-		return s.clustersFromSnapshotConnectProxy(cfgSnap)
+		clusters, err = s.clustersFromSnapshotConnectProxy(cfgSnap)
 	case structs.ServiceKindTerminatingGateway:
-		res, err := s.clustersFromSnapshotTerminatingGateway(cfgSnap)
-		if err != nil {
-			return nil, err
-		}
-		return res, nil
+		clusters, err = s.clustersFromSnapshotTerminatingGateway(cfgSnap)
 	case structs.ServiceKindMeshGateway:
-		res, err := s.clustersFromSnapshotMeshGateway(cfgSnap)
-		if err != nil {
-			return nil, err
-		}
-		return res, nil
+		clusters, err = s.clustersFromSnapshotMeshGateway(cfgSnap)
 	case structs.ServiceKindIngressGateway:
-		res, err := s.clustersFromSnapshotIngressGateway(cfgSnap)
-		if err != nil {
-			return nil, err
-		}
-		return res, nil
+		clusters, err = s.clustersFromSnapshotIngressGateway(cfgSnap)
 	case structs.ServiceKindAPIGateway:
-		res, err := s.clustersFromSnapshotAPIGateway(cfgSnap)
-		if err != nil {
-			return nil, err
-		}
-		return res, nil
+		clusters, err = s.clustersFromSnapshotAPIGateway(cfgSnap)
 	default:
 		return nil, fmt.Errorf("Invalid service kind: %v", cfgSnap.Kind)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	injectMeshClusterDefaults(cfgSnap.MeshConfig(), clusters)
+
+	if s.ClusterMutator != nil {
+		for _, msg := range clusters {
+			c, ok := msg.(*envoy_cluster_v3.Cluster)
+			if !ok {
+				continue
+			}
+			if err := s.ClusterMutator(c, cfgSnap); err != nil {
+				return nil, fmt.Errorf("failed to mutate cluster %q: %w", c.Name, err)
+			}
+		}
+	}
+
+	return clusters, nil
+}
+
+// DryRunClusters generates the clusters for the snapshot without delivering
+// them, and returns the name of every generated cluster along with any errors
+// from generating or validating them. It can be used to check that config
+// entries produce clusters Envoy would accept.
+func (s *ResourceGenerator) DryRunClusters(cfgSnap *proxycfg.ConfigSnapshot) ([]string, []error) {
+	clusters, err := s.clustersFromSnapshot(cfgSnap)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var (
+		names []string
+		errs  []error
+	)
+	for _, msg := range clusters {
+		c, ok := msg.(*envoy_cluster_v3.Cluster)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unexpected cluster type %T", msg))
+			continue
+		}
+		names = append(names, c.Name)
+		if err := c.ValidateAll(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid cluster %q: %w", c.Name, err))
+		}
+	}
+	return names, errs
+}
+
+// injectMeshClusterDefaults applies the mesh-wide cluster settings from the
+// mesh config entry to every generated cluster. Settings already present on a
+// cluster, such as a service-resolver override, are left alone.
+func injectMeshClusterDefaults(mesh *structs.MeshConfigEntry, clusters []proto.Message) {
+	stats := mesh.TrackClusterStats()
+	bufferLimit := mesh.DefaultPerConnectionBufferLimitBytes()
+	if stats == nil && bufferLimit == 0 {
+		return
+	}
+
+	for _, msg := range clusters {
+		c, ok := msg.(*envoy_cluster_v3.Cluster)
+		if !ok {
+			continue
+		}
+		if stats != nil && c.TrackClusterStats == nil {
+			c.TrackClusterStats = &envoy_cluster_v3.TrackClusterStats{
+				RequestResponseSizes: stats.RequestResponseSizes,
+				TimeoutBudgets:       stats.TimeoutBudgets,
+			}
+		}
+		if bufferLimit > 0 && c.PerConnectionBufferLimitBytes == nil {
+			c.PerConnectionBufferLimitBytes = wrapperspb.UInt32(bufferLimit)
+		}
+	}
 }
 
 // clustersFromSnapshot returns the xDS API representation of the "clusters"
@@ -147,12 +217,33 @@ func (s *ResourceGenerator) clustersFromSnapshotConnectProxy(cfgSnap *proxycfg.C
 	}
 
 	// add clusters for jwt-providers
-	for _, prov := range cfgSnap.JWTProviders {
+	providerNames := make([]string, 0, len(cfgSnap.JWTProviders))
+	for name := range cfgSnap.JWTProviders {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+
+	jwksClusters := make(map[*envoy_cluster_v3.Cluster]string, len(providerNames))
+	jwksClusterProviders := make(map[string]string, len(providerNames))
+	for _, providerName := range providerNames {
+		prov := cfgSnap.JWTProviders[providerName]
 		//skip cluster creation for local providers
 		if prov.JSONWebKeySet == nil || prov.JSONWebKeySet.Remote == nil {
 			continue
 		}
 
+		// An explicit JWKSCluster.ClusterName may be shared by providers
+		// with different URIs. Envoy would keep only one of the clusters and
+		// both providers would fetch their keys from the same host, so only
+		// the first provider in name order gets the cluster.
+		name := jwksClusterName(prov.JSONWebKeySet.Remote, prov.Name)
+		if other, ok := jwksClusterProviders[name]; ok {
+			s.Logger.Warn("skipping jwt-provider cluster, its name is used by another provider",
+				"provider name", prov.Name, "other provider name", other, "cluster name", name)
+			continue
+		}
+		jwksClusterProviders[name] = prov.Name
+
 		cluster, err := makeJWTProviderCluster(prov)
 		if err != nil {
 			s.Logger.Warn("failed to make jwt-provider cluster", "provider name", prov.Name, "error", err)
@@ -160,6 +251,7 @@ func (s *ResourceGenerator) clustersFromSnapshotConnectProxy(cfgSnap *proxycfg.C
 		}
 
 		clusters = append(clusters, cluster)
+		jwksClusters[cluster] = prov.Name
 	}
 
 	for _, u := range cfgSnap.Proxy.Upstreams {
@@ -202,23 +294,61 @@ func (s *ResourceGenerator) clustersFromSnapshotConnectProxy(cfgSnap *proxycfg.C
 		}
 		clusters = append(clusters, c)
 	}
-	return clusters, nil
+
+	return s.dropConflictingJWKSClusters(clusters, jwksClusters), nil
+}
+
+// dropConflictingJWKSClusters removes the JWKS clusters, given as a map of
+// cluster to provider name, that have the same name as another cluster
+// generated for the proxy, such as local_app or an upstream cluster. Only the
+// affected provider loses its cluster; the rest of the clusters are kept.
+func (s *ResourceGenerator) dropConflictingJWKSClusters(clusters []proto.Message, jwksClusters map[*envoy_cluster_v3.Cluster]string) []proto.Message {
+	if len(jwksClusters) == 0 {
+		return clusters
+	}
+	names := make(map[string]bool, len(clusters))
+	for _, msg := range clusters {
+		if c, ok := msg.(*envoy_cluster_v3.Cluster); ok {
+			if _, isJWKS := jwksClusters[c]; !isJWKS {
+				names[c.Name] = true
+			}
+		}
+	}
+
+	result := clusters[:0]
+	for _, msg := range clusters {
+		if c, ok := msg.(*envoy_cluster_v3.Cluster); ok {
+			if provider, isJWKS := jwksClusters[c]; isJWKS && names[c.Name] {
+				s.Logger.Warn("skipping jwt-provider cluster, its name is used by another cluster for the proxy",
+					"provider name", provider, "cluster name", c.Name)
+				continue
+			}
+		}
+		result = append(result, msg)
+	}
+	return result
 }
 
 func makeJWTProviderCluster(p *structs.JWTProviderConfigEntry) (*envoy_cluster_v3.Cluster, error) {
 	if p.JSONWebKeySet == nil || p.JSONWebKeySet.Remote == nil {
 		return nil, fmt.Errorf("cannot create JWKS cluster for non-remote JWKS. Provider Name: %s", p.Name)
 	}
+	// The JWKS cluster only ever has the single endpoint from the URI, so
+	// there is nothing that could serve its endpoints over EDS.
+	if c := p.JSONWebKeySet.Remote.JWKSCluster; c != nil && c.DiscoveryType == structs.DiscoveryTypeEDS {
+		return nil, fmt.Errorf("cannot create JWKS cluster with EDS discovery type, the endpoint must be resolved from the URI. Provider Name: %s", p.Name)
+	}
 	hostname, scheme, port, err := parseJWTRemoteURL(p.JSONWebKeySet.Remote.URI)
 	if err != nil {
 		return nil, err
 	}
 
+	clusterName := jwksClusterName(p.JSONWebKeySet.Remote, p.Name)
 	cluster := &envoy_cluster_v3.Cluster{
-		Name:                 makeJWKSClusterName(p.Name),
+		Name:                 clusterName,
 		ClusterDiscoveryType: makeJWKSDiscoveryClusterType(p.JSONWebKeySet.Remote),
 		LoadAssignment: &envoy_endpoint_v3.ClusterLoadAssignment{
-			ClusterName: makeJWKSClusterName(p.Name),
+			ClusterName: clusterName,
 			Endpoints: []*envoy_endpoint_v3.LocalityLbEndpoints{
 				{
 					LbEndpoints: []*envoy_endpoint_v3.LbEndpoint{
@@ -230,22 +360,38 @@ func makeJWTProviderCluster(p *structs.JWTProviderConfigEntry) (*envoy_cluster_v
 	}
 
 	if c := p.JSONWebKeySet.Remote.JWKSCluster; c != nil {
-		connectTimeout := int64(c.ConnectTimeout / time.Second)
-		if connectTimeout > 0 {
-			cluster.ConnectTimeout = &durationpb.Duration{Seconds: connectTimeout}
+		if c.ConnectTimeout > 0 {
+			cluster.ConnectTimeout = durationpb.New(c.ConnectTimeout)
+		}
+
+		if len(c.SocketOptions) > 0 {
+			cluster.UpstreamBindConfig = &envoy_core_v3.BindConfig{
+				SocketOptions: makeJWKSSocketOptions(c.SocketOptions),
+			}
+		}
+
+		if c.DNSRefreshRate != nil && *c.DNSRefreshRate > 0 {
+			switch cluster.GetType() {
+			case envoy_cluster_v3.Cluster_STRICT_DNS, envoy_cluster_v3.Cluster_LOGICAL_DNS:
+				cluster.DnsRefreshRate = durationpb.New(*c.DNSRefreshRate)
+			}
 		}
 	}
 
 	if scheme == "https" {
-		jwksTLSContext, err := makeUpstreamTLSTransportSocket(
-			&envoy_tls_v3.UpstreamTlsContext{
-				CommonTlsContext: &envoy_tls_v3.CommonTlsContext{
-					ValidationContextType: &envoy_tls_v3.CommonTlsContext_ValidationContext{
-						ValidationContext: makeJWTCertValidationContext(p.JSONWebKeySet.Remote.JWKSCluster),
-					},
+		tlsContext := &envoy_tls_v3.UpstreamTlsContext{
+			CommonTlsContext: &envoy_tls_v3.CommonTlsContext{
+				TlsCertificates: makeJWTClientCertificates(p.JSONWebKeySet.Remote.JWKSCluster),
+				ValidationContextType: &envoy_tls_v3.CommonTlsContext_ValidationContext{
+					ValidationContext: makeJWTCertValidationContext(p.JSONWebKeySet.Remote.JWKSCluster),
 				},
 			},
-		)
+		}
+		if c := p.JSONWebKeySet.Remote.JWKSCluster; c != nil && c.SNI != "" {
+			tlsContext.Sni = c.SNI
+		}
+
+		jwksTLSContext, err := makeUpstreamTLSTransportSocket(tlsContext)
 		if err != nil {
 			return nil, err
 		}
@@ -255,6 +401,24 @@ func makeJWTProviderCluster(p *structs.JWTProviderConfigEntry) (*envoy_cluster_v
 	return cluster, nil
 }
 
+func makeJWKSSocketOptions(opts []structs.SocketOption) []*envoy_core_v3.SocketOption {
+	out := make([]*envoy_core_v3.SocketOption, 0, len(opts))
+	for _, o := range opts {
+		so := &envoy_core_v3.SocketOption{
+			Description: o.Description,
+			Level:       o.Level,
+			Name:        o.Name,
+		}
+		if len(o.BufferValue) > 0 {
+			so.Value = &envoy_core_v3.SocketOption_BufValue{BufValue: o.BufferValue}
+		} else {
+			so.Value = &envoy_core_v3.SocketOption_IntValue{IntValue: o.IntValue}
+		}
+		out = append(out, so)
+	}
+	return out
+}
+
 func makeJWKSDiscoveryClusterType(r *structs.RemoteJWKS) *envoy_cluster_v3.Cluster_Type {
 	ct := &envoy_cluster_v3.Cluster_Type{}
 	if r == nil || r.JWKSCluster == nil {
@@ -285,30 +449,7 @@ func makeJWTCertValidationContext(p *structs.JWKSCluster) *envoy_tls_v3.Certific
 	}
 
 	if tc := p.TLSCertificates.TrustedCA; tc != nil {
-		vc.TrustedCa = &envoy_core_v3.DataSource{}
-		if tc.Filename != "" {
-			vc.TrustedCa.Specifier = &envoy_core_v3.DataSource_Filename{
-				Filename: tc.Filename,
-			}
-		}
-
-		if tc.EnvironmentVariable != "" {
-			vc.TrustedCa.Specifier = &envoy_core_v3.DataSource_EnvironmentVariable{
-				EnvironmentVariable: tc.EnvironmentVariable,
-			}
-		}
-
-		if tc.InlineString != "" {
-			vc.TrustedCa.Specifier = &envoy_core_v3.DataSource_InlineString{
-				InlineString: tc.InlineString,
-			}
-		}
-
-		if len(tc.InlineBytes) > 0 {
-			vc.TrustedCa.Specifier = &envoy_core_v3.DataSource_InlineBytes{
-				InlineBytes: tc.InlineBytes,
-			}
-		}
+		vc.TrustedCa = makeJWKSDataSource(tc.Filename, tc.EnvironmentVariable, tc.InlineString, tc.InlineBytes)
 	}
 
 	if pi := p.TLSCertificates.CaCertificateProviderInstance; pi != nil {
@@ -325,6 +466,53 @@ func makeJWTCertValidationContext(p *structs.JWKSCluster) *envoy_tls_v3.Certific
 	return vc
 }
 
+// makeJWTClientCertificates returns the client certificate the proxy presents
+// to a JWKS host that requires mutual TLS, or nil if none is configured.
+func makeJWTClientCertificates(p *structs.JWKSCluster) []*envoy_tls_v3.TlsCertificate {
+	if p == nil || p.TLSCertificates == nil {
+		return nil
+	}
+	cert, key := p.TLSCertificates.ClientCertificate, p.TLSCertificates.PrivateKey
+	if cert == nil || key == nil {
+		return nil
+	}
+
+	return []*envoy_tls_v3.TlsCertificate{{
+		CertificateChain: makeJWKSDataSource(cert.Filename, cert.EnvironmentVariable, cert.InlineString, cert.InlineBytes),
+		PrivateKey:       makeJWKSDataSource(key.Filename, key.EnvironmentVariable, key.InlineString, key.InlineBytes),
+	}}
+}
+
+// makeJWKSDataSource returns the envoy data source for whichever of the
+// given locations is set.
+func makeJWKSDataSource(filename, envVar, inlineString string, inlineBytes []byte) *envoy_core_v3.DataSource {
+	ds := &envoy_core_v3.DataSource{}
+	if filename != "" {
+		ds.Specifier = &envoy_core_v3.DataSource_Filename{
+			Filename: filename,
+		}
+	}
+
+	if envVar != "" {
+		ds.Specifier = &envoy_core_v3.DataSource_EnvironmentVariable{
+			EnvironmentVariable: envVar,
+		}
+	}
+
+	if inlineString != "" {
+		ds.Specifier = &envoy_core_v3.DataSource_InlineString{
+			InlineString: inlineString,
+		}
+	}
+
+	if len(inlineBytes) > 0 {
+		ds.Specifier = &envoy_core_v3.DataSource_InlineBytes{
+			InlineBytes: inlineBytes,
+		}
+	}
+	return ds
+}
+
 // parseJWTRemoteURL splits the URI into domain, scheme and port.
 // It will default to port 80 for http and 443 for https for any
 // URI that does not specify a port.
@@ -375,6 +563,9 @@ func makePassthroughClusters(cfgSnap *proxycfg.ConfigSnapshot, xdsCfg *config.XD
 			},
 			LbPolicy:       envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
 			ConnectTimeout: durationpb.New(5 * time.Second),
+			// Every original destination is its own host, so there is no
+			// health state worth keeping once Envoy drops the host.
+			IgnoreHealthOnHostRemoval: true,
 		})
 	}
 
@@ -400,7 +591,8 @@ func makePassthroughClusters(cfgSnap *proxycfg.ConfigSnapshot, xdsCfg *config.XD
 				},
 				LbPolicy: envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
 
-				ConnectTimeout: durationpb.New(5 * time.Second),
+				ConnectTimeout:            durationpb.New(5 * time.Second),
+				IgnoreHealthOnHostRemoval: true,
 			}
 
 			if discoTarget, ok := chain.Targets[targetID]; ok && discoTarget.ConnectTimeout > 0 {
@@ -485,6 +677,7 @@ func makeMTLSTransportSocket(cfgSnap *proxycfg.ConfigSnapshot, uid proxycfg.Upst
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 	}
+	commonTLSContext.AlpnProtocols = upstreamALPNProtocols(cfgSnap, structs.UpstreamConfig{})
 	tlsContext := envoy_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
 		Sni:              sni,
@@ -758,7 +951,7 @@ func (s *ResourceGenerator) makeGatewayServiceClusters(
 
 		// If there is a service-resolver for this service then also setup a cluster for each subset
 		for name, subset := range resolver.Subsets {
-			subsetHostnameEndpoints, err := s.filterSubsetEndpoints(&subset, hostnameEndpoints)
+			subsetHostnameEndpoints, err := s.filterResolverSubsetEndpoints(resolver, &subset, hostnameEndpoints)
 			if err != nil {
 				return nil, err
 			}
@@ -866,11 +1059,13 @@ func (s *ResourceGenerator) makeDestinationClusters(cfgSnap *proxycfg.ConfigSnap
 }
 
 func (s *ResourceGenerator) injectGatewayServiceAddons(cfgSnap *proxycfg.ConfigSnapshot, c *envoy_cluster_v3.Cluster, svc structs.ServiceName, lb *structs.LoadBalancer) error {
+	lb = lb.WithDefaultRingHashSizes(cfgSnap.MeshConfig().DefaultRingHashSizes())
 	switch cfgSnap.Kind {
 	case structs.ServiceKindMeshGateway:
 		// We can't apply hash based LB config to mesh gateways because they rely on inspecting HTTP attributes
-		// and mesh gateways do not decrypt traffic
-		if !lb.IsHashBased() {
+		// and mesh gateways do not decrypt traffic. Custom cluster types replace
+		// the gateway's own endpoint discovery, so they are only applied to sidecar upstreams.
+		if !lb.IsHashBased() && !lb.IsClusterProvided() {
 			if err := injectLBToCluster(lb, c); err != nil {
 				return fmt.Errorf("failed to apply load balancer configuration to cluster %q: %v", c.Name, err)
 			}
@@ -894,8 +1089,17 @@ func (s *ResourceGenerator) injectGatewayServiceAddons(cfgSnap *proxycfg.ConfigS
 			}
 			c.TransportSocket = transportSocket
 		}
-		if err := injectLBToCluster(lb, c); err != nil {
-			return fmt.Errorf("failed to apply load balancer configuration to cluster %q: %v", c.Name, err)
+		if mapping, ok := cfgSnap.TerminatingGateway.GatewayServices[svc]; ok {
+			if err := setUpstreamProxyProtocol(c, mapping.ProxyProtocolVersion); err != nil {
+				return err
+			}
+		}
+		// Terminating gateways resolve the service's addresses themselves, so a
+		// custom cluster type would replace the static or DNS discovery they rely on.
+		if !lb.IsClusterProvided() {
+			if err := injectLBToCluster(lb, c); err != nil {
+				return fmt.Errorf("failed to apply load balancer configuration to cluster %q: %v", c.Name, err)
+			}
 		}
 
 	}
@@ -923,6 +1127,11 @@ func (s *ResourceGenerator) injectGatewayDestinationAddons(cfgSnap *proxycfg.Con
 			}
 			c.TransportSocket = transportSocket
 		}
+		if mapping, ok := cfgSnap.TerminatingGateway.DestinationServices[svc]; ok {
+			if err := setUpstreamProxyProtocol(c, mapping.ProxyProtocolVersion); err != nil {
+				return err
+			}
+		}
 
 	}
 	return nil
@@ -1061,6 +1270,11 @@ func (s *ResourceGenerator) configIngressUpstreamCluster(c *envoy_cluster_v3.Clu
 	c.OutlierDetection = outlierDetection
 }
 
+// makeAppCluster creates the cluster the public listener forwards inbound
+// traffic to. The mesh-wide incoming TLS parameters (TLS.Incoming) are applied
+// to the public listener's DownstreamTlsContext where mTLS is terminated; this
+// cluster carries plaintext traffic to the local application and so has no
+// TLS context of its own to configure.
 func (s *ResourceGenerator) makeAppCluster(cfgSnap *proxycfg.ConfigSnapshot, name, pathProtocol string, port int) (*envoy_cluster_v3.Cluster, error) {
 	var c *envoy_cluster_v3.Cluster
 	var err error
@@ -1181,10 +1395,11 @@ func (s *ResourceGenerator) makeUpstreamClusterForPeerService(
 			},
 			OutlierDetection: outlierDetection,
 		}
-		if upstreamConfig.Protocol == "http2" || upstreamConfig.Protocol == "grpc" {
-			if err := s.setHttp2ProtocolOptions(c); err != nil {
-				return c, err
-			}
+		// Active health checks are not applied, since the endpoints of a
+		// peered upstream are usually the remote mesh gateways rather than
+		// the service instances.
+		if err := s.applyUpstreamClusterOptions(c, upstreamConfig, cfgSnap); err != nil {
+			return c, err
 		}
 
 		useEDS := true
@@ -1215,6 +1430,7 @@ func (s *ResourceGenerator) makeUpstreamClusterForPeerService(
 				s.Logger,
 				c,
 				"", /*TODO:make configurable?*/
+				0,  /*dnsRefreshRate*/
 				ep,
 				true,  /*isRemote*/
 				false, /*onlyPassing*/
@@ -1238,6 +1454,7 @@ func (s *ResourceGenerator) makeUpstreamClusterForPeerService(
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", clusterName, err)
 	}
+	commonTLSContext.AlpnProtocols = upstreamALPNProtocols(cfgSnap, upstreamConfig)
 
 	tlsContext := &envoy_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
@@ -1265,7 +1482,7 @@ func (s *ResourceGenerator) makeUpstreamClusterForPreparedQuery(upstream structs
 	}
 	sni := connect.UpstreamSNI(&upstream, "", dc, cfgSnap.Roots.TrustDomain)
 
-	cfg, err := structs.ParseUpstreamConfig(upstream.Config)
+	cfg, err := config.ParsePreparedQueryUpstreamConfig(upstream.Config, cfgSnap.MeshConfig())
 	if err != nil {
 		// Don't hard fail on a config typo, just warn. The parse func returns
 		// default config if there is an error so it's safe to continue.
@@ -1298,11 +1515,10 @@ func (s *ResourceGenerator) makeUpstreamClusterForPreparedQuery(upstream structs
 			},
 			OutlierDetection: config.ToOutlierDetection(cfg.PassiveHealthCheck, nil, true),
 		}
-		if cfg.Protocol == "http2" || cfg.Protocol == "grpc" {
-			if err := s.setHttp2ProtocolOptions(c); err != nil {
-				return c, err
-			}
+		if err := s.applyUpstreamClusterOptions(c, cfg, cfgSnap); err != nil {
+			return c, err
 		}
+		s.injectHealthCheckToCluster(c, cfg.Protocol, cfg.ActiveHealthCheck)
 	}
 
 	endpoints := cfgSnap.ConnectProxy.PreparedQueryEndpoints[uid]
@@ -1341,11 +1557,15 @@ func (s *ResourceGenerator) makeUpstreamClusterForPreparedQuery(upstream structs
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 	}
+	commonTLSContext.AlpnProtocols = upstreamALPNProtocols(cfgSnap, cfg)
 
 	tlsContext := &envoy_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
 		Sni:              sni,
 	}
+	if cfg.SNI != "" {
+		tlsContext.Sni = cfg.SNI
+	}
 
 	transportSocket, err := makeUpstreamTLSTransportSocket(tlsContext)
 	if err != nil {
@@ -1356,6 +1576,53 @@ func (s *ResourceGenerator) makeUpstreamClusterForPreparedQuery(upstream structs
 	return c, nil
 }
 
+// applyUpstreamClusterOptions applies the settings from the upstream config
+// that every kind of upstream cluster supports: HTTP protocol options,
+// connection and socket options, warming and stats. It must be called on a
+// generated cluster, never on one from envoy_cluster_json.
+func (s *ResourceGenerator) applyUpstreamClusterOptions(c *envoy_cluster_v3.Cluster, cfg structs.UpstreamConfig, cfgSnap *proxycfg.ConfigSnapshot) error {
+	if cfg.Protocol == "http2" || cfg.Protocol == "grpc" {
+		if err := s.setHttp2ProtocolOptions(c); err != nil {
+			return err
+		}
+		if err := setUpstreamHTTP2WindowSizes(c, cfg.HTTP2InitialStreamWindowSize, cfg.HTTP2InitialConnectionWindowSize); err != nil {
+			return err
+		}
+		if err := setUpstreamHTTP2OverrideStreamError(c, cfg.HTTP2OverrideStreamError); err != nil {
+			return err
+		}
+	}
+	if err := setUpstreamMaxHeadersCount(c, cfg.Protocol, cfg.MaxHeaderCount); err != nil {
+		return err
+	}
+	if err := setUpstreamMaxStreamDuration(c, cfg.Protocol, cfg.MaxStreamDurationMs); err != nil {
+		return err
+	}
+	if err := setUpstreamIdleTimeout(c, cfg.Protocol, cfg.IdleTimeout, cfgSnap.MeshConfig().DefaultHTTPIdleTimeout()); err != nil {
+		return err
+	}
+	if err := setUpstreamMaxRequestsPerConnection(c, cfg.Protocol, cfg.MaxRequestsPerConnection); err != nil {
+		return err
+	}
+	if err := setUpstreamHeaderKeyFormat(c, cfg.Protocol, cfg.HeaderKeyFormat); err != nil {
+		return err
+	}
+	if err := setUpstreamHTTPVersionAuto(c, cfg.Protocol, cfg.HTTPVersionAuto); err != nil {
+		return err
+	}
+	s.setUpstreamSourceAddress(c, cfg.LocalAddress, cfg.LocalPort, cfg.ExtraSourceAddresses)
+	setUpstreamTCPFastOpen(c, cfg.TCPFastOpen)
+	setUpstreamTCPKeepalive(c, cfg.TCPKeepalive, cfgSnap.MeshConfig().DefaultTCPKeepalive())
+	setClusterWarmUpDuration(c, cfg.ClusterWarmUpDurationMs)
+	setClusterWarming(c, cfg.WarmupTimeout, cfg.WaitForWarmOnInit)
+	setClusterStatsPrefix(c, cfg.ClusterStatsPrefix)
+	if err := setClusterFilterMetadata(c, clusterLBMetadataNamespace, cfg.ClusterMetadata); err != nil {
+		return fmt.Errorf("failed to apply cluster metadata to cluster %q: %v", c.Name, err)
+	}
+	c.ConnectionPoolPerDownstreamConnection = cfg.PerDownstreamConnectionPool
+	return nil
+}
+
 func finalizeUpstreamConfig(cfg structs.UpstreamConfig, chain *structs.CompiledDiscoveryChain, connectTimeout time.Duration) structs.UpstreamConfig {
 	if cfg.Protocol == "" {
 		cfg.Protocol = chain.Protocol
@@ -1371,6 +1638,18 @@ func finalizeUpstreamConfig(cfg structs.UpstreamConfig, chain *structs.CompiledD
 	return cfg
 }
 
+// resolverConnectTimeout returns the connect timeout for the clusters of a
+// discovery chain resolver node. The mesh-wide default is only used when
+// neither the service-resolver nor the upstream set a timeout.
+func resolverConnectTimeout(cfgSnap *proxycfg.ConfigSnapshot, resolver *structs.DiscoveryResolver) time.Duration {
+	if !resolver.ExplicitConnectTimeout {
+		if d := cfgSnap.MeshConfig().DefaultConnectTimeout(); d > 0 {
+			return d
+		}
+	}
+	return resolver.ConnectTimeout
+}
+
 func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 	uid proxycfg.UpstreamID,
 	upstream *structs.Upstream,
@@ -1442,7 +1721,8 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 		if primaryTargetClusterName == "" {
 			continue
 		}
-		upstreamConfig := finalizeUpstreamConfig(rawUpstreamConfig, chain, node.Resolver.ConnectTimeout)
+		connectTimeout := resolverConnectTimeout(cfgSnap, node.Resolver)
+		upstreamConfig := finalizeUpstreamConfig(rawUpstreamConfig, chain, connectTimeout)
 
 		if forMeshGateway && !cfgSnap.Locality.Matches(primaryTarget.Datacenter, primaryTarget.Partition) {
 			s.Logger.Warn("ignoring discovery chain target that crosses a datacenter or partition boundary in a mesh gateway",
@@ -1478,7 +1758,7 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 			c := &envoy_cluster_v3.Cluster{
 				Name:           mappedTargets.baseClusterName,
 				AltStatName:    mappedTargets.baseClusterName,
-				ConnectTimeout: durationpb.New(node.Resolver.ConnectTimeout),
+				ConnectTimeout: durationpb.New(connectTimeout),
 				LbPolicy:       envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
 				ClusterDiscoveryType: &envoy_cluster_v3.Cluster_ClusterType{
 					ClusterType: &envoy_cluster_v3.Cluster_CustomClusterType{
@@ -1487,18 +1767,19 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 					},
 				},
 			}
+			setClusterStatsPrefix(c, upstreamConfig.ClusterStatsPrefix)
 
 			out = append(out, c)
 		}
 
 		// Construct the target clusters.
-		for _, groupedTarget := range targetGroups {
+		for i, groupedTarget := range targetGroups {
 			s.Logger.Debug("generating cluster for", "cluster", groupedTarget.ClusterName)
 
 			c := &envoy_cluster_v3.Cluster{
 				Name:                 groupedTarget.ClusterName,
 				AltStatName:          groupedTarget.ClusterName,
-				ConnectTimeout:       durationpb.New(node.Resolver.ConnectTimeout),
+				ConnectTimeout:       durationpb.New(connectTimeout),
 				ClusterDiscoveryType: &envoy_cluster_v3.Cluster_Type{Type: envoy_cluster_v3.Cluster_EDS},
 				CommonLbConfig: &envoy_cluster_v3.Cluster_CommonLbConfig{
 					HealthyPanicThreshold: &envoy_type_v3.Percent{
@@ -1523,16 +1804,28 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 
 			var lb *structs.LoadBalancer
 			if node.LoadBalancer != nil {
-				lb = node.LoadBalancer
+				lb = node.LoadBalancer.WithDefaultRingHashSizes(cfgSnap.MeshConfig().DefaultRingHashSizes())
 			}
 			if err := injectLBToCluster(lb, c); err != nil {
 				return nil, fmt.Errorf("failed to apply load balancer configuration to cluster %q: %v", groupedTarget.ClusterName, err)
 			}
+			if err := setClusterFilterMetadata(c, clusterNetworkPolicyMetadataNamespace, node.Resolver.ClusterFilterChainMetadata); err != nil {
+				return nil, fmt.Errorf("failed to apply network policy metadata to cluster %q: %v", groupedTarget.ClusterName, err)
+			}
+			if limit := node.Resolver.PerConnectionBufferLimitBytes; limit > 0 {
+				c.PerConnectionBufferLimitBytes = wrapperspb.UInt32(limit)
+			}
+			setClusterGRPCHealthCheck(c, upstreamConfig.Protocol, node.Resolver.GRPCHealthCheck)
+			s.injectHealthCheckToCluster(c, upstreamConfig.Protocol, upstreamConfig.ActiveHealthCheck)
+			injectOutlierDetectionToCluster(node.Resolver.PassiveHealthCheck, c)
 
-			if upstreamConfig.Protocol == "http2" || upstreamConfig.Protocol == "grpc" {
-				if err := s.setHttp2ProtocolOptions(c); err != nil {
-					return nil, err
-				}
+			if err := s.applyUpstreamClusterOptions(c, upstreamConfig, cfgSnap); err != nil {
+				return nil, err
+			}
+			if mappedTargets.failover && upstreamConfig.ClusterStatsPrefix != "" {
+				// The aggregate cluster reports under the prefix itself, so keep
+				// the failover targets apart by their position in the failover order.
+				setClusterStatsPrefix(c, fmt.Sprintf("%s_%d", upstreamConfig.ClusterStatsPrefix, i))
 			}
 
 			switch len(groupedTarget.Targets) {
@@ -1562,6 +1855,13 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 		}
 		defaultCluster := out[0]
 
+		// Clusters are regenerated on every snapshot change, so keep this at
+		// debug level rather than repeating a warning for a deliberate override.
+		if conflicts := clusterOverrideConflicts(defaultCluster, escapeHatchCluster); len(conflicts) > 0 {
+			s.Logger.Debug("envoy_cluster_json overrides fields that Consul generates for this upstream",
+				"upstream", uid, "fields", conflicts)
+		}
+
 		// Overlay what the user provided.
 		escapeHatchCluster.TransportSocket = defaultCluster.TransportSocket
 
@@ -1571,6 +1871,37 @@ func (s *ResourceGenerator) makeUpstreamClustersForDiscoveryChain(
 	return out, nil
 }
 
+// clusterOverrideFieldsIgnored lists the cluster fields that are expected to
+// be set by an envoy_cluster_json override. The name identifies the upstream
+// cluster and the transport socket is always overlaid with the one Consul
+// generates, so neither is reported as a conflict.
+var clusterOverrideFieldsIgnored = map[protoreflect.Name]bool{
+	"name":             true,
+	"transport_socket": true,
+}
+
+// clusterOverrideConflicts returns the JSON names of the fields that are set
+// in both the generated cluster and the user provided override. The override
+// replaces the generated cluster wholesale, so these are the settings Consul
+// would otherwise have applied, even where the values happen to match.
+func clusterOverrideConflicts(generated, override *envoy_cluster_v3.Cluster) []string {
+	if generated == nil || override == nil {
+		return nil
+	}
+
+	overrideMsg := override.ProtoReflect()
+
+	var conflicts []string
+	generated.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if overrideMsg.Has(fd) && !clusterOverrideFieldsIgnored[fd.Name()] {
+			conflicts = append(conflicts, fd.JSONName())
+		}
+		return true
+	})
+	sort.Strings(conflicts)
+	return conflicts
+}
+
 func (s *ResourceGenerator) makeExportedUpstreamClustersForMeshGateway(cfgSnap *proxycfg.ConfigSnapshot) ([]proto.Message, error) {
 	// NOTE: Despite the mesh gateway already having one cluster per service
 	// (and subset) in the local datacenter we cannot reliably use those to
@@ -1708,6 +2039,9 @@ type clusterOpts struct {
 	// connectTimeout is the timeout for new network connections to hosts in the cluster
 	connectTimeout time.Duration
 
+	// dnsRefreshRate is how often hostname endpoints are resolved again
+	dnsRefreshRate time.Duration
+
 	// hostnameEndpoints is a list of endpoints with a hostname as their address
 	hostnameEndpoints structs.CheckServiceNodes
 
@@ -1724,6 +2058,9 @@ func (s *ResourceGenerator) makeGatewayCluster(snap *proxycfg.ConfigSnapshot, op
 	if opts.connectTimeout <= 0 {
 		opts.connectTimeout = time.Duration(cfg.ConnectTimeoutMs) * time.Millisecond
 	}
+	if opts.dnsRefreshRate <= 0 {
+		opts.dnsRefreshRate = time.Duration(cfg.DNSRefreshRateMs) * time.Millisecond
+	}
 
 	cluster := &envoy_cluster_v3.Cluster{
 		Name:           opts.name,
@@ -1742,16 +2079,11 @@ func (s *ResourceGenerator) makeGatewayCluster(snap *proxycfg.ConfigSnapshot, op
 	remoteUpstream := opts.isRemote || snap.Kind == structs.ServiceKindTerminatingGateway
 	if remoteUpstream && cfg.TcpKeepaliveEnable {
 		cluster.UpstreamConnectionOptions = &envoy_cluster_v3.UpstreamConnectionOptions{
-			TcpKeepalive: &envoy_core_v3.TcpKeepalive{},
-		}
-		if cfg.TcpKeepaliveTime != 0 {
-			cluster.UpstreamConnectionOptions.TcpKeepalive.KeepaliveTime = response.MakeUint32Value(cfg.TcpKeepaliveTime)
-		}
-		if cfg.TcpKeepaliveInterval != 0 {
-			cluster.UpstreamConnectionOptions.TcpKeepalive.KeepaliveInterval = response.MakeUint32Value(cfg.TcpKeepaliveInterval)
-		}
-		if cfg.TcpKeepaliveProbes != 0 {
-			cluster.UpstreamConnectionOptions.TcpKeepalive.KeepaliveProbes = response.MakeUint32Value(cfg.TcpKeepaliveProbes)
+			TcpKeepalive: makeTCPKeepalive(&structs.TCPKeepaliveConfig{
+				Time:     uint32(cfg.TcpKeepaliveTime),
+				Interval: uint32(cfg.TcpKeepaliveInterval),
+				Probes:   uint32(cfg.TcpKeepaliveProbes),
+			}),
 		}
 	}
 
@@ -1772,10 +2104,22 @@ func (s *ResourceGenerator) makeGatewayCluster(snap *proxycfg.ConfigSnapshot, op
 			s.Logger,
 			cluster,
 			cfg.DNSDiscoveryType,
+			opts.dnsRefreshRate,
 			opts.hostnameEndpoints,
 			opts.isRemote,
 			opts.onlyPassing,
 		)
+
+		if len(cfg.DNSResolvers) > 0 {
+			resolverConfig, err := makeDNSResolverConfig(cfg.DNSResolvers)
+			if err != nil {
+				// Don't hard fail on a config typo, just warn. Envoy falls back to
+				// the host's resolvers.
+				s.Logger.Warn("failed to configure envoy_dns_resolvers", "cluster", opts.name, "error", err)
+			} else {
+				cluster.TypedDnsResolverConfig = resolverConfig
+			}
+		}
 	}
 
 	if opts.limits != nil {
@@ -1791,6 +2135,8 @@ func configureClusterWithHostnames(
 	logger hclog.Logger,
 	cluster *envoy_cluster_v3.Cluster,
 	dnsDiscoveryType string,
+	// dnsRefreshRate is how often the hostname is resolved again, or zero for the default
+	dnsRefreshRate time.Duration,
 	// hostnameEndpoints is a list of endpoints with a hostname as their address
 	hostnameEndpoints structs.CheckServiceNodes,
 	// isRemote determines whether the cluster is in a remote DC or partition and we should prefer a WAN address
@@ -1801,6 +2147,9 @@ func configureClusterWithHostnames(
 	// When a service instance is addressed by a hostname we have Envoy do the DNS resolution
 	// by setting a DNS cluster type and passing the hostname endpoints via CDS.
 	rate := 10 * time.Second
+	if dnsRefreshRate > 0 {
+		rate = dnsRefreshRate
+	}
 	cluster.DnsRefreshRate = durationpb.New(rate)
 	cluster.DnsLookupFamily = envoy_cluster_v3.Cluster_V4_ONLY
 
@@ -1901,6 +2250,7 @@ func (s *ResourceGenerator) makeExternalIPCluster(snap *proxycfg.ConfigSnapshot,
 func (s *ResourceGenerator) makeExternalHostnameCluster(snap *proxycfg.ConfigSnapshot, opts clusterOpts, discoveryType envoy_cluster_v3.Cluster_DiscoveryType) *envoy_cluster_v3.Cluster {
 	cfg := snap.GetGatewayConfig(s.Logger)
 	opts.connectTimeout = time.Duration(cfg.ConnectTimeoutMs) * time.Millisecond
+	opts.dnsRefreshRate = time.Duration(cfg.DNSRefreshRateMs) * time.Millisecond
 
 	cluster := &envoy_cluster_v3.Cluster{
 		Name:           opts.name,
@@ -1913,6 +2263,9 @@ func (s *ResourceGenerator) makeExternalHostnameCluster(snap *proxycfg.ConfigSna
 	}
 
 	rate := 10 * time.Second
+	if opts.dnsRefreshRate > 0 {
+		rate = opts.dnsRefreshRate
+	}
 	cluster.DnsRefreshRate = durationpb.New(rate)
 
 	endpoints := make([]*envoy_endpoint_v3.LbEndpoint, 0, len(opts.addresses))
@@ -1959,6 +2312,9 @@ func makeThresholdsIfNeeded(limits *structs.UpstreamLimits) []*envoy_cluster_v3.
 	if limits.MaxConcurrentRequests != nil {
 		threshold.MaxRequests = response.MakeUint32Value(*limits.MaxConcurrentRequests)
 	}
+	if limits.MaxRetries != nil {
+		threshold.MaxRetries = response.MakeUint32Value(*limits.MaxRetries)
+	}
 
 	return []*envoy_cluster_v3.CircuitBreakers_Thresholds{threshold}
 }
@@ -1984,6 +2340,9 @@ func makeLbEndpoint(addr string, port int, health envoy_core_v3.HealthStatus, we
 	}
 }
 
+// injectLBToCluster applies the load balancer configuration to the cluster.
+// Callers pass the configuration with the mesh-wide defaults already merged
+// in, see structs.LoadBalancer.WithDefaultRingHashSizes.
 func injectLBToCluster(ec *structs.LoadBalancer, c *envoy_cluster_v3.Cluster) error {
 	if ec == nil {
 		return nil
@@ -2011,23 +2370,195 @@ func injectLBToCluster(ec *structs.LoadBalancer, c *envoy_cluster_v3.Cluster) er
 	case structs.LBPolicyRingHash:
 		c.LbPolicy = envoy_cluster_v3.Cluster_RING_HASH
 
-		if ec.RingHashConfig != nil {
+		// A RingHashConfig that only names a header to hash on is applied to
+		// the route hash policy; leave Envoy's default ring sizes alone.
+		if rh := ec.RingHashConfig; rh != nil && !rh.OnlyHeaderName() {
 			c.LbConfig = &envoy_cluster_v3.Cluster_RingHashLbConfig_{
 				RingHashLbConfig: &envoy_cluster_v3.Cluster_RingHashLbConfig{
-					MinimumRingSize: &wrapperspb.UInt64Value{Value: ec.RingHashConfig.MinimumRingSize},
-					MaximumRingSize: &wrapperspb.UInt64Value{Value: ec.RingHashConfig.MaximumRingSize},
+					MinimumRingSize: &wrapperspb.UInt64Value{Value: rh.MinimumRingSize},
+					MaximumRingSize: &wrapperspb.UInt64Value{Value: rh.MaximumRingSize},
 				},
 			}
 		}
 	case structs.LBPolicyMaglev:
 		c.LbPolicy = envoy_cluster_v3.Cluster_MAGLEV
 
+	case structs.LBPolicyClusterProvided:
+		if ec.CustomClusterType == "" {
+			return fmt.Errorf("load balancer policy %q requires a custom cluster type for cluster %q", ec.Policy, c.Name)
+		}
+		c.LbPolicy = envoy_cluster_v3.Cluster_CLUSTER_PROVIDED
+
+		customType := &envoy_cluster_v3.Cluster_CustomClusterType{
+			Name: ec.CustomClusterType,
+		}
+		if ec.CustomClusterConfigJSON != "" {
+			var typedConfig anypb.Any
+			if err := protojson.Unmarshal([]byte(ec.CustomClusterConfigJSON), &typedConfig); err != nil {
+				return fmt.Errorf("failed to parse custom cluster config for cluster %q: %w", c.Name, err)
+			}
+			customType.TypedConfig = &typedConfig
+		}
+
+		// The custom cluster type is responsible for discovering its own hosts.
+		c.ClusterDiscoveryType = &envoy_cluster_v3.Cluster_ClusterType{
+			ClusterType: customType,
+		}
+		c.EdsClusterConfig = nil
+
 	default:
 		return fmt.Errorf("unsupported load balancer policy %q for cluster %q", ec.Policy, c.Name)
 	}
 	return nil
 }
 
+// injectOutlierDetectionToCluster applies the outlier detection configured on
+// a service-resolver to the cluster. The cluster's existing outlier detection
+// comes from the downstream's upstream configuration, so any field it already
+// sets takes precedence over the resolver's value.
+func injectOutlierDetectionToCluster(phc *structs.PassiveHealthCheck, c *envoy_cluster_v3.Cluster) {
+	if phc == nil {
+		return
+	}
+
+	od := config.ToOutlierDetection(phc, nil, true)
+	if c.OutlierDetection != nil {
+		proto.Merge(od, c.OutlierDetection)
+	}
+	c.OutlierDetection = od
+}
+
+// Default timing and thresholds for active health checks. The gRPC health
+// checks configured by a service-resolver always use them.
+const (
+	activeHealthCheckInterval           = 10 * time.Second
+	activeHealthCheckTimeout            = 5 * time.Second
+	activeHealthCheckUnhealthyThreshold = 2
+	activeHealthCheckHealthyThreshold   = 2
+	activeHealthCheckHTTPPath           = "/"
+)
+
+// setClusterGRPCHealthCheck adds an active health check that calls the
+// grpc.health.v1.Health/Check method on each endpoint of the cluster. gRPC
+// health checks are sent over HTTP/2, so they are only added for the http2
+// and grpc protocols.
+func setClusterGRPCHealthCheck(c *envoy_cluster_v3.Cluster, protocol string, hc *structs.GRPCHealthCheckConfig) {
+	if hc == nil || (protocol != "http2" && protocol != "grpc") {
+		return
+	}
+
+	c.HealthChecks = append(c.HealthChecks, &envoy_core_v3.HealthCheck{
+		Timeout:            durationpb.New(activeHealthCheckTimeout),
+		Interval:           durationpb.New(activeHealthCheckInterval),
+		UnhealthyThreshold: wrapperspb.UInt32(activeHealthCheckUnhealthyThreshold),
+		HealthyThreshold:   wrapperspb.UInt32(activeHealthCheckHealthyThreshold),
+		HealthChecker: &envoy_core_v3.HealthCheck_GrpcHealthCheck_{
+			GrpcHealthCheck: &envoy_core_v3.HealthCheck_GrpcHealthCheck{
+				ServiceName: hc.ServiceName,
+				Authority:   hc.AuthorityHeader,
+			},
+		},
+	})
+}
+
+// injectHealthCheckToCluster adds the active health check from the upstream
+// configuration to the cluster. Unset timings and thresholds use the defaults
+// above. gRPC health checks are sent over HTTP/2, so they are only added for
+// the http2 and grpc protocols.
+func (s *ResourceGenerator) injectHealthCheckToCluster(c *envoy_cluster_v3.Cluster, protocol string, hc *structs.ActiveHealthCheck) {
+	if hc == nil {
+		return
+	}
+
+	http2 := protocol == "http2" || protocol == "grpc"
+
+	check := &envoy_core_v3.HealthCheck{
+		Timeout:            durationpb.New(activeHealthCheckTimeout),
+		Interval:           durationpb.New(activeHealthCheckInterval),
+		UnhealthyThreshold: wrapperspb.UInt32(activeHealthCheckUnhealthyThreshold),
+		HealthyThreshold:   wrapperspb.UInt32(activeHealthCheckHealthyThreshold),
+	}
+	if hc.Timeout > 0 {
+		check.Timeout = durationpb.New(hc.Timeout)
+	}
+	if hc.Interval > 0 {
+		check.Interval = durationpb.New(hc.Interval)
+	}
+	if hc.UnhealthyThreshold > 0 {
+		check.UnhealthyThreshold = wrapperspb.UInt32(hc.UnhealthyThreshold)
+	}
+	if hc.HealthyThreshold > 0 {
+		check.HealthyThreshold = wrapperspb.UInt32(hc.HealthyThreshold)
+	}
+
+	switch hc.Type {
+	case structs.ActiveHealthCheckTypeHTTP:
+		httpCheck := &envoy_core_v3.HealthCheck_HttpHealthCheck{
+			Path: hc.Path,
+		}
+		if httpCheck.Path == "" {
+			httpCheck.Path = activeHealthCheckHTTPPath
+		}
+		if http2 {
+			httpCheck.CodecClientType = envoy_type_v3.CodecClientType_HTTP2
+		}
+		check.HealthChecker = &envoy_core_v3.HealthCheck_HttpHealthCheck_{HttpHealthCheck: httpCheck}
+	case structs.ActiveHealthCheckTypeTCP:
+		check.HealthChecker = &envoy_core_v3.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: &envoy_core_v3.HealthCheck_TcpHealthCheck{},
+		}
+	case structs.ActiveHealthCheckTypeGRPC:
+		if !http2 {
+			s.Logger.Warn("ignoring active gRPC health check for upstream without HTTP/2", "cluster", c.Name, "protocol", protocol)
+			return
+		}
+		check.HealthChecker = &envoy_core_v3.HealthCheck_GrpcHealthCheck_{
+			GrpcHealthCheck: &envoy_core_v3.HealthCheck_GrpcHealthCheck{},
+		}
+	default:
+		s.Logger.Warn("ignoring active health check with invalid type", "cluster", c.Name, "type", hc.Type)
+		return
+	}
+
+	c.HealthChecks = append(c.HealthChecks, check)
+}
+
+const (
+	// clusterNetworkPolicyMetadataNamespace is the cluster filter metadata
+	// namespace read by network policy extensions.
+	clusterNetworkPolicyMetadataNamespace = "envoy.network_policy"
+
+	// clusterLBMetadataNamespace is the cluster filter metadata namespace
+	// for user provided cluster metadata.
+	clusterLBMetadataNamespace = "envoy.lb"
+)
+
+// setClusterFilterMetadata attaches md to the cluster's filter metadata under
+// the given namespace, replacing anything already set there.
+func setClusterFilterMetadata(c *envoy_cluster_v3.Cluster, namespace string, md map[string]string) error {
+	if len(md) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]interface{}, len(md))
+	for k, v := range md {
+		fields[k] = v
+	}
+	st, err := structpb.NewStruct(fields)
+	if err != nil {
+		return err
+	}
+
+	if c.Metadata == nil {
+		c.Metadata = &envoy_core_v3.Metadata{}
+	}
+	if c.Metadata.FilterMetadata == nil {
+		c.Metadata.FilterMetadata = make(map[string]*structpb.Struct)
+	}
+	c.Metadata.FilterMetadata[namespace] = st
+	return nil
+}
+
 func (s *ResourceGenerator) setHttp2ProtocolOptions(c *envoy_cluster_v3.Cluster) error {
 	cfg := &envoy_upstreams_v3.HttpProtocolOptions{
 		UpstreamProtocolOptions: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
@@ -2049,6 +2580,487 @@ func (s *ResourceGenerator) setHttp2ProtocolOptions(c *envoy_cluster_v3.Cluster)
 	return nil
 }
 
+// setUpstreamMaxHeadersCount limits the number of headers accepted on
+// responses from an HTTP upstream. Any protocol options already set on the
+// cluster, such as HTTP/2, are preserved.
+func setUpstreamMaxHeadersCount(c *envoy_cluster_v3.Cluster, protocol string, count int) error {
+	if count <= 0 || !structs.IsProtocolHTTPLike(protocol) {
+		return nil
+	}
+
+	max := uint32(math.MaxUint32)
+	if int64(count) < math.MaxUint32 {
+		max = uint32(count)
+	}
+	return updateUpstreamCommonHttpProtocolOptions(c, func(opts *envoy_core_v3.HttpProtocolOptions) {
+		opts.MaxHeadersCount = &wrapperspb.UInt32Value{Value: max}
+	})
+}
+
+// setUpstreamMaxStreamDuration limits how long a single request or stream to
+// an HTTP upstream may last, so that abandoned HTTP/2 and gRPC streams are
+// eventually reset. Any protocol options already set on the cluster are
+// preserved.
+func setUpstreamMaxStreamDuration(c *envoy_cluster_v3.Cluster, protocol string, durationMs int) error {
+	if durationMs <= 0 || !structs.IsProtocolHTTPLike(protocol) {
+		return nil
+	}
+
+	return updateUpstreamCommonHttpProtocolOptions(c, func(opts *envoy_core_v3.HttpProtocolOptions) {
+		opts.MaxStreamDuration = durationpb.New(time.Duration(durationMs) * time.Millisecond)
+	})
+}
+
+// setUpstreamIdleTimeout sets how long a connection to an HTTP upstream may sit
+// without active requests before Envoy closes it. The upstream's own timeout
+// replaces the mesh-wide default, and an explicit zero restores Envoy's
+// default. Any protocol options already set on the cluster are preserved.
+func setUpstreamIdleTimeout(c *envoy_cluster_v3.Cluster, protocol string, upstream *time.Duration, mesh time.Duration) error {
+	timeout := mesh
+	if upstream != nil {
+		timeout = *upstream
+	}
+	if timeout <= 0 || !structs.IsProtocolHTTPLike(protocol) {
+		return nil
+	}
+
+	return updateUpstreamCommonHttpProtocolOptions(c, func(opts *envoy_core_v3.HttpProtocolOptions) {
+		opts.IdleTimeout = durationpb.New(timeout)
+	})
+}
+
+// setUpstreamMaxRequestsPerConnection caps the number of requests Envoy sends
+// on a single connection to an HTTP upstream before closing it. Any protocol
+// options already set on the cluster are preserved.
+func setUpstreamMaxRequestsPerConnection(c *envoy_cluster_v3.Cluster, protocol string, maxRequests *uint32) error {
+	if maxRequests == nil || *maxRequests == 0 || !structs.IsProtocolHTTPLike(protocol) {
+		return nil
+	}
+
+	return updateUpstreamCommonHttpProtocolOptions(c, func(opts *envoy_core_v3.HttpProtocolOptions) {
+		opts.MaxRequestsPerConnection = wrapperspb.UInt32(*maxRequests)
+	})
+}
+
+// updateUpstreamCommonHttpProtocolOptions applies fn to the common HTTP
+// protocol options of the cluster. If the cluster has no HTTP protocol options
+// yet, HTTP/1.1 options are added.
+func updateUpstreamCommonHttpProtocolOptions(c *envoy_cluster_v3.Cluster, fn func(*envoy_core_v3.HttpProtocolOptions)) error {
+	return updateUpstreamHttpProtocolOptions(c, func(cfg *envoy_upstreams_v3.HttpProtocolOptions) bool {
+		if cfg.UpstreamProtocolOptions == nil {
+			cfg.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+						HttpProtocolOptions: &envoy_core_v3.Http1ProtocolOptions{},
+					},
+				},
+			}
+		}
+		if cfg.CommonHttpProtocolOptions == nil {
+			cfg.CommonHttpProtocolOptions = &envoy_core_v3.HttpProtocolOptions{}
+		}
+		fn(cfg.CommonHttpProtocolOptions)
+		return true
+	})
+}
+
+// updateUpstreamHttpProtocolOptions applies fn to the HTTP protocol options of
+// the cluster and stores the result. fn is given empty options if the cluster
+// has none yet, and returns false to leave the cluster unchanged.
+func updateUpstreamHttpProtocolOptions(c *envoy_cluster_v3.Cluster, fn func(*envoy_upstreams_v3.HttpProtocolOptions) bool) error {
+	const key = "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
+
+	cfg := &envoy_upstreams_v3.HttpProtocolOptions{}
+	if existing, ok := c.TypedExtensionProtocolOptions[key]; ok {
+		if err := existing.UnmarshalTo(cfg); err != nil {
+			return err
+		}
+	}
+	if !fn(cfg) {
+		return nil
+	}
+
+	any, err := anypb.New(cfg)
+	if err != nil {
+		return err
+	}
+	if c.TypedExtensionProtocolOptions == nil {
+		c.TypedExtensionProtocolOptions = make(map[string]*anypb.Any)
+	}
+	c.TypedExtensionProtocolOptions[key] = any
+
+	return nil
+}
+
+// setUpstreamHeaderKeyFormat sets the casing Envoy uses for header names sent
+// to an HTTP/1.1 upstream. Envoy lower cases header names by default, so only
+// proper case words needs any configuration. Any protocol options already set
+// on the cluster are preserved.
+func setUpstreamHeaderKeyFormat(c *envoy_cluster_v3.Cluster, protocol, format string) error {
+	if protocol != "http" || format != structs.UpstreamHeaderKeyFormatProperCaseWords {
+		return nil
+	}
+
+	return updateUpstreamHttpProtocolOptions(c, func(cfg *envoy_upstreams_v3.HttpProtocolOptions) bool {
+		explicit := cfg.GetExplicitHttpConfig()
+		if explicit == nil {
+			explicit = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{}
+			cfg.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: explicit,
+			}
+		}
+		http1 := explicit.GetHttpProtocolOptions()
+		if http1 == nil {
+			http1 = &envoy_core_v3.Http1ProtocolOptions{}
+			explicit.ProtocolConfig = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+				HttpProtocolOptions: http1,
+			}
+		}
+		http1.HeaderKeyFormat = &envoy_core_v3.Http1ProtocolOptions_HeaderKeyFormat{
+			HeaderFormat: &envoy_core_v3.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords_{
+				ProperCaseWords: &envoy_core_v3.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords{},
+			},
+		}
+		return true
+	})
+}
+
+// setUpstreamHTTPVersionAuto replaces the explicit HTTP version of the cluster
+// with auto config, so that Envoy uses ALPN to pick HTTP/1.1 or HTTP/2 for each
+// upstream connection. gRPC requires HTTP/2 and is left alone. Any HTTP/1.1 or
+// HTTP/2 options already set on the cluster are kept for the negotiated
+// version, so this must run after they are applied.
+func setUpstreamHTTPVersionAuto(c *envoy_cluster_v3.Cluster, protocol string, enabled bool) error {
+	if !enabled || (protocol != "http" && protocol != "http2") {
+		return nil
+	}
+
+	return updateUpstreamHttpProtocolOptions(c, func(cfg *envoy_upstreams_v3.HttpProtocolOptions) bool {
+		explicit := cfg.GetExplicitHttpConfig()
+		http1 := explicit.GetHttpProtocolOptions()
+		if http1 == nil {
+			http1 = &envoy_core_v3.Http1ProtocolOptions{}
+		}
+		http2 := explicit.GetHttp2ProtocolOptions()
+		if http2 == nil {
+			http2 = &envoy_core_v3.Http2ProtocolOptions{}
+		}
+		cfg.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_AutoConfig{
+			AutoConfig: &envoy_upstreams_v3.HttpProtocolOptions_AutoHttpConfig{
+				HttpProtocolOptions:  http1,
+				Http2ProtocolOptions: http2,
+			},
+		}
+		return true
+	})
+}
+
+// setUpstreamHTTP2WindowSizes sets the initial HTTP/2 flow control window sizes
+// on a cluster that has already been configured for HTTP/2. Sizes outside of
+// the range Envoy accepts are clamped to it.
+func setUpstreamHTTP2WindowSizes(c *envoy_cluster_v3.Cluster, streamWindowSize, connectionWindowSize int) error {
+	if streamWindowSize <= 0 && connectionWindowSize <= 0 {
+		return nil
+	}
+
+	return updateUpstreamHttpProtocolOptions(c, func(cfg *envoy_upstreams_v3.HttpProtocolOptions) bool {
+		http2 := cfg.GetExplicitHttpConfig().GetHttp2ProtocolOptions()
+		if http2 == nil {
+			return false
+		}
+		if streamWindowSize > 0 {
+			http2.InitialStreamWindowSize = &wrapperspb.UInt32Value{Value: clampHTTP2WindowSize(streamWindowSize)}
+		}
+		if connectionWindowSize > 0 {
+			http2.InitialConnectionWindowSize = &wrapperspb.UInt32Value{Value: clampHTTP2WindowSize(connectionWindowSize)}
+		}
+		return true
+	})
+}
+
+// setUpstreamHTTP2OverrideStreamError makes Envoy reset only the offending
+// stream, instead of terminating the whole connection, when the upstream sends
+// an invalid HTTP/2 message. The cluster must already be configured for HTTP/2.
+func setUpstreamHTTP2OverrideStreamError(c *envoy_cluster_v3.Cluster, enabled bool) error {
+	if !enabled {
+		return nil
+	}
+
+	return updateUpstreamHttpProtocolOptions(c, func(cfg *envoy_upstreams_v3.HttpProtocolOptions) bool {
+		http2 := cfg.GetExplicitHttpConfig().GetHttp2ProtocolOptions()
+		if http2 == nil {
+			return false
+		}
+		http2.OverrideStreamErrorOnInvalidHttpMessage = &wrapperspb.BoolValue{Value: true}
+		return true
+	})
+}
+
+func clampHTTP2WindowSize(size int) uint32 {
+	switch {
+	case size < structs.MinHTTP2WindowSize:
+		return structs.MinHTTP2WindowSize
+	case size > structs.MaxHTTP2WindowSize:
+		return structs.MaxHTTP2WindowSize
+	}
+	return uint32(size)
+}
+
+// setUpstreamSourceAddress pins the source address, and optionally port, that
+// Envoy binds to for connections to the upstream. Invalid addresses are
+// ignored, since upstream config on a service registration is not validated.
+func (s *ResourceGenerator) setUpstreamSourceAddress(c *envoy_cluster_v3.Cluster, address string, port int, extra []structs.UpstreamSourceAddress) {
+	if address == "" {
+		return
+	}
+	if net.ParseIP(address) == nil {
+		s.Logger.Warn("ignoring invalid envoy_upstream_local_address", "cluster", c.Name, "address", address)
+		return
+	}
+	if port < 0 || port > 65535 {
+		s.Logger.Warn("ignoring invalid envoy_upstream_local_port", "cluster", c.Name, "port", port)
+		port = 0
+	}
+
+	c.UpstreamBindConfig = &envoy_core_v3.BindConfig{
+		SourceAddress: makeSourceSocketAddress(address, port),
+	}
+
+	// Envoy rejects a second address of the same IP family, so only extra
+	// addresses that validation would have accepted are passed on.
+	if err := structs.ValidateUpstreamExtraSourceAddresses(address, extra); err != nil {
+		s.Logger.Warn("ignoring invalid envoy_upstream_extra_source_addresses", "cluster", c.Name, "error", err)
+		return
+	}
+	for _, addr := range extra {
+		c.UpstreamBindConfig.ExtraSourceAddresses = append(c.UpstreamBindConfig.ExtraSourceAddresses, &envoy_core_v3.ExtraSourceAddress{
+			Address: makeSourceSocketAddress(addr.Address, addr.Port),
+		})
+	}
+}
+
+func makeSourceSocketAddress(address string, port int) *envoy_core_v3.SocketAddress {
+	return &envoy_core_v3.SocketAddress{
+		Address: address,
+		PortSpecifier: &envoy_core_v3.SocketAddress_PortValue{
+			PortValue: uint32(port),
+		},
+	}
+}
+
+// makeDNSResolverConfig returns a c-ares resolver config that sends DNS queries
+// to the given servers, each an "ip" or "ip:port".
+func makeDNSResolverConfig(resolvers []string) (*envoy_core_v3.TypedExtensionConfig, error) {
+	cfg := &envoy_cares_v3.CaresDnsResolverConfig{}
+	for _, r := range resolvers {
+		host, port := r, 53
+		if h, p, err := net.SplitHostPort(r); err == nil {
+			host = h
+			port, err = strconv.Atoi(p)
+			if err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid DNS resolver %q: port must be between 1 and 65535", r)
+			}
+		}
+		if net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("invalid DNS resolver %q: address must be an IP address", r)
+		}
+		cfg.Resolvers = append(cfg.Resolvers, &envoy_core_v3.Address{
+			Address: &envoy_core_v3.Address_SocketAddress{
+				SocketAddress: &envoy_core_v3.SocketAddress{
+					Address: host,
+					PortSpecifier: &envoy_core_v3.SocketAddress_PortValue{
+						PortValue: uint32(port),
+					},
+				},
+			},
+		})
+	}
+
+	any, err := anypb.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &envoy_core_v3.TypedExtensionConfig{
+		Name:        "envoy.network.dns_resolver.cares",
+		TypedConfig: any,
+	}, nil
+}
+
+// Linux socket option used to enable TCP Fast Open on outbound connections.
+// Envoy applies socket options by number, so these are the values from the
+// Linux headers rather than the host Consul runs on.
+const (
+	linuxSolTCP             = 6  // IPPROTO_TCP
+	linuxTCPFastOpenConnect = 30 // TCP_FASTOPEN_CONNECT
+)
+
+// setUpstreamTCPFastOpen enables TCP Fast Open for connections to the
+// upstream. Envoy has no dedicated cluster setting for client side TCP Fast
+// Open, so it is set as a socket option on the cluster's bind config. Option
+// numbers are platform specific, so this only works when Envoy runs on Linux.
+func setUpstreamTCPFastOpen(c *envoy_cluster_v3.Cluster, enabled bool) {
+	if !enabled {
+		return
+	}
+
+	if c.UpstreamBindConfig == nil {
+		c.UpstreamBindConfig = &envoy_core_v3.BindConfig{}
+	}
+	c.UpstreamBindConfig.SocketOptions = append(c.UpstreamBindConfig.SocketOptions, &envoy_core_v3.SocketOption{
+		Description: "TCP_FASTOPEN_CONNECT",
+		Level:       linuxSolTCP,
+		Name:        linuxTCPFastOpenConnect,
+		Value:       &envoy_core_v3.SocketOption_IntValue{IntValue: 1},
+		State:       envoy_core_v3.SocketOption_STATE_PREBIND,
+	})
+}
+
+// setUpstreamProxyProtocol wraps the cluster's transport socket so that Envoy
+// sends a PROXY protocol header carrying the downstream's address before any
+// other bytes on each upstream connection. It must run after the cluster's TLS
+// transport socket has been set, since the header is written outside of TLS.
+// It is only used for the services behind a terminating gateway: the public
+// listeners of mesh proxies have no PROXY protocol listener filter, so a
+// header in front of the mTLS handshake would break it.
+func setUpstreamProxyProtocol(c *envoy_cluster_v3.Cluster, version string) error {
+	var v envoy_core_v3.ProxyProtocolConfig_Version
+	switch version {
+	case "":
+		return nil
+	case structs.UpstreamProxyProtocolV1:
+		v = envoy_core_v3.ProxyProtocolConfig_V1
+	case structs.UpstreamProxyProtocolV2:
+		v = envoy_core_v3.ProxyProtocolConfig_V2
+	default:
+		return fmt.Errorf("unsupported proxy protocol version %q for cluster %q", version, c.Name)
+	}
+
+	inner := c.TransportSocket
+	if inner == nil {
+		var err error
+		inner, err = makeTransportSocket("envoy.transport_sockets.raw_buffer", &envoy_raw_buffer_v3.RawBuffer{})
+		if err != nil {
+			return err
+		}
+	}
+
+	transportSocket, err := makeTransportSocket("envoy.transport_sockets.upstream_proxy_protocol", &envoy_proxy_protocol_v3.ProxyProtocolUpstreamTransport{
+		Config:          &envoy_core_v3.ProxyProtocolConfig{Version: v},
+		TransportSocket: inner,
+	})
+	if err != nil {
+		return err
+	}
+	c.TransportSocket = transportSocket
+	return nil
+}
+
+// setUpstreamTCPKeepalive enables TCP keepalives on connections to the
+// upstream. The upstream's own configuration replaces the mesh-wide default
+// as a whole rather than field by field.
+func setUpstreamTCPKeepalive(c *envoy_cluster_v3.Cluster, upstream, mesh *structs.TCPKeepaliveConfig) {
+	cfg := upstream
+	if cfg == nil {
+		cfg = mesh
+	}
+	if cfg == nil {
+		return
+	}
+
+	c.UpstreamConnectionOptions = &envoy_cluster_v3.UpstreamConnectionOptions{
+		TcpKeepalive: makeTCPKeepalive(cfg),
+	}
+}
+
+// makeTCPKeepalive builds the keepalive socket options for upstream
+// connections. Zero fields are left unset so that the operating system
+// defaults apply.
+func makeTCPKeepalive(cfg *structs.TCPKeepaliveConfig) *envoy_core_v3.TcpKeepalive {
+	keepalive := &envoy_core_v3.TcpKeepalive{}
+	if cfg.Time != 0 {
+		keepalive.KeepaliveTime = wrapperspb.UInt32(cfg.Time)
+	}
+	if cfg.Interval != 0 {
+		keepalive.KeepaliveInterval = wrapperspb.UInt32(cfg.Interval)
+	}
+	if cfg.Probes != 0 {
+		keepalive.KeepaliveProbes = wrapperspb.UInt32(cfg.Probes)
+	}
+	return keepalive
+}
+
+// upstreamALPNProtocols returns the protocols to advertise with ALPN on mTLS
+// connections to an upstream. The upstream's own list takes precedence over
+// the outgoing list of the mesh config entry. Without either, HTTP version
+// auto negotiation offers HTTP/2 and HTTP/1.1, since Envoy only picks HTTP/2
+// for an auto config cluster when ALPN selects it.
+func upstreamALPNProtocols(cfgSnap *proxycfg.ConfigSnapshot, cfg structs.UpstreamConfig) []string {
+	if len(cfg.ALPNProtocols) > 0 {
+		return cfg.ALPNProtocols
+	}
+	if tlsCfg := cfgSnap.MeshConfigTLSOutgoing(); tlsCfg != nil && len(tlsCfg.ALPNProtocols) > 0 {
+		return tlsCfg.ALPNProtocols
+	}
+	if cfg.HTTPVersionAuto && (cfg.Protocol == "http" || cfg.Protocol == "http2") {
+		return []string{"h2", "http/1.1"}
+	}
+	return nil
+}
+
+// setClusterStatsPrefix makes envoy emit the stats of the cluster under the
+// given prefix instead of the cluster name.
+func setClusterStatsPrefix(c *envoy_cluster_v3.Cluster, prefix string) {
+	if prefix == "" {
+		return
+	}
+	c.AltStatName = prefix
+}
+
+// setClusterWarming controls how Envoy warms a newly added EDS cluster. A
+// warmup timeout replaces the proxy-wide xds fetch timeout as the time the
+// cluster waits for its endpoints, with zero waiting indefinitely. Disabling
+// waitForWarmOnInit lets Envoy finish initializing without this cluster.
+func setClusterWarming(c *envoy_cluster_v3.Cluster, warmupTimeout *time.Duration, waitForWarmOnInit *bool) {
+	if warmupTimeout != nil {
+		if edsConfig := c.GetEdsClusterConfig().GetEdsConfig(); edsConfig != nil {
+			edsConfig.InitialFetchTimeout = durationpb.New(*warmupTimeout)
+		}
+	}
+	if waitForWarmOnInit != nil {
+		c.WaitForWarmOnInit = wrapperspb.Bool(*waitForWarmOnInit)
+	}
+}
+
+// setClusterWarmUpDuration configures a slow start window so that traffic to
+// newly added endpoints ramps up over the given duration. Envoy only supports
+// slow start for the round robin and least request load balancer policies, so
+// the setting is ignored for any other policy.
+func setClusterWarmUpDuration(c *envoy_cluster_v3.Cluster, durationMs int) {
+	if durationMs <= 0 {
+		return
+	}
+
+	slowStart := &envoy_cluster_v3.Cluster_SlowStartConfig{
+		SlowStartWindow: durationpb.New(time.Duration(durationMs) * time.Millisecond),
+	}
+
+	switch c.LbPolicy {
+	case envoy_cluster_v3.Cluster_ROUND_ROBIN:
+		c.LbConfig = &envoy_cluster_v3.Cluster_RoundRobinLbConfig_{
+			RoundRobinLbConfig: &envoy_cluster_v3.Cluster_RoundRobinLbConfig{
+				SlowStartConfig: slowStart,
+			},
+		}
+	case envoy_cluster_v3.Cluster_LEAST_REQUEST:
+		cfg := c.GetLeastRequestLbConfig()
+		if cfg == nil {
+			cfg = &envoy_cluster_v3.Cluster_LeastRequestLbConfig{}
+			c.LbConfig = &envoy_cluster_v3.Cluster_LeastRequestLbConfig_{LeastRequestLbConfig: cfg}
+		}
+		cfg.SlowStartConfig = slowStart
+	}
+}
+
 // Allows forwarding either HTTP/1.1 or HTTP/2 traffic to the local application.
 // The protocol used depends on the protocol received from the downstream service
 // on the public listener.
@@ -2085,7 +3097,16 @@ func generatePeeredClusterName(uid proxycfg.UpstreamID, tb *pbpeering.PeeringTru
 }
 
 func (s *ResourceGenerator) getTargetClusterName(upstreamsSnapshot *proxycfg.ConfigSnapshotUpstreams, chain *structs.CompiledDiscoveryChain, tid string, forMeshGateway bool) string {
-	target := chain.Targets[tid]
+	target, ok := chain.Targets[tid]
+	if !ok {
+		// The target was excluded from the compiled chain, so no cluster
+		// is generated for it.
+		s.Logger.Debug("skipping discovery chain target missing from the chain",
+			"chain", chain.ServiceName,
+			"target", tid,
+		)
+		return ""
+	}
 	clusterName := target.Name
 	targetUID := proxycfg.NewUpstreamIDFromTargetID(tid)
 
@@ -5,20 +5,40 @@ package xds
 
 import (
 	"bytes"
+	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"text/template"
 	"time"
 
 	envoy_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_redis_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/redis/v3"
+	envoy_proxy_protocol_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
+	envoy_raw_buffer_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"github.com/hashicorp/go-hclog"
 	testinf "github.com/mitchellh/go-testing-interface"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/hashicorp/consul/agent/proxycfg"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/xds/proxystateconverter"
+	"github.com/hashicorp/consul/agent/xds/response"
+	"github.com/hashicorp/consul/agent/xds/testcommon"
+	"github.com/hashicorp/consul/agent/xdsv2"
+	"github.com/hashicorp/consul/envoyextensions/xdscommon"
+	"github.com/hashicorp/consul/proto/private/prototest"
+	"github.com/hashicorp/consul/sdk/testutil"
 )
 
 type mockCfgFetcher struct {
@@ -37,6 +57,10 @@ func durationPtr(d time.Duration) *time.Duration {
 	return &d
 }
 
+func intPtr(i int) *int {
+	return &i
+}
+
 type customClusterJSONOptions struct {
 	Name       string
 	TLSContext string
@@ -122,10 +146,16 @@ func customClusterJSON(t testinf.T, opts customClusterJSONOptions) string {
 }
 
 func TestEnvoyLBConfig_InjectToCluster(t *testing.T) {
+	redisConfig, err := anypb.New(&envoy_redis_cluster_v3.RedisClusterConfig{
+		ClusterRefreshRate: durationpb.New(5 * time.Second),
+	})
+	require.NoError(t, err)
+
 	var tests = []struct {
-		name     string
-		lb       *structs.LoadBalancer
-		expected *envoy_cluster_v3.Cluster
+		name        string
+		lb          *structs.LoadBalancer
+		expected    *envoy_cluster_v3.Cluster
+		expectedErr string
 	}{
 		{
 			name: "skip empty",
@@ -174,6 +204,34 @@ func TestEnvoyLBConfig_InjectToCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ring_hash with empty config",
+			lb: &structs.LoadBalancer{
+				Policy:         structs.LBPolicyRingHash,
+				RingHashConfig: &structs.RingHashConfig{},
+			},
+			expected: &envoy_cluster_v3.Cluster{
+				LbPolicy: envoy_cluster_v3.Cluster_RING_HASH,
+				LbConfig: &envoy_cluster_v3.Cluster_RingHashLbConfig_{
+					RingHashLbConfig: &envoy_cluster_v3.Cluster_RingHashLbConfig{
+						MinimumRingSize: &wrapperspb.UInt64Value{Value: 0},
+						MaximumRingSize: &wrapperspb.UInt64Value{Value: 0},
+					},
+				},
+			},
+		},
+		{
+			name: "ring_hash with only a header name",
+			lb: &structs.LoadBalancer{
+				Policy: structs.LBPolicyRingHash,
+				RingHashConfig: &structs.RingHashConfig{
+					HeaderName: "x-user-id",
+				},
+			},
+			expected: &envoy_cluster_v3.Cluster{
+				LbPolicy: envoy_cluster_v3.Cluster_RING_HASH,
+			},
+		},
 		{
 			name: "least_request",
 			lb: &structs.LoadBalancer{
@@ -191,15 +249,270 @@ func TestEnvoyLBConfig_InjectToCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "cluster_provided",
+			lb: &structs.LoadBalancer{
+				Policy:            structs.LBPolicyClusterProvided,
+				CustomClusterType: "envoy.clusters.redis",
+			},
+			expected: &envoy_cluster_v3.Cluster{
+				LbPolicy: envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
+				ClusterDiscoveryType: &envoy_cluster_v3.Cluster_ClusterType{
+					ClusterType: &envoy_cluster_v3.Cluster_CustomClusterType{
+						Name: "envoy.clusters.redis",
+					},
+				},
+			},
+		},
+		{
+			name: "cluster_provided with typed config",
+			lb: &structs.LoadBalancer{
+				Policy:                  structs.LBPolicyClusterProvided,
+				CustomClusterType:       "envoy.clusters.redis",
+				CustomClusterConfigJSON: `{"@type": "type.googleapis.com/envoy.extensions.clusters.redis.v3.RedisClusterConfig", "clusterRefreshRate": "5s"}`,
+			},
+			expected: &envoy_cluster_v3.Cluster{
+				LbPolicy: envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
+				ClusterDiscoveryType: &envoy_cluster_v3.Cluster_ClusterType{
+					ClusterType: &envoy_cluster_v3.Cluster_CustomClusterType{
+						Name:        "envoy.clusters.redis",
+						TypedConfig: redisConfig,
+					},
+				},
+			},
+		},
+		{
+			name: "cluster_provided with invalid typed config",
+			lb: &structs.LoadBalancer{
+				Policy:                  structs.LBPolicyClusterProvided,
+				CustomClusterType:       "envoy.clusters.redis",
+				CustomClusterConfigJSON: `{"clusterRefreshRate": "5s"}`,
+			},
+			expectedErr: `failed to parse custom cluster config`,
+		},
+		{
+			name: "cluster_provided without custom cluster type",
+			lb: &structs.LoadBalancer{
+				Policy: structs.LBPolicyClusterProvided,
+			},
+			expectedErr: `load balancer policy "cluster_provided" requires a custom cluster type`,
+		},
+		{
+			name: "unknown_policy",
+			lb: &structs.LoadBalancer{
+				Policy: "fastest",
+			},
+			expectedErr: `unsupported load balancer policy "fastest"`,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var c envoy_cluster_v3.Cluster
 			err := injectLBToCluster(tc.lb, &c)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			prototest.AssertDeepEqual(t, tc.expected, &c)
+		})
+	}
+}
+
+func TestSetUpstreamProxyProtocol(t *testing.T) {
+	tlsSocket, err := makeUpstreamTLSTransportSocket(&envoy_tls_v3.UpstreamTlsContext{Sni: "db"})
+	require.NoError(t, err)
+
+	rawSocket, err := makeTransportSocket("envoy.transport_sockets.raw_buffer", &envoy_raw_buffer_v3.RawBuffer{})
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name          string
+		version       string
+		inner         *envoy_core_v3.TransportSocket
+		expectVersion envoy_core_v3.ProxyProtocolConfig_Version
+		expectInner   *envoy_core_v3.TransportSocket
+		expectErr     string
+	}{
+		{
+			name:  "skip empty",
+			inner: tlsSocket,
+		},
+		{
+			name:          "v1 wraps tls",
+			version:       structs.UpstreamProxyProtocolV1,
+			inner:         tlsSocket,
+			expectVersion: envoy_core_v3.ProxyProtocolConfig_V1,
+			expectInner:   tlsSocket,
+		},
+		{
+			name:          "v2 wraps tls",
+			version:       structs.UpstreamProxyProtocolV2,
+			inner:         tlsSocket,
+			expectVersion: envoy_core_v3.ProxyProtocolConfig_V2,
+			expectInner:   tlsSocket,
+		},
+		{
+			name:          "no existing socket wraps raw buffer",
+			version:       structs.UpstreamProxyProtocolV2,
+			expectVersion: envoy_core_v3.ProxyProtocolConfig_V2,
+			expectInner:   rawSocket,
+		},
+		{
+			name:      "unknown version",
+			version:   "v3",
+			inner:     tlsSocket,
+			expectErr: `unsupported proxy protocol version "v3"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &envoy_cluster_v3.Cluster{Name: "db", TransportSocket: tc.inner}
+			err := setUpstreamProxyProtocol(c, tc.version)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
 			require.NoError(t, err)
 
-			require.Equal(t, tc.expected, &c)
+			if tc.version == "" {
+				require.Same(t, tc.inner, c.TransportSocket)
+				return
+			}
+
+			require.Equal(t, "envoy.transport_sockets.upstream_proxy_protocol", c.TransportSocket.Name)
+			var pp envoy_proxy_protocol_v3.ProxyProtocolUpstreamTransport
+			require.NoError(t, c.TransportSocket.GetTypedConfig().UnmarshalTo(&pp))
+			require.Equal(t, tc.expectVersion, pp.GetConfig().GetVersion())
+			require.True(t, proto.Equal(tc.expectInner, pp.GetTransportSocket()))
+		})
+	}
+}
+
+func TestMakeThresholdsIfNeeded(t *testing.T) {
+	var tests = []struct {
+		name     string
+		limits   *structs.UpstreamLimits
+		expected []*envoy_cluster_v3.CircuitBreakers_Thresholds
+	}{
+		{
+			name: "skip nil",
+		},
+		{
+			name:     "empty uses envoy defaults",
+			limits:   &structs.UpstreamLimits{},
+			expected: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{}},
+		},
+		{
+			name: "all fields",
+			limits: &structs.UpstreamLimits{
+				MaxConnections:        intPtr(500),
+				MaxPendingRequests:    intPtr(600),
+				MaxConcurrentRequests: intPtr(700),
+				MaxRetries:            intPtr(5),
+			},
+			expected: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{
+				MaxConnections:     &wrapperspb.UInt32Value{Value: 500},
+				MaxPendingRequests: &wrapperspb.UInt32Value{Value: 600},
+				MaxRequests:        &wrapperspb.UInt32Value{Value: 700},
+				MaxRetries:         &wrapperspb.UInt32Value{Value: 5},
+			}},
+		},
+		{
+			name: "zero is kept",
+			limits: &structs.UpstreamLimits{
+				MaxRetries: intPtr(0),
+			},
+			expected: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{
+				MaxRetries: &wrapperspb.UInt32Value{Value: 0},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, makeThresholdsIfNeeded(tc.limits))
+		})
+	}
+}
+
+func TestInjectOutlierDetectionToCluster(t *testing.T) {
+	var tests = []struct {
+		name     string
+		phc      *structs.PassiveHealthCheck
+		existing *envoy_cluster_v3.OutlierDetection
+		expected *envoy_cluster_v3.OutlierDetection
+	}{
+		{
+			name:     "skip nil",
+			existing: &envoy_cluster_v3.OutlierDetection{},
+			expected: &envoy_cluster_v3.OutlierDetection{},
+		},
+		{
+			name:     "empty enables defaults",
+			phc:      &structs.PassiveHealthCheck{},
+			expected: &envoy_cluster_v3.OutlierDetection{},
+		},
+		{
+			name: "all fields",
+			phc: &structs.PassiveHealthCheck{
+				Interval:                       10 * time.Second,
+				MaxFailures:                    3,
+				EnforcingConsecutive5xx:        uint32ptr(80),
+				MaxEjectionPercent:             uint32ptr(50),
+				BaseEjectionTime:               durationPtr(45 * time.Second),
+				SplitExternalLocalOriginErrors: true,
+				ConsecutiveLocalOriginFailure:  uint32ptr(7),
+			},
+			existing: &envoy_cluster_v3.OutlierDetection{},
+			expected: &envoy_cluster_v3.OutlierDetection{
+				Interval:                       durationpb.New(10 * time.Second),
+				Consecutive_5Xx:                &wrapperspb.UInt32Value{Value: 3},
+				EnforcingConsecutive_5Xx:       &wrapperspb.UInt32Value{Value: 80},
+				MaxEjectionPercent:             &wrapperspb.UInt32Value{Value: 50},
+				BaseEjectionTime:               durationpb.New(45 * time.Second),
+				SplitExternalLocalOriginErrors: true,
+				ConsecutiveLocalOriginFailure:  &wrapperspb.UInt32Value{Value: 7},
+			},
+		},
+		{
+			name: "enforcing consecutive 5xx of zero is kept",
+			phc: &structs.PassiveHealthCheck{
+				EnforcingConsecutive5xx: uint32ptr(0),
+			},
+			expected: &envoy_cluster_v3.OutlierDetection{
+				EnforcingConsecutive_5Xx: &wrapperspb.UInt32Value{Value: 0},
+			},
+		},
+		{
+			name: "upstream config takes precedence",
+			phc: &structs.PassiveHealthCheck{
+				Interval:           10 * time.Second,
+				MaxFailures:        3,
+				MaxEjectionPercent: uint32ptr(50),
+				BaseEjectionTime:   durationPtr(45 * time.Second),
+			},
+			existing: &envoy_cluster_v3.OutlierDetection{
+				Consecutive_5Xx:  &wrapperspb.UInt32Value{Value: 10},
+				BaseEjectionTime: durationpb.New(5 * time.Second),
+			},
+			expected: &envoy_cluster_v3.OutlierDetection{
+				Interval:           durationpb.New(10 * time.Second),
+				Consecutive_5Xx:    &wrapperspb.UInt32Value{Value: 10},
+				MaxEjectionPercent: &wrapperspb.UInt32Value{Value: 50},
+				BaseEjectionTime:   durationpb.New(5 * time.Second),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := envoy_cluster_v3.Cluster{OutlierDetection: tc.existing}
+			injectOutlierDetectionToCluster(tc.phc, &c)
+			require.True(t, proto.Equal(tc.expected, c.OutlierDetection), "got %v", c.OutlierDetection)
 		})
 	}
 }
@@ -216,7 +529,7 @@ func TestMakeJWTProviderCluster(t *testing.T) {
 				Name:          "okta",
 				JSONWebKeySet: &structs.JSONWebKeySet{},
 			},
-			expectedError: "cannot create JWKS cluster for non remote JWKS. Provider Name: okta",
+			expectedError: "cannot create JWKS cluster for non-remote JWKS. Provider Name: okta",
 		},
 		"local-jwks-configured": {
 			provider: &structs.JWTProviderConfigEntry{
@@ -228,7 +541,13 @@ func TestMakeJWTProviderCluster(t *testing.T) {
 					},
 				},
 			},
-			expectedError: "cannot create JWKS cluster for non remote JWKS. Provider Name: okta",
+			expectedError: "cannot create JWKS cluster for non-remote JWKS. Provider Name: okta",
+		},
+		"eds-discovery-type": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.DiscoveryType = structs.DiscoveryTypeEDS
+			}),
+			expectedError: "cannot create JWKS cluster with EDS discovery type, the endpoint must be resolved from the URI. Provider Name: okta",
 		},
 		"https-provider-with-hostname-no-port": {
 			provider: makeTestProviderWithJWKS("https://example-okta.com/.well-known/jwks.json"),
@@ -254,6 +573,85 @@ func TestMakeJWTProviderCluster(t *testing.T) {
 		"http-provider-with-ip-and-port": {
 			provider: makeTestProviderWithJWKS("http://127.0.0.1:9091"),
 		},
+		"https-provider-with-sni-override": {
+			provider: makeTestProviderWithJWKSCluster("https://127.0.0.1:9091", func(c *structs.JWKSCluster) {
+				c.SNI = "example-okta.com"
+			}),
+		},
+		"http-provider-with-sni-override": {
+			provider: makeTestProviderWithJWKSCluster("http://127.0.0.1:9091", func(c *structs.JWKSCluster) {
+				c.SNI = "example-okta.com"
+			}),
+		},
+		"https-provider-with-custom-dns-refresh": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.DiscoveryType = structs.DiscoveryTypeStrictDNS
+				dnsRefreshRate := 30 * time.Second
+				c.DNSRefreshRate = &dnsRefreshRate
+			}),
+		},
+		"https-provider-with-cluster-name-override": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.ClusterName = "okta-jwks-failover"
+			}),
+		},
+		"https-provider-with-socket-options": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.SocketOptions = []structs.SocketOption{
+					{
+						// IPPROTO_IP / IP_TOS with DSCP AF41.
+						Description: "dscp-af41",
+						Level:       0,
+						Name:        1,
+						IntValue:    136,
+					},
+					{
+						Description: "buffer-option",
+						Level:       6,
+						Name:        42,
+						BufferValue: []byte("abc"),
+					},
+				}
+			}),
+		},
+		"https-provider-with-client-cert-filename": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.TLSCertificates.ClientCertificate = &structs.JWKSTLSCertData{Filename: "client.crt"}
+				c.TLSCertificates.PrivateKey = &structs.JWKSTLSCertData{Filename: "client.key"}
+			}),
+		},
+		"https-provider-with-client-cert-inline-string": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.TLSCertificates.ClientCertificate = &structs.JWKSTLSCertData{InlineString: "<client cert>"}
+				c.TLSCertificates.PrivateKey = &structs.JWKSTLSCertData{InlineString: "<client key>"}
+			}),
+		},
+		"https-provider-with-connect-timeout-1ms": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.ConnectTimeout = 1 * time.Millisecond
+			}),
+		},
+		"https-provider-with-connect-timeout-999ms": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.ConnectTimeout = 999 * time.Millisecond
+			}),
+		},
+		"https-provider-with-connect-timeout-1000ms": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.ConnectTimeout = 1000 * time.Millisecond
+			}),
+		},
+		"https-provider-with-connect-timeout-1001ms": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.ConnectTimeout = 1001 * time.Millisecond
+			}),
+		},
+		"https-provider-with-client-cert-env-var": {
+			provider: makeTestProviderWithJWKSCluster("https://example-okta.com/.well-known/jwks.json", func(c *structs.JWKSCluster) {
+				c.TLSCertificates.ClientCertificate = &structs.JWKSTLSCertData{EnvironmentVariable: "JWKS_CLIENT_CERT"}
+				c.TLSCertificates.PrivateKey = &structs.JWKSTLSCertData{EnvironmentVariable: "JWKS_CLIENT_KEY"}
+			}),
+		},
 	}
 
 	for name, tt := range tests {
@@ -261,7 +659,7 @@ func TestMakeJWTProviderCluster(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			cluster, err := makeJWTProviderCluster(tt.provider)
 			if tt.expectedError != "" {
-				require.Error(t, err, tt.expectedError)
+				require.EqualError(t, err, tt.expectedError)
 			} else {
 				require.NoError(t, err)
 				gotJSON := protoToJSON(t, cluster)
@@ -296,6 +694,36 @@ func makeTestProviderWithJWKS(uri string) *structs.JWTProviderConfigEntry {
 	}
 }
 
+func makeTestProviderWithJWKSCluster(uri string, modify func(c *structs.JWKSCluster)) *structs.JWTProviderConfigEntry {
+	p := makeTestProviderWithJWKS(uri)
+	modify(p.JSONWebKeySet.Remote.JWKSCluster)
+	return p
+}
+
+func TestMakeJWTProviderCluster_SameHostDifferentPaths(t *testing.T) {
+	// JWKS clusters are named after the provider, so providers that fetch
+	// different key sets from the same host must not share a cluster.
+	tenantA := makeTestProviderWithJWKS("https://auth.example.com/tenant-a/jwks")
+	tenantA.Name = "tenant-a"
+	tenantB := makeTestProviderWithJWKS("https://auth.example.com/tenant-b/jwks")
+	tenantB.Name = "tenant-b"
+
+	clusterA, err := makeJWTProviderCluster(tenantA)
+	require.NoError(t, err)
+	clusterB, err := makeJWTProviderCluster(tenantB)
+	require.NoError(t, err)
+	require.NotEqual(t, clusterA.Name, clusterB.Name)
+
+	// Each provider fetches its own path through its own cluster.
+	remoteA := makeRemoteJWKS(tenantA.JSONWebKeySet.Remote, tenantA.Name)
+	require.Equal(t, "https://auth.example.com/tenant-a/jwks", remoteA.RemoteJwks.HttpUri.Uri)
+	require.Equal(t, clusterA.Name, remoteA.RemoteJwks.HttpUri.GetCluster())
+
+	remoteB := makeRemoteJWKS(tenantB.JSONWebKeySet.Remote, tenantB.Name)
+	require.Equal(t, "https://auth.example.com/tenant-b/jwks", remoteB.RemoteJwks.HttpUri.Uri)
+	require.Equal(t, clusterB.Name, remoteB.RemoteJwks.HttpUri.GetCluster())
+}
+
 func TestMakeJWKSDiscoveryClusterType(t *testing.T) {
 	tests := map[string]struct {
 		remoteJWKS          *structs.RemoteJWKS
@@ -580,3 +1008,605 @@ func TestMakeJWTCertValidationContext(t *testing.T) {
 		})
 	}
 }
+
+func TestMakePassthroughClusters_IgnoreHealthOnHostRemoval(t *testing.T) {
+	snaps := map[string]*proxycfg.ConfigSnapshot{
+		"dial-instances-directly": proxycfg.TestConfigSnapshotTransparentProxyDialDirectly(t),
+		"destination":             proxycfg.TestConfigSnapshotTransparentProxyDestination(t),
+	}
+
+	var originalDst, eds int
+	for name, snap := range snaps {
+		clusters, err := makePassthroughClusters(snap, snap.GetXDSCommonConfig(testutil.Logger(t)))
+		require.NoError(t, err, name)
+
+		for _, msg := range clusters {
+			c, ok := msg.(*envoy_cluster_v3.Cluster)
+			require.True(t, ok)
+
+			switch c.GetType() {
+			case envoy_cluster_v3.Cluster_ORIGINAL_DST:
+				originalDst++
+				require.True(t, c.IgnoreHealthOnHostRemoval, "cluster %q", c.Name)
+			case envoy_cluster_v3.Cluster_EDS:
+				eds++
+				require.False(t, c.IgnoreHealthOnHostRemoval, "cluster %q", c.Name)
+			}
+		}
+	}
+	require.NotZero(t, originalDst)
+	require.NotZero(t, eds)
+}
+
+func TestSetClusterFilterMetadata(t *testing.T) {
+	t.Run("empty metadata leaves the cluster alone", func(t *testing.T) {
+		c := &envoy_cluster_v3.Cluster{}
+		require.NoError(t, setClusterFilterMetadata(c, clusterLBMetadataNamespace, nil))
+		require.Nil(t, c.Metadata)
+	})
+
+	t.Run("namespaces are kept apart", func(t *testing.T) {
+		c := &envoy_cluster_v3.Cluster{}
+		require.NoError(t, setClusterFilterMetadata(c, clusterNetworkPolicyMetadataNamespace, map[string]string{
+			"policy": "pci",
+		}))
+		require.NoError(t, setClusterFilterMetadata(c, clusterLBMetadataNamespace, map[string]string{
+			"version": "v2",
+			"stage":   "canary",
+		}))
+
+		expect := &envoy_core_v3.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{
+				"envoy.network_policy": {
+					Fields: map[string]*structpb.Value{
+						"policy": structpb.NewStringValue("pci"),
+					},
+				},
+				"envoy.lb": {
+					Fields: map[string]*structpb.Value{
+						"version": structpb.NewStringValue("v2"),
+						"stage":   structpb.NewStringValue("canary"),
+					},
+				},
+			},
+		}
+		require.True(t, proto.Equal(expect, c.Metadata), "got %v", c.Metadata)
+	})
+}
+
+func TestMakeDNSResolverConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resolvers []string
+		expectErr string
+	}{
+		"ipv6 without port":  {resolvers: []string{"2001:db8::53"}},
+		"ipv4 with port":     {resolvers: []string{"10.0.0.53:5353"}},
+		"hostname":           {resolvers: []string{"dns.example.com"}, expectErr: "address must be an IP address"},
+		"hostname with port": {resolvers: []string{"dns.example.com:53"}, expectErr: "address must be an IP address"},
+		"port out of range":  {resolvers: []string{"10.0.0.53:70000"}, expectErr: "port must be between 1 and 65535"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := makeDNSResolverConfig(tc.resolvers)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "envoy.network.dns_resolver.cares", cfg.Name)
+		})
+	}
+}
+
+func TestClustersFromSnapshotConnectProxy_SharedJWTProviderCluster(t *testing.T) {
+	provider := makeTestProviderWithJWKS("https://example-okta.com/.well-known/jwks.json")
+	jwt := &structs.IntentionJWTRequirement{
+		Providers: []*structs.IntentionJWTProvider{{Name: provider.Name}},
+	}
+
+	// Two intentions referencing the same provider must only produce a
+	// single JWKS cluster.
+	snap := proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+		{
+			CorrelationID: "intentions",
+			Result: structs.SimplifiedIntentions{
+				{
+					SourceNS:        "default",
+					SourceName:      "api",
+					DestinationNS:   "default",
+					DestinationName: "web",
+					Action:          structs.IntentionActionAllow,
+					JWT:             jwt,
+				},
+				{
+					SourceNS:        "default",
+					SourceName:      "billing",
+					DestinationNS:   "default",
+					DestinationName: "web",
+					Action:          structs.IntentionActionAllow,
+					JWT:             jwt,
+				},
+			},
+		},
+		{
+			CorrelationID: "jwt-provider",
+			Result: &structs.IndexedConfigEntries{
+				Kind:    structs.JWTProvider,
+				Entries: []structs.ConfigEntry{provider},
+			},
+		},
+	})
+
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+	clusters, err := g.clustersFromSnapshotConnectProxy(snap)
+	require.NoError(t, err)
+
+	var count int
+	for _, msg := range clusters {
+		c, ok := msg.(*envoy_cluster_v3.Cluster)
+		require.True(t, ok)
+		if c.Name == makeJWKSClusterName(provider.Name) {
+			count++
+		}
+	}
+	require.Equal(t, 1, count)
+}
+
+func TestClustersFromSnapshotConnectProxy_JWKSClusterNameConflicts(t *testing.T) {
+	withClusterName := func(name, uri, clusterName string) *structs.JWTProviderConfigEntry {
+		p := makeTestProviderWithJWKSCluster(uri, func(c *structs.JWKSCluster) {
+			c.ClusterName = clusterName
+		})
+		p.Name = name
+		return p
+	}
+
+	cases := map[string]struct {
+		providers []structs.ConfigEntry
+		// expect maps the name of each JWKS cluster to the host it fetches
+		// keys from.
+		expect map[string]string
+	}{
+		"distinct names": {
+			providers: []structs.ConfigEntry{
+				withClusterName("okta", "https://okta.example.com/jwks", "okta-jwks"),
+				withClusterName("auth0", "https://auth0.example.com/jwks", "auth0-jwks"),
+			},
+			expect: map[string]string{
+				"okta-jwks":  "okta.example.com",
+				"auth0-jwks": "auth0.example.com",
+			},
+		},
+		"shared between providers": {
+			providers: []structs.ConfigEntry{
+				withClusterName("okta", "https://okta.example.com/jwks", "jwks"),
+				withClusterName("auth0", "https://auth0.example.com/jwks", "jwks"),
+			},
+			expect: map[string]string{
+				"jwks": "auth0.example.com",
+			},
+		},
+		"generated cluster name": {
+			providers: []structs.ConfigEntry{
+				withClusterName("okta", "https://okta.example.com/jwks", xdscommon.LocalAppClusterName),
+				withClusterName("auth0", "https://auth0.example.com/jwks", "auth0-jwks"),
+			},
+			expect: map[string]string{
+				"auth0-jwks": "auth0.example.com",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			snap := proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+				{
+					CorrelationID: "jwt-provider",
+					Result: &structs.IndexedConfigEntries{
+						Kind:    structs.JWTProvider,
+						Entries: tc.providers,
+					},
+				},
+			})
+
+			g := NewResourceGenerator(testutil.Logger(t), nil, false)
+			clusters, err := g.clustersFromSnapshotConnectProxy(snap)
+			require.NoError(t, err)
+
+			names := make(map[string]int)
+			got := make(map[string]string)
+			for _, msg := range clusters {
+				c := msg.(*envoy_cluster_v3.Cluster)
+				names[c.Name]++
+				for _, e := range c.GetLoadAssignment().GetEndpoints() {
+					for _, lb := range e.LbEndpoints {
+						addr := lb.GetEndpoint().GetAddress().GetSocketAddress().GetAddress()
+						if strings.HasSuffix(addr, ".example.com") {
+							got[c.Name] = addr
+						}
+					}
+				}
+			}
+			require.Equal(t, tc.expect, got)
+			for name, count := range names {
+				require.Equal(t, 1, count, "cluster %q", name)
+			}
+		})
+	}
+}
+
+func TestClustersFromSnapshotConnectProxy_PeeredUpstreamOptions(t *testing.T) {
+	snap := proxycfg.TestConfigSnapshotPeeringWithHTTP2(t, func(ns *structs.NodeService) {
+		ns.Proxy.Upstreams[0].Config = map[string]interface{}{
+			"envoy_upstream_max_header_count":                 50,
+			"envoy_upstream_http2_initial_stream_window_size": 131072,
+			"envoy_upstream_override_stream_error":            true,
+		}
+	})
+
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+	clusters, err := g.clustersFromSnapshotConnectProxy(snap)
+	require.NoError(t, err)
+
+	var found bool
+	for _, msg := range clusters {
+		c, ok := msg.(*envoy_cluster_v3.Cluster)
+		require.True(t, ok)
+		if !strings.HasPrefix(c.Name, "payments.") {
+			continue
+		}
+		found = true
+
+		opts := &envoy_upstreams_v3.HttpProtocolOptions{}
+		require.NoError(t, c.TypedExtensionProtocolOptions["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"].UnmarshalTo(opts))
+		require.Equal(t, uint32(50), opts.GetCommonHttpProtocolOptions().GetMaxHeadersCount().GetValue())
+
+		http2 := opts.GetExplicitHttpConfig().GetHttp2ProtocolOptions()
+		require.NotNil(t, http2)
+		require.Equal(t, uint32(131072), http2.GetInitialStreamWindowSize().GetValue())
+		require.True(t, http2.GetOverrideStreamErrorOnInvalidHttpMessage().GetValue())
+	}
+	require.True(t, found, "expected a cluster for the payments upstream")
+}
+
+func TestClustersFromSnapshotIngressGateway_SharedUpstream(t *testing.T) {
+	// Regression test: two listeners routing to the same upstream must not
+	// produce duplicate clusters.
+	snap := proxycfg.TestConfigSnapshotIngress_MultipleListenersSharedUpstream(t)
+
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+	clusters, err := g.clustersFromSnapshotIngressGateway(snap)
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+
+	c, ok := clusters[0].(*envoy_cluster_v3.Cluster)
+	require.True(t, ok)
+	require.Equal(t, "foo.default.dc1.internal.11111111-2222-3333-4444-555555555555.consul", c.Name)
+}
+
+func TestClustersFromSnapshotConnectProxy_UnixSocketUpstreamKeepsTLS(t *testing.T) {
+	// A local bind socket only changes how the application reaches the
+	// upstream listener. The cluster still dials remote sidecars over the
+	// network, so it must keep its mTLS transport socket like a TCP upstream.
+	const dbCluster = "db.default.dc1.internal.11111111-2222-3333-4444-555555555555.consul"
+
+	cases := map[string]func(ns *structs.NodeService){
+		"tcp": nil,
+		"unix socket": func(ns *structs.NodeService) {
+			ns.Proxy.Upstreams[0].LocalBindAddress = ""
+			ns.Proxy.Upstreams[0].LocalBindPort = 0
+			ns.Proxy.Upstreams[0].LocalBindSocketPath = "/tmp/service-mesh/client-1/db"
+		},
+	}
+
+	for name, nsFn := range cases {
+		t.Run(name, func(t *testing.T) {
+			snap := proxycfg.TestConfigSnapshot(t, nsFn, nil)
+
+			g := NewResourceGenerator(testutil.Logger(t), nil, false)
+			clusters, err := g.clustersFromSnapshotConnectProxy(snap)
+			require.NoError(t, err)
+
+			var found bool
+			for _, msg := range clusters {
+				c, ok := msg.(*envoy_cluster_v3.Cluster)
+				require.True(t, ok)
+				if c.Name != dbCluster {
+					continue
+				}
+				found = true
+
+				require.NotNil(t, c.TransportSocket)
+				tlsContext := &envoy_tls_v3.UpstreamTlsContext{}
+				require.NoError(t, c.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext))
+				require.Equal(t, dbCluster, tlsContext.Sni)
+			}
+			require.True(t, found, "expected a cluster for the db upstream")
+		})
+	}
+}
+
+func TestClustersFromSnapshot_V1V2Parity(t *testing.T) {
+	// Both generators are checked against the same golden files, but that only
+	// compares their JSON renderings. Compare the cluster protos directly so
+	// the two generators cannot drift apart while both still match.
+	tests := map[string]func(t testinf.T) *proxycfg.ConfigSnapshot{
+		"transparent-proxy-catalog-destinations-only": proxycfg.TestConfigSnapshotTransparentProxyCatalogDestinationsOnly,
+		"upstream-max-requests-per-connection": func(t testinf.T) *proxycfg.ConfigSnapshot {
+			return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", false, func(ns *structs.NodeService) {
+				ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+				ns.Proxy.Upstreams[0].Config["envoy_upstream_max_requests_per_connection"] = 1000
+				ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+					"protocol": "http2",
+					"envoy_upstream_max_requests_per_connection": 100,
+				}
+			}, nil)
+		},
+	}
+
+	for name, create := range tests {
+		t.Run(name+"/v1-v2-parity", func(t *testing.T) {
+			snap := create(t)
+			testcommon.SetupTLSRootsAndLeaf(t, snap)
+
+			g := NewResourceGenerator(testutil.Logger(t), nil, false)
+			v1Clusters, err := g.clustersFromSnapshot(snap)
+			require.NoError(t, err)
+
+			converter := proxystateconverter.NewConverter(testutil.Logger(t), &mockCfgFetcher{addressLan: "192.0.2.1"})
+			proxyState, err := converter.ProxyStateFromSnapshot(snap)
+			require.NoError(t, err)
+			v2Resources, err := xdsv2.NewResourceGenerator(testutil.Logger(t)).AllResourcesFromIR(proxyState)
+			require.NoError(t, err)
+			v2Clusters := v2Resources[xdscommon.ClusterType]
+
+			byName := func(clusters []proto.Message) map[string]*envoy_cluster_v3.Cluster {
+				out := make(map[string]*envoy_cluster_v3.Cluster, len(clusters))
+				for _, msg := range clusters {
+					c, ok := msg.(*envoy_cluster_v3.Cluster)
+					require.True(t, ok)
+					out[c.Name] = c
+				}
+				return out
+			}
+			v1ByName := byName(v1Clusters)
+			v2ByName := byName(v2Clusters)
+
+			require.ElementsMatch(t, maps.Keys(v1ByName), maps.Keys(v2ByName))
+			for clusterName, v1Cluster := range v1ByName {
+				require.True(t, proto.Equal(v1Cluster, v2ByName[clusterName]),
+					"cluster %q differs between v1 and v2:\nv1: %s\nv2: %s",
+					clusterName, protoToJSON(t, v1Cluster), protoToJSON(t, v2ByName[clusterName]))
+			}
+		})
+	}
+}
+
+func TestClustersFromSnapshotMeshGateway_IgnoreExtraResolversVersionIndependent(t *testing.T) {
+	// The golden suite runs this case for every Envoy version, but each version
+	// may have its own golden file. Compare the outputs directly so that
+	// version-specific logic cannot creep into this path unnoticed.
+	var (
+		latestVersion string
+		latestJSON    string
+	)
+	for _, envoyVersion := range xdscommon.EnvoyVersions {
+		sf, err := xdscommon.DetermineSupportedProxyFeaturesFromString(envoyVersion)
+		require.NoError(t, err)
+
+		snap := proxycfg.TestConfigSnapshotMeshGateway(t, "ignore-extra-resolvers", nil, nil)
+		testcommon.SetupTLSRootsAndLeaf(t, snap)
+
+		g := NewResourceGenerator(testutil.Logger(t), nil, false)
+		g.ProxyFeatures = sf
+		clusters, err := g.clustersFromSnapshot(snap)
+		require.NoError(t, err)
+
+		sort.Slice(clusters, func(i, j int) bool {
+			return clusters[i].(*envoy_cluster_v3.Cluster).Name < clusters[j].(*envoy_cluster_v3.Cluster).Name
+		})
+		r, err := response.CreateResponse(xdscommon.ClusterType, "00000001", "00000001", clusters)
+		require.NoError(t, err)
+		gotJSON := protoToJSON(t, r)
+
+		if latestJSON == "" {
+			latestVersion, latestJSON = envoyVersion, gotJSON
+			continue
+		}
+		require.JSONEq(t, latestJSON, gotJSON,
+			"clusters for envoy %s differ from envoy %s", envoyVersion, latestVersion)
+	}
+}
+
+func TestClustersFromSnapshot_ClusterMutator(t *testing.T) {
+	t.Run("called for every cluster", func(t *testing.T) {
+		snap := proxycfg.TestConfigSnapshot(t, nil, nil)
+
+		var called []string
+		g := NewResourceGenerator(testutil.Logger(t), nil, false)
+		g.ClusterMutator = func(c *envoy_cluster_v3.Cluster, s *proxycfg.ConfigSnapshot) error {
+			require.Same(t, snap, s)
+			called = append(called, c.Name)
+			c.AltStatName = "mutated_" + c.Name
+			return nil
+		}
+
+		clusters, err := g.clustersFromSnapshot(snap)
+		require.NoError(t, err)
+
+		var names []string
+		for _, msg := range clusters {
+			c, ok := msg.(*envoy_cluster_v3.Cluster)
+			require.True(t, ok)
+			names = append(names, c.Name)
+			require.Equal(t, "mutated_"+c.Name, c.AltStatName)
+		}
+		require.ElementsMatch(t, names, called)
+	})
+
+	t.Run("error fails generation", func(t *testing.T) {
+		snap := proxycfg.TestConfigSnapshot(t, nil, nil)
+
+		g := NewResourceGenerator(testutil.Logger(t), nil, false)
+		g.ClusterMutator = func(c *envoy_cluster_v3.Cluster, _ *proxycfg.ConfigSnapshot) error {
+			if c.Name == "local_app" {
+				return fmt.Errorf("rejected")
+			}
+			return nil
+		}
+
+		_, err := g.clustersFromSnapshot(snap)
+		require.ErrorContains(t, err, `failed to mutate cluster "local_app": rejected`)
+	})
+}
+
+func TestResourceGenerator_DryRunClusters(t *testing.T) {
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+
+	t.Run("valid clusters", func(t *testing.T) {
+		snap := proxycfg.TestConfigSnapshot(t, nil, nil)
+
+		names, errs := g.DryRunClusters(snap)
+		require.Empty(t, errs)
+		require.ElementsMatch(t, []string{
+			"local_app",
+			"db.default.dc1.internal.11111111-2222-3333-4444-555555555555.consul",
+			"geo-cache.default.dc1.query.11111111-2222-3333-4444-555555555555.consul",
+		}, names)
+	})
+
+	t.Run("invalid cluster", func(t *testing.T) {
+		snap := proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+			// A zero connect timeout is rejected by Envoy's validation rules.
+			ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+				"envoy_cluster_json": `{
+					"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster",
+					"name": "custom-geo-cache",
+					"connectTimeout": "0s",
+					"type": "STATIC"
+				}`,
+			}
+		}, nil)
+
+		names, errs := g.DryRunClusters(snap)
+		require.Contains(t, names, "custom-geo-cache")
+		require.Len(t, errs, 1)
+		require.Contains(t, errs[0].Error(), `invalid cluster "custom-geo-cache"`)
+		require.Contains(t, errs[0].Error(), "ConnectTimeout")
+	})
+
+	t.Run("generation error", func(t *testing.T) {
+		snap := proxycfg.TestConfigSnapshot(t, nil, nil)
+		snap.Kind = "not-a-kind"
+
+		names, errs := g.DryRunClusters(snap)
+		require.Nil(t, names)
+		require.Len(t, errs, 1)
+		require.Contains(t, errs[0].Error(), "Invalid service kind")
+	})
+
+	t.Run("nil snapshot", func(t *testing.T) {
+		names, errs := g.DryRunClusters(nil)
+		require.Nil(t, names)
+		require.Len(t, errs, 1)
+	})
+}
+
+func TestClustersFromSnapshot_EscapeHatchConflictLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := hclog.New(&hclog.LoggerOptions{Output: &buf, Level: hclog.Debug})
+
+	snap := proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", false, func(ns *structs.NodeService) {
+		ns.Proxy.Upstreams[0].Config["envoy_cluster_json"] =
+			customAppClusterJSON(t, customClusterJSONOptions{
+				Name: "myservice",
+			})
+	}, nil)
+
+	g := NewResourceGenerator(logger, nil, false)
+	_, err := g.clustersFromSnapshot(snap)
+	require.NoError(t, err)
+
+	out := buf.String()
+	require.Contains(t, out, "envoy_cluster_json overrides fields that Consul generates for this upstream")
+	require.Contains(t, out, `fields=["connectTimeout"]`)
+	// The generated cluster has no load assignment, so it is not a conflict.
+	require.NotContains(t, out, "loadAssignment")
+}
+
+func TestClusterOverrideConflicts(t *testing.T) {
+	generated := &envoy_cluster_v3.Cluster{
+		Name:                 "db",
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &envoy_cluster_v3.Cluster_Type{Type: envoy_cluster_v3.Cluster_EDS},
+		OutlierDetection:     &envoy_cluster_v3.OutlierDetection{},
+	}
+
+	t.Run("no overlap", func(t *testing.T) {
+		override := &envoy_cluster_v3.Cluster{
+			LbPolicy: envoy_cluster_v3.Cluster_RING_HASH,
+		}
+		require.Empty(t, clusterOverrideConflicts(generated, override))
+	})
+
+	t.Run("identical values", func(t *testing.T) {
+		override := proto.Clone(generated).(*envoy_cluster_v3.Cluster)
+		require.Equal(t, []string{"connectTimeout", "outlierDetection", "type"},
+			clusterOverrideConflicts(generated, override))
+	})
+
+	t.Run("differing values", func(t *testing.T) {
+		override := &envoy_cluster_v3.Cluster{
+			Name:                 "db",
+			ConnectTimeout:       durationpb.New(15 * time.Second),
+			ClusterDiscoveryType: &envoy_cluster_v3.Cluster_Type{Type: envoy_cluster_v3.Cluster_STATIC},
+			OutlierDetection: &envoy_cluster_v3.OutlierDetection{
+				Consecutive_5Xx: wrapperspb.UInt32(3),
+			},
+		}
+		require.Equal(t, []string{"connectTimeout", "outlierDetection", "type"},
+			clusterOverrideConflicts(generated, override))
+	})
+
+	t.Run("overlaid fields", func(t *testing.T) {
+		generated := proto.Clone(generated).(*envoy_cluster_v3.Cluster)
+		generated.TransportSocket = &envoy_core_v3.TransportSocket{Name: "tls"}
+		override := &envoy_cluster_v3.Cluster{
+			Name:            "db",
+			TransportSocket: &envoy_core_v3.TransportSocket{Name: "tls"},
+		}
+		require.Empty(t, clusterOverrideConflicts(generated, override))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		require.Nil(t, clusterOverrideConflicts(nil, generated))
+		require.Nil(t, clusterOverrideConflicts(generated, nil))
+	})
+}
+
+func TestClustersFromSnapshotMeshGateway_ServiceSubsetsPassThroughTLS(t *testing.T) {
+	// Mesh gateways route on SNI and pass the sidecars' mTLS session through
+	// untouched, so clusters for local service subsets must not originate TLS
+	// of their own. Subsets therefore have no TLS settings to vary.
+	snap := proxycfg.TestConfigSnapshotMeshGateway(t, "service-subsets", nil, nil)
+
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+	clusters, err := g.clustersFromSnapshotMeshGateway(snap)
+	require.NoError(t, err)
+
+	subsets := map[string]bool{
+		"v1.bar.default.dc1.internal.11111111-2222-3333-4444-555555555555.consul": false,
+		"v2.bar.default.dc1.internal.11111111-2222-3333-4444-555555555555.consul": false,
+	}
+	for _, msg := range clusters {
+		c, ok := msg.(*envoy_cluster_v3.Cluster)
+		require.True(t, ok)
+		if _, ok := subsets[c.Name]; !ok {
+			continue
+		}
+		subsets[c.Name] = true
+		require.Nil(t, c.TransportSocket, "cluster %q", c.Name)
+	}
+	for name, found := range subsets {
+		require.True(t, found, "expected a cluster for subset %q", name)
+	}
+}
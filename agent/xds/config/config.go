@@ -122,6 +122,11 @@ type ProxyConfig struct {
 	// BalanceInboundConnections indicates how the proxy should attempt to distribute
 	// connections across worker threads. Only used by envoy proxies.
 	BalanceInboundConnections string `json:",omitempty" alias:"balance_inbound_connections"`
+
+	// GRPCTranscoding configures the public listener to transcode HTTP/JSON
+	// requests to gRPC before they reach the local app. Only used when
+	// Protocol is "grpc".
+	GRPCTranscoding *structs.GRPCTranscodingConfig `mapstructure:"grpc_transcoding"`
 }
 
 // ParseProxyConfig returns the ProxyConfig parsed from the an opaque map. If an
@@ -166,6 +171,16 @@ type GatewayConfig struct {
 	// See: https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/service_discovery#arch-overview-service-discovery-types
 	DNSDiscoveryType string `mapstructure:"envoy_dns_discovery_type"`
 
+	// DNSResolvers are the DNS servers, as "ip" or "ip:port", that Envoy
+	// queries to resolve upstreams addressed by a hostname instead of the
+	// host's resolv.conf. The port defaults to 53.
+	DNSResolvers []string `mapstructure:"envoy_dns_resolvers"`
+
+	// DNSRefreshRateMs is the number of milliseconds between DNS resolutions
+	// of upstreams that are addressed by a hostname. Defaults to 10000 (10
+	// seconds) if not set.
+	DNSRefreshRateMs int `mapstructure:"envoy_dns_refresh_rate_ms"`
+
 	// ConnectTimeoutMs is the number of milliseconds to timeout making a new
 	// connection to this upstream. Defaults to 5000 (5 seconds) if not set.
 	ConnectTimeoutMs int `mapstructure:"connect_timeout_ms"`
@@ -193,6 +208,31 @@ func ParseGatewayConfig(m map[string]interface{}) (GatewayConfig, error) {
 	return cfg, nil
 }
 
+// ParsePreparedQueryUpstreamConfig parses the config of an upstream that
+// targets a prepared query. Prepared queries have no discovery chain to
+// supply a connect timeout, so when connect_timeout_ms is unset the mesh-wide
+// default is used before falling back to the built-in default. As with
+// structs.ParseUpstreamConfig the defaults are set even if an error is
+// returned.
+func ParsePreparedQueryUpstreamConfig(m map[string]interface{}, mesh *structs.MeshConfigEntry) (structs.UpstreamConfig, error) {
+	cfg, err := structs.ParseUpstreamConfigNoDefaults(m)
+	if cfg.ConnectTimeoutMs == 0 {
+		if d := mesh.DefaultConnectTimeout(); d > 0 {
+			cfg.ConnectTimeoutMs = int(d / time.Millisecond)
+		}
+	}
+
+	defaults, _ := structs.ParseUpstreamConfig(nil)
+	if cfg.Protocol == "" {
+		cfg.Protocol = defaults.Protocol
+	}
+	if cfg.ConnectTimeoutMs == 0 {
+		cfg.ConnectTimeoutMs = defaults.ConnectTimeoutMs
+	}
+
+	return cfg, err
+}
+
 // Return an envoy.OutlierDetection populated by the values from structs.PassiveHealthCheck.
 // If all values are zero a default empty OutlierDetection will be returned to
 // enable outlier detection with default values.
@@ -226,6 +266,12 @@ func ToOutlierDetection(p *structs.PassiveHealthCheck, override *structs.Passive
 		if p.BaseEjectionTime != nil {
 			od.BaseEjectionTime = durationpb.New(*p.BaseEjectionTime)
 		}
+		if p.SplitExternalLocalOriginErrors {
+			od.SplitExternalLocalOriginErrors = true
+		}
+		if p.ConsecutiveLocalOriginFailure != nil {
+			od.ConsecutiveLocalOriginFailure = &wrapperspb.UInt32Value{Value: *p.ConsecutiveLocalOriginFailure}
+		}
 	}
 
 	if override == nil {
@@ -255,6 +301,12 @@ func ToOutlierDetection(p *structs.PassiveHealthCheck, override *structs.Passive
 	if override.BaseEjectionTime != nil {
 		od.BaseEjectionTime = durationpb.New(*override.BaseEjectionTime)
 	}
+	if override.SplitExternalLocalOriginErrors {
+		od.SplitExternalLocalOriginErrors = true
+	}
+	if override.ConsecutiveLocalOriginFailure != nil {
+		od.ConsecutiveLocalOriginFailure = &wrapperspb.UInt32Value{Value: *override.ConsecutiveLocalOriginFailure}
+	}
 
 	return od
 }
@@ -207,6 +207,24 @@ func TestParseProxyConfig(t *testing.T) {
 				BalanceInboundConnections: "exact_balance",
 			},
 		},
+		{
+			name: "grpc transcoding",
+			input: map[string]interface{}{
+				"protocol": "grpc",
+				"grpc_transcoding": map[string]interface{}{
+					"proto_descriptor": "/etc/envoy/bookstore.pb",
+					"services":         []interface{}{"bookstore.Bookstore"},
+				},
+			},
+			want: ProxyConfig{
+				LocalConnectTimeoutMs: 5000,
+				Protocol:              "grpc",
+				GRPCTranscoding: &structs.GRPCTranscodingConfig{
+					ProtoDescriptor: "/etc/envoy/bookstore.pb",
+					Services:        []string{"bookstore.Bookstore"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -254,6 +272,7 @@ func TestParseGatewayConfig(t *testing.T) {
 				"envoy_gateway_bind_addresses":        map[string]structs.ServiceAddress{"foo": {Address: "127.0.0.1", Port: 80}},
 				"envoy_gateway_no_default_bind":       true,
 				"envoy_dns_discovery_type":            "StRiCt_DnS",
+				"envoy_dns_refresh_rate_ms":           1000,
 				"connect_timeout_ms":                  10,
 			},
 			want: GatewayConfig{
@@ -262,6 +281,7 @@ func TestParseGatewayConfig(t *testing.T) {
 				NoDefaultBind:       true,
 				BindAddresses:       map[string]structs.ServiceAddress{"foo": {Address: "127.0.0.1", Port: 80}},
 				DNSDiscoveryType:    "strict_dns",
+				DNSRefreshRateMs:    1000,
 			},
 		},
 		{
@@ -370,3 +390,42 @@ func Test_ProxyConfig_GetXDSFetchTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePreparedQueryUpstreamConfig(t *testing.T) {
+	meshTimeout := &structs.MeshConfigEntry{
+		ConnectTimeout: structs.DurationPointer(12 * time.Second),
+	}
+
+	tests := map[string]struct {
+		input map[string]interface{}
+		mesh  *structs.MeshConfigEntry
+		want  int
+	}{
+		"defaults": {
+			want: 5000,
+		},
+		"mesh without timeout": {
+			mesh: &structs.MeshConfigEntry{},
+			want: 5000,
+		},
+		"mesh default": {
+			mesh: meshTimeout,
+			want: 12000,
+		},
+		"upstream over mesh default": {
+			input: map[string]interface{}{
+				"connect_timeout_ms": 2345,
+			},
+			mesh: meshTimeout,
+			want: 2345,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePreparedQueryUpstreamConfig(tc.input, tc.mesh)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.ConnectTimeoutMs)
+			require.Equal(t, "tcp", got.Protocol)
+		})
+	}
+}
@@ -17,12 +17,14 @@ import (
 
 	"github.com/armon/go-metrics"
 	envoy_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/grpc-external/limiter"
 	"github.com/hashicorp/consul/agent/proxycfg"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/xds/extensionruntime"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/envoyextensions/extensioncommon"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -35,6 +37,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // NOTE: For these tests, prefer not using xDS protobuf "factory" methods if
@@ -1949,6 +1952,53 @@ func Test_applyEnvoyExtension_CanApply(t *testing.T) {
 	}
 }
 
+func Test_applyEnvoyExtension_PatchesGeneratedClusters(t *testing.T) {
+	// Extensions configured in the service-defaults of the local service are
+	// applied after the base clusters are generated, and only add to them.
+	snap := proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+		ns.Proxy.EnvoyExtensions = []structs.EnvoyExtension{
+			{Name: "clusterMetadataExtension"},
+		}
+	}, nil)
+
+	g := NewResourceGenerator(testutil.Logger(t), nil, false)
+	clusters, err := g.clustersFromSnapshot(snap)
+	require.NoError(t, err)
+	require.NotEmpty(t, clusters)
+	indexedResources := xdscommon.IndexResources(testutil.Logger(t), map[string][]proto.Message{
+		xdscommon.ClusterType: clusters,
+	})
+
+	var configs []extensioncommon.RuntimeConfig
+	for svc, cfgs := range extensionruntime.GetRuntimeConfigurations(snap) {
+		if svc.Name == "web" {
+			configs = cfgs
+		}
+	}
+	require.Len(t, configs, 1)
+	require.False(t, configs[0].IsSourcedFromUpstream)
+
+	extender := extensioncommon.BasicEnvoyExtender{
+		Extension: &clusterMetadataExtension{},
+	}
+	result, err := applyEnvoyExtension(&extender, indexedResources, &configs[0])
+	require.NoError(t, err)
+	require.Len(t, result.Index[xdscommon.ClusterType], len(clusters))
+
+	for _, msg := range clusters {
+		base := msg.(*envoy_cluster_v3.Cluster)
+		// The generated cluster is cloned before it is patched.
+		require.Nil(t, base.Metadata)
+
+		patched := proto.Clone(result.Index[xdscommon.ClusterType][base.Name]).(*envoy_cluster_v3.Cluster)
+		require.True(t, patched.GetMetadata().GetFilterMetadata()["consul.test"].GetFields()["patched"].GetBoolValue(),
+			"cluster %q was not patched", base.Name)
+
+		patched.Metadata = nil
+		require.True(t, proto.Equal(base, patched), "cluster %q lost generated config", base.Name)
+	}
+}
+
 func Test_applyEnvoyExtension_PartialApplicationDisallowed(t *testing.T) {
 	type testCase struct {
 		name            string
@@ -2124,6 +2174,33 @@ func (m *maybeCanApplyExtension) PatchListener(payload extensioncommon.ListenerP
 	return payload.Message, true, nil
 }
 
+// clusterMetadataExtension appends filter metadata to every cluster.
+type clusterMetadataExtension struct {
+	extensioncommon.BasicExtensionAdapter
+}
+
+var _ extensioncommon.BasicExtension = (*clusterMetadataExtension)(nil)
+
+func (c *clusterMetadataExtension) CanApply(_ *extensioncommon.RuntimeConfig) bool {
+	return true
+}
+
+func (c *clusterMetadataExtension) PatchCluster(payload extensioncommon.ClusterPayload) (*envoy_cluster_v3.Cluster, bool, error) {
+	cluster := payload.Message
+	if cluster.Metadata == nil {
+		cluster.Metadata = &envoy_core_v3.Metadata{}
+	}
+	if cluster.Metadata.FilterMetadata == nil {
+		cluster.Metadata.FilterMetadata = make(map[string]*structpb.Struct)
+	}
+	cluster.Metadata.FilterMetadata["consul.test"] = &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"patched": structpb.NewBoolValue(true),
+		},
+	}
+	return cluster, true, nil
+}
+
 type partialFailureExtension struct {
 	extensioncommon.BasicExtensionAdapter
 	returnOnFailure bool
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -15,6 +16,7 @@ import (
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/hashicorp/go-bexpr"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/proxycfg"
@@ -200,6 +202,26 @@ func (s *ResourceGenerator) filterSubsetEndpoints(subset *structs.ServiceResolve
 	return endpoints, nil
 }
 
+// filterResolverSubsetEndpoints filters the endpoints for a subset of the
+// given resolver. If the subset matches no endpoints and the resolver asks to
+// fall back to the default subset, all of the endpoints are returned instead.
+func (s *ResourceGenerator) filterResolverSubsetEndpoints(
+	resolver *structs.ServiceResolverConfigEntry,
+	subset *structs.ServiceResolverSubset,
+	endpoints structs.CheckServiceNodes,
+) (structs.CheckServiceNodes, error) {
+	subsetEndpoints, err := s.filterSubsetEndpoints(subset, endpoints)
+	if err != nil {
+		return nil, err
+	}
+	if len(subsetEndpoints) == 0 && resolver.FallbackOnEmptySubset() {
+		s.Logger.Debug("subset matched no endpoints, falling back to default subset",
+			"resolver", resolver.Name, "filter", subset.Filter)
+		return endpoints, nil
+	}
+	return subsetEndpoints, nil
+}
+
 func (s *ResourceGenerator) endpointsFromSnapshotTerminatingGateway(cfgSnap *proxycfg.ConfigSnapshot) ([]proto.Message, error) {
 	return s.endpointsFromServicesAndResolvers(cfgSnap, cfgSnap.TerminatingGateway.ServiceGroups, cfgSnap.TerminatingGateway.ServiceResolvers)
 }
@@ -406,7 +428,7 @@ func (s *ResourceGenerator) endpointsFromServicesAndResolvers(
 		// instances.
 		if resolver, hasResolver := resolvers[svc]; hasResolver {
 			for subsetName, subset := range resolver.Subsets {
-				subsetEndpoints, err := s.filterSubsetEndpoints(&subset, endpoints)
+				subsetEndpoints, err := s.filterResolverSubsetEndpoints(resolver, &subset, endpoints)
 				if err != nil {
 					return nil, err
 				}
@@ -792,6 +814,9 @@ func (s *ResourceGenerator) endpointsFromDiscoveryChain(
 				[]loadAssignmentEndpointGroup{endpointGroup},
 				gatewayKey,
 			)
+			if failover := node.Resolver.Failover; failover != nil {
+				setFailoverOverprovisioningFactor(la, failover.OverprovisioningFactor)
+			}
 			resources = append(resources, la)
 		}
 	}
@@ -835,7 +860,7 @@ func (s *ResourceGenerator) makeExportedUpstreamEndpointsForMeshGateway(cfgSnap
 					continue // ignore; not ready
 				}
 
-				subsetEndpoints, err := s.filterSubsetEndpoints(&subset, endpoints)
+				subsetEndpoints, err := s.filterResolverSubsetEndpoints(resolver, &subset, endpoints)
 				if err != nil {
 					return nil, err
 				}
@@ -874,6 +899,83 @@ type loadAssignmentEndpointGroup struct {
 	OverrideHealth envoy_core_v3.HealthStatus
 }
 
+type geoLocalityGroup struct {
+	locality  *structs.Locality
+	endpoints structs.CheckServiceNodes
+}
+
+// groupByGeoLocality groups endpoints by the geographic locality in their
+// service metadata, preserving the order in which each locality first appears.
+func groupByGeoLocality(csns structs.CheckServiceNodes) []geoLocalityGroup {
+	var groups []geoLocalityGroup
+	index := make(map[structs.Locality]int)
+	for _, csn := range csns {
+		var key structs.Locality
+		locality := csn.GeoLocality()
+		if locality != nil {
+			key = *locality
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, geoLocalityGroup{locality: locality})
+		}
+		groups[i].endpoints = append(groups[i].endpoints, csn)
+	}
+	if len(groups) == 0 {
+		// Preserve a single, empty group so callers still emit an entry.
+		groups = append(groups, geoLocalityGroup{})
+	}
+	return groups
+}
+
+func makeEnvoyLocality(locality *structs.Locality) *envoy_core_v3.Locality {
+	if locality == nil {
+		return nil
+	}
+	return &envoy_core_v3.Locality{
+		Region: locality.Region,
+		Zone:   locality.Zone,
+	}
+}
+
+// endpointLBMetadataTagPrefix marks service tags that are copied into the
+// "envoy.lb" filter metadata of the instance's endpoint, for use by Envoy's
+// subset load balancer. For example the tag "envoy.lb.group=canary" becomes
+// the metadata key "group" with the value "canary".
+const endpointLBMetadataTagPrefix = "envoy.lb."
+
+// makeEndpointLBMetadata returns the endpoint metadata for the "envoy.lb."
+// tags of a service instance, or nil if it has none. Tags that are not of the
+// form "envoy.lb.<key>=<value>" are ignored.
+func makeEndpointLBMetadata(tags []string) *envoy_core_v3.Metadata {
+	var fields map[string]*structpb.Value
+	for _, tag := range tags {
+		kv, ok := strings.CutPrefix(tag, endpointLBMetadataTagPrefix)
+		if !ok {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]*structpb.Value)
+		}
+		fields[k] = structpb.NewStringValue(v)
+	}
+	if fields == nil {
+		return nil
+	}
+
+	return &envoy_core_v3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			"envoy.lb": {Fields: fields},
+		},
+	}
+}
+
 func makeLoadAssignment(logger hclog.Logger, cfgSnap *proxycfg.ConfigSnapshot, clusterName string, policy *structs.DiscoveryPrioritizeByLocality, endpointGroups []loadAssignmentEndpointGroup, localKey proxycfg.GatewayKey) *envoy_endpoint_v3.ClusterLoadAssignment {
 	cla := &envoy_endpoint_v3.ClusterLoadAssignment{
 		ClusterName: clusterName,
@@ -896,34 +998,40 @@ func makeLoadAssignment(logger hclog.Logger, cfgSnap *proxycfg.ConfigSnapshot, c
 		}
 
 		for _, endpoints := range endpointsByLocality {
-			es := make([]*envoy_endpoint_v3.LbEndpoint, 0, len(endpointGroup.Endpoints))
+			// Endpoints of the same priority are further split by the geographic
+			// locality described in their service metadata.
+			for _, geoGroup := range groupByGeoLocality(endpoints) {
+				es := make([]*envoy_endpoint_v3.LbEndpoint, 0, len(geoGroup.endpoints))
 
-			for _, ep := range endpoints {
-				// TODO (mesh-gateway) - should we respect the translate_wan_addrs configuration here or just always use the wan for cross-dc?
-				_, addr, port := ep.BestAddress(!localKey.Matches(ep.Node.Datacenter, ep.Node.PartitionOrDefault()))
-				healthStatus, weight := calculateEndpointHealthAndWeight(ep, endpointGroup.OnlyPassing)
+				for _, ep := range geoGroup.endpoints {
+					// TODO (mesh-gateway) - should we respect the translate_wan_addrs configuration here or just always use the wan for cross-dc?
+					_, addr, port := ep.BestAddress(!localKey.Matches(ep.Node.Datacenter, ep.Node.PartitionOrDefault()))
+					healthStatus, weight := calculateEndpointHealthAndWeight(ep, endpointGroup.OnlyPassing)
 
-				if endpointGroup.OverrideHealth != envoy_core_v3.HealthStatus_UNKNOWN {
-					healthStatus = endpointGroup.OverrideHealth
-				}
+					if endpointGroup.OverrideHealth != envoy_core_v3.HealthStatus_UNKNOWN {
+						healthStatus = endpointGroup.OverrideHealth
+					}
 
-				endpoint := &envoy_endpoint_v3.Endpoint{
-					Address: response.MakeAddress(addr, port),
+					endpoint := &envoy_endpoint_v3.Endpoint{
+						Address: response.MakeAddress(addr, port),
+					}
+					es = append(es, &envoy_endpoint_v3.LbEndpoint{
+						HostIdentifier: &envoy_endpoint_v3.LbEndpoint_Endpoint{
+							Endpoint: endpoint,
+						},
+						HealthStatus:        healthStatus,
+						LoadBalancingWeight: response.MakeUint32Value(weight),
+						Metadata:            makeEndpointLBMetadata(ep.Service.Tags),
+					})
 				}
-				es = append(es, &envoy_endpoint_v3.LbEndpoint{
-					HostIdentifier: &envoy_endpoint_v3.LbEndpoint_Endpoint{
-						Endpoint: endpoint,
-					},
-					HealthStatus:        healthStatus,
-					LoadBalancingWeight: response.MakeUint32Value(weight),
+
+				cla.Endpoints = append(cla.Endpoints, &envoy_endpoint_v3.LocalityLbEndpoints{
+					Locality:    makeEnvoyLocality(geoGroup.locality),
+					Priority:    priority,
+					LbEndpoints: es,
 				})
 			}
 
-			cla.Endpoints = append(cla.Endpoints, &envoy_endpoint_v3.LocalityLbEndpoints{
-				Priority:    priority,
-				LbEndpoints: es,
-			})
-
 			priority++
 		}
 	}
@@ -995,6 +1103,20 @@ func makeLoadAssignmentEndpointGroup(
 	}, true
 }
 
+// setFailoverOverprovisioningFactor applies the overprovisioning factor
+// configured on a service-resolver failover to a load assignment for one of
+// the failover's clusters. Envoy's aggregate cluster uses each underlying
+// cluster's factor to decide when traffic spills over to the next cluster.
+func setFailoverOverprovisioningFactor(la *envoy_endpoint_v3.ClusterLoadAssignment, factor uint32) {
+	if factor == 0 {
+		return
+	}
+	if la.Policy == nil {
+		la.Policy = &envoy_endpoint_v3.ClusterLoadAssignment_Policy{}
+	}
+	la.Policy.OverprovisioningFactor = response.MakeUint32Value(int(factor))
+}
+
 func calculateEndpointHealthAndWeight(
 	ep structs.CheckServiceNode,
 	onlyPassing bool,
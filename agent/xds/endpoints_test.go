@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/mitchellh/copystructure"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func Test_makeLoadAssignment(t *testing.T) {
@@ -229,3 +230,44 @@ func Test_makeLoadAssignment(t *testing.T) {
 		})
 	}
 }
+
+func Test_makeEndpointLBMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want *envoy_core_v3.Metadata
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name: "no lb tags",
+			tags: []string{"primary", "group=canary"},
+			want: nil,
+		},
+		{
+			name: "lb tags",
+			tags: []string{"primary", "envoy.lb.group=canary", "envoy.lb.version=v2"},
+			want: &envoy_core_v3.Metadata{
+				FilterMetadata: map[string]*structpb.Struct{
+					"envoy.lb": {Fields: map[string]*structpb.Value{
+						"group":   structpb.NewStringValue("canary"),
+						"version": structpb.NewStringValue("v2"),
+					}},
+				},
+			},
+		},
+		{
+			name: "malformed lb tags are ignored",
+			tags: []string{"envoy.lb.group", "envoy.lb.=canary"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, makeEndpointLBMetadata(tt.tags))
+		})
+	}
+}
@@ -70,6 +70,11 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 		return discoChainTargets{}, err
 	}
 
+	if _, ok := chain.Targets[primaryTargetID]; !ok {
+		// Nothing is generated for a resolver whose target was excluded
+		// from the chain.
+		return discoChainTargets{}, nil
+	}
 	failoverTargets.baseClusterName = s.getTargetClusterName(upstreamsSnapshot, chain, primaryTargetID, forMeshGateway)
 
 	tids := []string{primaryTargetID}
@@ -85,7 +90,11 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 	}
 
 	for _, tid := range tids {
-		target := chain.Targets[tid]
+		target, ok := chain.Targets[tid]
+		if !ok {
+			s.Logger.Debug("skipping failover target missing from the discovery chain", "target", tid)
+			continue
+		}
 		var sni, rootPEMs string
 		var spiffeIDs []string
 		targetUID := proxycfg.NewUpstreamIDFromTargetID(tid)
@@ -118,13 +127,7 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 		} else {
 			sni = target.SNI
 			rootPEMs = cfgSnap.RootPEMs()
-			spiffeIDs = []string{connect.SpiffeIDService{
-				Host:       cfgSnap.Roots.TrustDomain,
-				Namespace:  target.Namespace,
-				Partition:  target.Partition,
-				Datacenter: target.Datacenter,
-				Service:    target.Service,
-			}.URI().String()}
+			spiffeIDs = targetSANs(cfgSnap, target)
 		}
 		commonTLSContext := makeCommonTLSContext(
 			cfgSnap.Leaf(),
@@ -136,11 +139,18 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 		if err != nil {
 			return failoverTargets, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 		}
+		commonTLSContext.AlpnProtocols = upstreamALPNProtocols(cfgSnap, upstreamConfig)
 
 		tlsContext := &envoy_tls_v3.UpstreamTlsContext{
 			CommonTlsContext: commonTLSContext,
 			Sni:              sni,
 		}
+		// Mesh gateways route on the SNI, so the override is only honored
+		// by proxies that connect to the target directly.
+		if upstreamConfig.SNI != "" && !forMeshGateway &&
+			!target.ViaMeshGateway(cfgSnap.Datacenter, cfgSnap.ProxyID.PartitionOrDefault()) {
+			tlsContext.Sni = upstreamConfig.SNI
+		}
 		ti.TLSContext = tlsContext
 		failoverTargets.targets = append(failoverTargets.targets, ti)
 	}
@@ -148,6 +158,21 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 	return failoverTargets, nil
 }
 
+// targetSANs returns the URI SANs that certificates presented by a local
+// target are validated against.
+func targetSANs(cfgSnap *proxycfg.ConfigSnapshot, target *structs.DiscoveryTarget) []string {
+	if len(target.CustomSANs) > 0 {
+		return target.CustomSANs
+	}
+	return []string{connect.SpiffeIDService{
+		Host:       cfgSnap.Roots.TrustDomain,
+		Namespace:  target.Namespace,
+		Partition:  target.Partition,
+		Datacenter: target.Datacenter,
+		Service:    target.Service,
+	}.URI().String()}
+}
+
 func (ft discoChainTargets) sequential() ([]discoChainTargetGroup, error) {
 	var targetGroups []discoChainTargetGroup
 	for i, t := range ft.targets {
@@ -6,6 +6,7 @@ package xds
 import (
 	"encoding/base64"
 	"fmt"
+	"time"
 
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
@@ -237,15 +238,14 @@ func makeRemoteJWKS(r *structs.RemoteJWKS, providerName string) *envoy_http_jwt_
 		RemoteJwks: &envoy_http_jwt_authn_v3.RemoteJwks{
 			HttpUri: &envoy_core_v3.HttpUri{
 				Uri:              r.URI,
-				HttpUpstreamType: &envoy_core_v3.HttpUri_Cluster{Cluster: makeJWKSClusterName(providerName)},
+				HttpUpstreamType: &envoy_core_v3.HttpUri_Cluster{Cluster: jwksClusterName(r, providerName)},
 			},
 			AsyncFetch: &envoy_http_jwt_authn_v3.JwksAsyncFetch{
 				FastListener: r.FetchAsynchronously,
 			},
 		},
 	}
-	timeOutSecond := int64(r.RequestTimeoutMs) / 1000
-	remote_specifier.RemoteJwks.HttpUri.Timeout = &durationpb.Duration{Seconds: timeOutSecond}
+	remote_specifier.RemoteJwks.HttpUri.Timeout = durationpb.New(time.Duration(r.RequestTimeoutMs) * time.Millisecond)
 	cacheDuration := int64(r.CacheDuration)
 	if cacheDuration > 0 {
 		remote_specifier.RemoteJwks.CacheDuration = &durationpb.Duration{Seconds: cacheDuration}
@@ -263,6 +263,16 @@ func makeJWKSClusterName(providerName string) string {
 	return fmt.Sprintf("%s_%s", jwksClusterPrefix, providerName)
 }
 
+// jwksClusterName returns the name of the cluster used to fetch a remote JWKS.
+// An explicit JWKSCluster.ClusterName takes precedence over the name derived
+// from the provider.
+func jwksClusterName(r *structs.RemoteJWKS, providerName string) string {
+	if r != nil && r.JWKSCluster != nil && r.JWKSCluster.ClusterName != "" {
+		return r.JWKSCluster.ClusterName
+	}
+	return makeJWKSClusterName(providerName)
+}
+
 func buildJWTRetryPolicy(r *structs.JWKSRetryPolicy) *envoy_core_v3.RetryPolicy {
 	var pol envoy_core_v3.RetryPolicy
 	if r == nil {
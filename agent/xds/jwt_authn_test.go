@@ -5,6 +5,7 @@ package xds
 
 import (
 	"encoding/base64"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	envoy_http_jwt_authn_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -475,6 +477,29 @@ func TestMakeRemoteJWKS(t *testing.T) {
 				},
 			},
 		},
+		"with-cluster-name-override": {
+			jwks: &structs.RemoteJWKS{
+				RequestTimeoutMs:    1000,
+				FetchAsynchronously: true,
+				URI:                 oktaRemoteJWKS.URI,
+				JWKSCluster: &structs.JWKSCluster{
+					ClusterName: "okta-jwks-failover",
+				},
+			},
+			providerName: "okta",
+			expected: &envoy_http_jwt_authn_v3.JwtProvider_RemoteJwks{
+				RemoteJwks: &envoy_http_jwt_authn_v3.RemoteJwks{
+					HttpUri: &envoy_core_v3.HttpUri{
+						Uri:              oktaRemoteJWKS.URI,
+						HttpUpstreamType: &envoy_core_v3.HttpUri_Cluster{Cluster: "okta-jwks-failover"},
+						Timeout:          &durationpb.Duration{Seconds: 1},
+					},
+					AsyncFetch: &envoy_http_jwt_authn_v3.JwksAsyncFetch{
+						FastListener: true,
+					},
+				},
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -486,6 +511,28 @@ func TestMakeRemoteJWKS(t *testing.T) {
 	}
 }
 
+func TestMakeRemoteJWKS_RequestTimeout(t *testing.T) {
+	// The timeout is kept to the millisecond rather than truncated to seconds.
+	tests := map[int]*durationpb.Duration{
+		1:    {Nanos: 1000000},
+		999:  {Nanos: 999000000},
+		1000: {Seconds: 1},
+		1001: {Seconds: 1, Nanos: 1000000},
+	}
+
+	for ms, expected := range tests {
+		ms, expected := ms, expected
+		t.Run(fmt.Sprintf("%dms", ms), func(t *testing.T) {
+			jwks := &structs.RemoteJWKS{
+				RequestTimeoutMs: ms,
+				URI:              oktaRemoteJWKS.URI,
+			}
+			res := makeRemoteJWKS(jwks, "okta")
+			require.True(t, proto.Equal(expected, res.RemoteJwks.HttpUri.Timeout), "got %v", res.RemoteJwks.HttpUri.Timeout)
+		})
+	}
+}
+
 func TestBuildJWTRetryPolicy(t *testing.T) {
 	var (
 		noBackofRetryPolicy = &structs.JWKSRetryPolicy{NumRetries: 1}
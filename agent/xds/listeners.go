@@ -18,7 +18,9 @@ import (
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_grpc_http1_bridge_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_http1_bridge/v3"
+	envoy_grpc_json_transcoder_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
 	envoy_grpc_stats_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
+	envoy_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	envoy_http_router_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	envoy_extensions_filters_listener_http_inspector_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/http_inspector/v3"
 	envoy_original_dst_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/original_dst/v3"
@@ -184,6 +186,8 @@ func (s *ResourceGenerator) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.
 				useRDS:          useRDS,
 				fetchTimeoutRDS: cfgSnap.GetXDSCommonConfig(s.Logger).GetXDSFetchTimeout(),
 				tracing:         tracing,
+				localRateLimit:  chainHasVirtualHostRateLimit(chain),
+				retryPolicy:     cfg.RetryPolicy,
 			})
 			if err != nil {
 				return nil, err
@@ -220,6 +224,8 @@ func (s *ResourceGenerator) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.
 			useRDS:          useRDS,
 			fetchTimeoutRDS: cfgSnap.GetXDSCommonConfig(s.Logger).GetXDSFetchTimeout(),
 			tracing:         tracing,
+			localRateLimit:  chainHasVirtualHostRateLimit(chain),
+			retryPolicy:     cfg.RetryPolicy,
 		})
 		if err != nil {
 			return nil, err
@@ -400,10 +406,11 @@ func (s *ResourceGenerator) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.
 					upstreamCfg.DestinationName,
 					upstreamCfg.DestinationNamespace,
 					upstreamCfg.DestinationPeer),
-				routeName:  uid.EnvoyID(),
-				protocol:   cfg.Protocol,
-				useRDS:     false,
-				statPrefix: "upstream_peered.",
+				routeName:   uid.EnvoyID(),
+				protocol:    cfg.Protocol,
+				useRDS:      false,
+				statPrefix:  "upstream_peered.",
+				retryPolicy: cfg.RetryPolicy,
 			})
 			if err != nil {
 				return nil, err
@@ -440,10 +447,11 @@ func (s *ResourceGenerator) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.
 				uid.Name,
 				uid.NamespaceOrDefault(),
 				uid.Peer),
-			protocol:   cfg.Protocol,
-			useRDS:     false,
-			statPrefix: "upstream_peered.",
-			tracing:    tracing,
+			protocol:    cfg.Protocol,
+			useRDS:      false,
+			statPrefix:  "upstream_peered.",
+			tracing:     tracing,
+			retryPolicy: cfg.RetryPolicy,
 		})
 		if err != nil {
 			return nil, err
@@ -613,6 +621,7 @@ func (s *ResourceGenerator) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.
 			routeName:   uid.EnvoyID(),
 			protocol:    cfg.Protocol,
 			tracing:     tracing,
+			retryPolicy: cfg.RetryPolicy,
 		})
 		if err != nil {
 			return nil, err
@@ -1176,6 +1185,9 @@ func createDownstreamTransportSocketForConnectTLS(cfgSnap *proxycfg.ConfigSnapsh
 	if tlsContext != nil {
 		// Configure alpn protocols on CommonTLSContext
 		tlsContext.AlpnProtocols = getAlpnProtocols(proxyCfg.Protocol)
+		if tlsCfg := cfgSnap.MeshConfigTLSIncoming(); tlsCfg != nil && len(tlsCfg.ALPNProtocols) > 0 {
+			tlsContext.AlpnProtocols = tlsCfg.ALPNProtocols
+		}
 	}
 
 	// Inject peering trust bundles if this service is exported to peered clusters.
@@ -1356,6 +1368,7 @@ func (s *ResourceGenerator) makeInboundListener(cfgSnap *proxycfg.ConfigSnapshot
 		tracing:          tracing,
 		accessLogs:       &cfgSnap.Proxy.AccessLogs,
 		logger:           s.Logger,
+		grpcTranscoding:  cfg.GRPCTranscoding,
 	}
 	if useHTTPFilter {
 		jwtFilter, err := makeJWTAuthFilter(cfgSnap.JWTProviders, cfgSnap.ConnectProxy.Intentions)
@@ -1384,6 +1397,9 @@ func (s *ResourceGenerator) makeInboundListener(cfgSnap *proxycfg.ConfigSnapshot
 
 		meshConfig := cfgSnap.MeshConfig()
 		includeXFCC := meshConfig == nil || meshConfig.HTTP == nil || !meshConfig.HTTP.SanitizeXForwardedClientCert
+		if meshConfig != nil && meshConfig.HTTP != nil {
+			filterOpts.responseHeadersToRemove = meshConfig.HTTP.ResponseHeadersToRemove
+		}
 		notGRPC := cfg.Protocol != "grpc"
 		if includeXFCC && notGRPC {
 			filterOpts.forwardClientDetails = true
@@ -2204,6 +2220,8 @@ type filterChainOpts struct {
 	forwardClientDetails bool
 	forwardClientPolicy  envoy_http_v3.HttpConnectionManager_ForwardClientCertDetails
 	tracing              *envoy_http_v3.HttpConnectionManager_Tracing
+	localRateLimit       bool
+	retryPolicy          *structs.UpstreamRetryPolicy
 }
 
 func (s *ResourceGenerator) makeUpstreamFilterChain(opts filterChainOpts) (*envoy_listener_v3.FilterChain, error) {
@@ -2222,6 +2240,8 @@ func (s *ResourceGenerator) makeUpstreamFilterChain(opts filterChainOpts) (*envo
 		forwardClientPolicy:  opts.forwardClientPolicy,
 		tracing:              opts.tracing,
 		accessLogs:           opts.accessLogs,
+		localRateLimit:       opts.localRateLimit,
+		retryPolicy:          opts.retryPolicy,
 		logger:               s.Logger,
 	})
 	if err != nil {
@@ -2240,6 +2260,18 @@ func (s *ResourceGenerator) makeUpstreamFilterChain(opts filterChainOpts) (*envo
 	}, nil
 }
 
+// localRateLimitFilterName is the HTTP filter that enforces the virtual host
+// rate limits configured on a service-router.
+const localRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+
+// chainHasVirtualHostRateLimit returns true if requests routed through the
+// chain are subject to a virtual host rate limit, in which case the HTTP
+// filter chain needs the local rate limit filter.
+func chainHasVirtualHostRateLimit(chain *structs.CompiledDiscoveryChain) bool {
+	startNode := chain.Nodes[chain.StartNode]
+	return startNode != nil && startNode.IsRouter() && startNode.VirtualHostRateLimit != nil
+}
+
 // simpleChainTarget returns the discovery target for a chain with a single node.
 // A chain can have a single target if it is for a TCP service or an HTTP service without
 // multiple splits/routes/failovers.
@@ -2365,16 +2397,20 @@ type listenerFilterOpts struct {
 	statPrefix string
 
 	// HTTP listener filter options
-	forwardClientDetails bool
-	forwardClientPolicy  envoy_http_v3.HttpConnectionManager_ForwardClientCertDetails
-	httpAuthzFilters     []*envoy_http_v3.HttpFilter
-	idleTimeoutMs        *int
-	requestTimeoutMs     *int
-	routeName            string
-	routePath            string
-	tracing              *envoy_http_v3.HttpConnectionManager_Tracing
-	useRDS               bool
-	fetchTimeoutRDS      *durationpb.Duration
+	forwardClientDetails    bool
+	forwardClientPolicy     envoy_http_v3.HttpConnectionManager_ForwardClientCertDetails
+	grpcTranscoding         *structs.GRPCTranscodingConfig
+	httpAuthzFilters        []*envoy_http_v3.HttpFilter
+	idleTimeoutMs           *int
+	requestTimeoutMs        *int
+	responseHeadersToRemove []string
+	localRateLimit          bool
+	retryPolicy             *structs.UpstreamRetryPolicy
+	routeName               string
+	routePath               string
+	tracing                 *envoy_http_v3.HttpConnectionManager_Tracing
+	useRDS                  bool
+	fetchTimeoutRDS         *durationpb.Duration
 }
 
 func makeListenerFilter(opts listenerFilterOpts) (*envoy_listener_v3.Filter, error) {
@@ -2541,6 +2577,11 @@ func makeHTTPFilter(opts listenerFilterOpts) (*envoy_listener_v3.Filter, error)
 			r.IdleTimeout = durationpb.New(time.Duration(*opts.idleTimeoutMs) * time.Millisecond)
 		}
 
+		if opts.retryPolicy != nil {
+			r := route.GetRoute()
+			r.RetryPolicy = makeUpstreamRetryPolicy(opts.retryPolicy)
+		}
+
 		// If a path is provided, do not match on a catch-all prefix
 		if opts.routePath != "" {
 			route.Match.PathSpecifier = &envoy_route_v3.RouteMatch_Path{Path: opts.routePath}
@@ -2548,7 +2589,8 @@ func makeHTTPFilter(opts listenerFilterOpts) (*envoy_listener_v3.Filter, error)
 
 		cfg.RouteSpecifier = &envoy_http_v3.HttpConnectionManager_RouteConfig{
 			RouteConfig: &envoy_route_v3.RouteConfiguration{
-				Name: opts.routeName,
+				Name:                    opts.routeName,
+				ResponseHeadersToRemove: opts.responseHeadersToRemove,
 				VirtualHosts: []*envoy_route_v3.VirtualHost{
 					{
 						Name:    opts.filterName,
@@ -2578,14 +2620,45 @@ func makeHTTPFilter(opts listenerFilterOpts) (*envoy_listener_v3.Filter, error)
 		}
 	}
 
+	// The local rate limit filter does nothing on its own. It only enforces
+	// the limits configured per virtual host in the route configuration.
+	if opts.localRateLimit {
+		rateLimit, err := makeEnvoyHTTPFilter(localRateLimitFilterName, &envoy_local_ratelimit_v3.LocalRateLimit{
+			StatPrefix: "local_rate_limit",
+		})
+		if err != nil {
+			return nil, err
+		}
+		cfg.HttpFilters = append([]*envoy_http_v3.HttpFilter{rateLimit}, cfg.HttpFilters...)
+	}
+
 	// Like injectConnectFilters for L4, here we ensure that the first filter
-	// (other than the "envoy.grpc_http1_bridge" filter) in the http filter
+	// (other than the "envoy.grpc_http1_bridge" and gRPC JSON transcoder filters) in the http filter
 	// chain of a public listener is the authz filter to prevent unauthorized
 	// access and that every filter chain uses our TLS certs.
 	if len(opts.httpAuthzFilters) > 0 {
 		cfg.HttpFilters = append(opts.httpAuthzFilters, cfg.HttpFilters...)
 	}
 
+	// The transcoder sits in front of the authz filters so that L7
+	// intentions always match the gRPC method, whether the request arrived
+	// as gRPC or as HTTP/JSON.
+	if opts.protocol == "grpc" && opts.grpcTranscoding != nil {
+		transcoder, err := makeEnvoyHTTPFilter(
+			"envoy.filters.http.grpc_json_transcoder",
+			&envoy_grpc_json_transcoder_v3.GrpcJsonTranscoder{
+				DescriptorSet: &envoy_grpc_json_transcoder_v3.GrpcJsonTranscoder_ProtoDescriptor{
+					ProtoDescriptor: opts.grpcTranscoding.ProtoDescriptor,
+				},
+				Services: opts.grpcTranscoding.Services,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		cfg.HttpFilters = append([]*envoy_http_v3.HttpFilter{transcoder}, cfg.HttpFilters...)
+	}
+
 	if opts.protocol == "grpc" {
 		grpcHttp1Bridge, err := makeEnvoyHTTPFilter(
 			"envoy.filters.http.grpc_http1_bridge",
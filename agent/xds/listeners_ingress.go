@@ -121,6 +121,12 @@ func (s *ResourceGenerator) makeIngressGatewayListeners(address string, cfgSnap
 				accessLogs:       &cfgSnap.Proxy.AccessLogs,
 				logger:           s.Logger,
 			}
+			for _, u := range upstreams {
+				if chain := cfgSnap.IngressGateway.DiscoveryChain[proxycfg.NewUpstreamID(&u)]; chain != nil && chainHasVirtualHostRateLimit(chain) {
+					filterOpts.localRateLimit = true
+					break
+				}
+			}
 
 			// Generate any filter chains needed for services with custom TLS certs
 			// via SDS.
@@ -5,6 +5,7 @@ package xds
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"text/template"
 
@@ -15,6 +16,9 @@ import (
 	"github.com/hashicorp/consul/agent/proxycfg"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/xds/configfetcher"
+	"github.com/hashicorp/consul/agent/xds/proxystateconverter"
+	"github.com/hashicorp/consul/agent/xdsv2"
+	"github.com/hashicorp/consul/sdk/testutil"
 )
 
 type customListenerJSONOptions struct {
@@ -369,3 +373,63 @@ func TestGetAlpnProtocols(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkListenersFromSnapshot(b *testing.B) {
+	snap := testConfigSnapshotWithUpstreams(b, 100)
+
+	b.Run("v1", func(b *testing.B) {
+		g := NewResourceGenerator(testutil.Logger(b), nil, false)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := g.listenersFromSnapshot(snap); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("v2", func(b *testing.B) {
+		converter := proxystateconverter.NewConverter(testutil.Logger(b), &mockCfgFetcher{addressLan: "192.0.2.1"})
+		proxyState, err := converter.ProxyStateFromSnapshot(snap)
+		require.NoError(b, err)
+
+		g := xdsv2.NewResourceGenerator(testutil.Logger(b))
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := g.ListenersFromIR(proxyState); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// testConfigSnapshotWithUpstreams returns a connect proxy snapshot with the
+// given number of upstreams, each with its own cluster.
+func testConfigSnapshotWithUpstreams(t testinf.T, count int) *proxycfg.ConfigSnapshot {
+	var (
+		upstreams structs.Upstreams
+		events    []proxycfg.UpdateEvent
+	)
+	for i := 0; i < count; i++ {
+		u := structs.Upstream{
+			DestinationType: structs.UpstreamDestTypePreparedQuery,
+			DestinationName: fmt.Sprintf("query-%d", i),
+			LocalBindPort:   10000 + i,
+		}
+		uid := proxycfg.NewUpstreamID(&u)
+		upstreams = append(upstreams, u)
+		events = append(events, proxycfg.UpdateEvent{
+			CorrelationID: "upstream:" + uid.String(),
+			Result: &structs.PreparedQueryExecuteResponse{
+				Nodes: proxycfg.TestPreparedQueryNodes(t, u.DestinationName),
+			},
+		})
+	}
+
+	return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+		ns.Proxy.Upstreams = upstreams
+	}, events)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-uuid"
 
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -543,7 +544,8 @@ func (s *Converter) makeUpstreamClusterForPeerService(
 					CircuitBreakers: &pbproxystate.CircuitBreakers{
 						UpstreamLimits: makeUpstreamLimitsIfNeeded(upstreamConfig.Limits),
 					},
-					OutlierDetection: outlierDetection,
+					OutlierDetection:          outlierDetection,
+					UpstreamConnectionOptions: makeUpstreamConnectionOptions(upstreamConfig.TCPKeepalive, cfgSnap.MeshConfig().DefaultTCPKeepalive()),
 				},
 			}
 			c.Group = &pbproxystate.Cluster_EndpointGroup{
@@ -574,7 +576,8 @@ func (s *Converter) makeUpstreamClusterForPeerService(
 					CircuitBreakers: &pbproxystate.CircuitBreakers{
 						UpstreamLimits: makeUpstreamLimitsIfNeeded(upstreamConfig.Limits),
 					},
-					OutlierDetection: outlierDetection,
+					OutlierDetection:          outlierDetection,
+					UpstreamConnectionOptions: makeUpstreamConnectionOptions(upstreamConfig.TCPKeepalive, cfgSnap.MeshConfig().DefaultTCPKeepalive()),
 				},
 			}
 			c.Group = &pbproxystate.Cluster_EndpointGroup{
@@ -628,7 +631,7 @@ func (s *Converter) makeUpstreamClusterForPreparedQuery(upstream structs.Upstrea
 	}
 	sni := connect.UpstreamSNI(&upstream, "", dc, cfgSnap.Roots.TrustDomain)
 
-	cfg, _ := structs.ParseUpstreamConfig(upstream.Config)
+	cfg, _ := config.ParsePreparedQueryUpstreamConfig(upstream.Config, cfgSnap.MeshConfig())
 	// TODO(proxystate): add logger and enable this
 	//if err != nil {
 	// Don't hard fail on a config typo, just warn. The parse func returns
@@ -660,6 +663,11 @@ func (s *Converter) makeUpstreamClusterForPreparedQuery(upstream structs.Upstrea
 								CircuitBreakers: &pbproxystate.CircuitBreakers{
 									UpstreamLimits: makeUpstreamLimitsIfNeeded(cfg.Limits),
 								},
+								MaxRequestsPerConnection:  makeMaxRequestsPerConnection(cfg),
+								HealthCheck:               makeHealthCheck(cfg),
+								WarmupTimeout:             makeWarmupTimeout(cfg),
+								WaitForWarmOnInit:         makeWaitForWarmOnInit(cfg),
+								UpstreamConnectionOptions: makeUpstreamConnectionOptions(cfg.TCPKeepalive, cfgSnap.MeshConfig().DefaultTCPKeepalive()),
 							},
 						},
 					},
@@ -694,7 +702,11 @@ func (s *Converter) makeUpstreamClusterForPreparedQuery(upstream structs.Upstrea
 		}.URI().String())
 	}
 
-	transportSocket, err := s.createOutboundMeshMTLS(cfgSnap, spiffeIDs, sni)
+	tlsSNI := sni
+	if cfg.SNI != "" {
+		tlsSNI = cfg.SNI
+	}
+	transportSocket, err := s.createOutboundMeshMTLS(cfgSnap, spiffeIDs, tlsSNI)
 	if err != nil {
 		return nil, err
 	}
@@ -718,6 +730,18 @@ func finalizeUpstreamConfig(cfg structs.UpstreamConfig, chain *structs.CompiledD
 	return cfg
 }
 
+// resolverConnectTimeout returns the connect timeout for the clusters of a
+// discovery chain resolver node. The mesh-wide default is only used when
+// neither the service-resolver nor the upstream set a timeout.
+func resolverConnectTimeout(cfgSnap *proxycfg.ConfigSnapshot, resolver *structs.DiscoveryResolver) time.Duration {
+	if !resolver.ExplicitConnectTimeout {
+		if d := cfgSnap.MeshConfig().DefaultConnectTimeout(); d > 0 {
+			return d
+		}
+	}
+	return resolver.ConnectTimeout
+}
+
 func (s *Converter) createOutboundMeshMTLS(cfgSnap *proxycfg.ConfigSnapshot, spiffeIDs []string, sni string) (*pbproxystate.TransportSocket, error) {
 	switch cfgSnap.Kind {
 	case structs.ServiceKindConnectProxy:
@@ -837,7 +861,8 @@ func (s *Converter) makeUpstreamClustersForDiscoveryChain(
 			continue
 		}
 
-		upstreamConfig := finalizeUpstreamConfig(rawUpstreamConfig, chain, node.Resolver.ConnectTimeout)
+		connectTimeout := resolverConnectTimeout(cfgSnap, node.Resolver)
+		upstreamConfig := finalizeUpstreamConfig(rawUpstreamConfig, chain, connectTimeout)
 
 		mappedTargets, err := s.mapDiscoChainTargets(cfgSnap, chain, node, upstreamConfig, forMeshGateway)
 		if err != nil {
@@ -856,7 +881,7 @@ func (s *Converter) makeUpstreamClustersForDiscoveryChain(
 			// below.
 			failoverGroup = &pbproxystate.FailoverGroup{
 				Config: &pbproxystate.FailoverGroupConfig{
-					ConnectTimeout: durationpb.New(node.Resolver.ConnectTimeout),
+					ConnectTimeout: durationpb.New(connectTimeout),
 					UseAltStatName: true,
 				},
 			}
@@ -870,17 +895,29 @@ func (s *Converter) makeUpstreamClustersForDiscoveryChain(
 			dynamic := &pbproxystate.DynamicEndpointGroup{
 				Config: &pbproxystate.DynamicEndpointGroupConfig{
 					UseAltStatName: true,
-					ConnectTimeout: durationpb.New(node.Resolver.ConnectTimeout),
+					ConnectTimeout: durationpb.New(connectTimeout),
 					// TODO(peering): make circuit breakers or outlier detection work?
 					CircuitBreakers: &pbproxystate.CircuitBreakers{
 						UpstreamLimits: makeUpstreamLimitsIfNeeded(upstreamConfig.Limits),
 					},
-					DisablePanicThreshold: true,
-					OutlierDetection:      makeOutlierDetection(upstreamConfig.PassiveHealthCheck, nil, true),
+					DisablePanicThreshold:     true,
+					OutlierDetection:          makeOutlierDetection(upstreamConfig.PassiveHealthCheck, nil, true),
+					MaxRequestsPerConnection:  makeMaxRequestsPerConnection(upstreamConfig),
+					HealthCheck:               makeHealthCheck(upstreamConfig),
+					WarmupTimeout:             makeWarmupTimeout(upstreamConfig),
+					WaitForWarmOnInit:         makeWaitForWarmOnInit(upstreamConfig),
+					UpstreamConnectionOptions: makeUpstreamConnectionOptions(upstreamConfig.TCPKeepalive, cfgSnap.MeshConfig().DefaultTCPKeepalive()),
 				},
 			}
 			ti := groupedTarget.Targets[0]
-			transportSocket, err := s.createOutboundMeshMTLS(cfgSnap, ti.SpiffeIDs, ti.SNI)
+			tlsSNI := ti.SNI
+			// Mesh gateways route on the SNI, so the override is only honored
+			// by proxies that connect to the target directly.
+			if upstreamConfig.SNI != "" && !forMeshGateway &&
+				!chain.Targets[ti.TargetID].ViaMeshGateway(cfgSnap.Datacenter, cfgSnap.ProxyID.PartitionOrDefault()) {
+				tlsSNI = upstreamConfig.SNI
+			}
+			transportSocket, err := s.createOutboundMeshMTLS(cfgSnap, ti.SpiffeIDs, tlsSNI)
 			if err != nil {
 				return nil, err
 			}
@@ -888,18 +925,12 @@ func (s *Converter) makeUpstreamClustersForDiscoveryChain(
 
 			var lb *structs.LoadBalancer
 			if node.LoadBalancer != nil {
-				lb = node.LoadBalancer
+				lb = node.LoadBalancer.WithDefaultRingHashSizes(cfgSnap.MeshConfig().DefaultRingHashSizes())
 			}
 			if err := injectLBToCluster(lb, dynamic.Config); err != nil {
 				return nil, fmt.Errorf("failed to apply load balancer configuration to cluster %q: %v", groupedTarget.ClusterName, err)
 			}
-
-			// TODO: IR: http2 options not currently supported
-			//if upstreamConfig.Protocol == "http2" || upstreamConfig.Protocol == "grpc" {
-			//	if err := s.setHttp2ProtocolOptions(c); err != nil {
-			//		return nil, err
-			//	}
-			//}
+			injectOutlierDetectionToCluster(node.Resolver.PassiveHealthCheck, dynamic.Config)
 
 			switch len(groupedTarget.Targets) {
 			case 0:
@@ -1073,6 +1104,121 @@ func configureClusterWithHostnames(
 // Functions to add from agent/xds/clusters.go:
 // func makeExternalHostnameCluster
 
+// makeWarmupTimeout returns how long the clusters of an upstream wait for their
+// endpoints while warming, or nil to keep the default.
+func makeWarmupTimeout(cfg structs.UpstreamConfig) *durationpb.Duration {
+	if cfg.WarmupTimeout == nil {
+		return nil
+	}
+	return durationpb.New(*cfg.WarmupTimeout)
+}
+
+// makeWaitForWarmOnInit returns whether proxy initialization waits for the
+// clusters of an upstream to warm, or nil to keep the default.
+func makeWaitForWarmOnInit(cfg structs.UpstreamConfig) *wrapperspb.BoolValue {
+	if cfg.WaitForWarmOnInit == nil {
+		return nil
+	}
+	return wrapperspb.Bool(*cfg.WaitForWarmOnInit)
+}
+
+// makeUpstreamConnectionOptions returns the TCP keepalive settings for
+// connections to an upstream. The upstream's own configuration replaces the
+// mesh-wide default as a whole rather than field by field.
+func makeUpstreamConnectionOptions(upstream, mesh *structs.TCPKeepaliveConfig) *pbproxystate.UpstreamConnectionOptions {
+	cfg := upstream
+	if cfg == nil {
+		cfg = mesh
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	opts := &pbproxystate.UpstreamConnectionOptions{}
+	if cfg.Time != 0 {
+		opts.TcpKeepaliveTime = wrapperspb.UInt32(cfg.Time)
+	}
+	if cfg.Interval != 0 {
+		opts.TcpKeepaliveInterval = wrapperspb.UInt32(cfg.Interval)
+	}
+	if cfg.Probes != 0 {
+		opts.TcpKeepaliveProbes = wrapperspb.UInt32(cfg.Probes)
+	}
+	return opts
+}
+
+// makeMaxRequestsPerConnection returns the per-connection request limit of an
+// HTTP upstream, or nil to rely on the Envoy default of no limit.
+func makeMaxRequestsPerConnection(cfg structs.UpstreamConfig) *wrapperspb.UInt32Value {
+	if cfg.MaxRequestsPerConnection == nil || *cfg.MaxRequestsPerConnection == 0 || !structs.IsProtocolHTTPLike(cfg.Protocol) {
+		return nil
+	}
+	return &wrapperspb.UInt32Value{Value: *cfg.MaxRequestsPerConnection}
+}
+
+// Default timing and thresholds for active health checks.
+const (
+	activeHealthCheckInterval           = 10 * time.Second
+	activeHealthCheckTimeout            = 5 * time.Second
+	activeHealthCheckUnhealthyThreshold = 2
+	activeHealthCheckHealthyThreshold   = 2
+	activeHealthCheckHTTPPath           = "/"
+)
+
+// makeHealthCheck returns the active health check of an upstream with unset
+// timings and thresholds filled in. gRPC health checks are only returned for
+// the http2 and grpc protocols.
+func makeHealthCheck(cfg structs.UpstreamConfig) *pbproxystate.HealthCheck {
+	hc := cfg.ActiveHealthCheck
+	if hc == nil {
+		return nil
+	}
+
+	check := &pbproxystate.HealthCheck{
+		Interval:           durationpb.New(activeHealthCheckInterval),
+		Timeout:            durationpb.New(activeHealthCheckTimeout),
+		UnhealthyThreshold: wrapperspb.UInt32(activeHealthCheckUnhealthyThreshold),
+		HealthyThreshold:   wrapperspb.UInt32(activeHealthCheckHealthyThreshold),
+	}
+	if hc.Interval > 0 {
+		check.Interval = durationpb.New(hc.Interval)
+	}
+	if hc.Timeout > 0 {
+		check.Timeout = durationpb.New(hc.Timeout)
+	}
+	if hc.UnhealthyThreshold > 0 {
+		check.UnhealthyThreshold = wrapperspb.UInt32(hc.UnhealthyThreshold)
+	}
+	if hc.HealthyThreshold > 0 {
+		check.HealthyThreshold = wrapperspb.UInt32(hc.HealthyThreshold)
+	}
+
+	switch hc.Type {
+	case structs.ActiveHealthCheckTypeHTTP:
+		path := hc.Path
+		if path == "" {
+			path = activeHealthCheckHTTPPath
+		}
+		check.HealthChecker = &pbproxystate.HealthCheck_Http{
+			Http: &pbproxystate.HTTPHealthCheck{Path: path},
+		}
+	case structs.ActiveHealthCheckTypeTCP:
+		check.HealthChecker = &pbproxystate.HealthCheck_Tcp{
+			Tcp: &pbproxystate.TCPHealthCheck{},
+		}
+	case structs.ActiveHealthCheckTypeGRPC:
+		if cfg.Protocol != "http2" && cfg.Protocol != "grpc" {
+			return nil
+		}
+		check.HealthChecker = &pbproxystate.HealthCheck_Grpc{
+			Grpc: &pbproxystate.GRPCHealthCheck{},
+		}
+	default:
+		return nil
+	}
+	return check
+}
+
 func makeUpstreamLimitsIfNeeded(limits *structs.UpstreamLimits) *pbproxystate.UpstreamLimits {
 	if limits == nil {
 		return nil
@@ -1091,10 +1237,31 @@ func makeUpstreamLimitsIfNeeded(limits *structs.UpstreamLimits) *pbproxystate.Up
 	if limits.MaxConcurrentRequests != nil {
 		upstreamLimits.MaxConcurrentRequests = response.MakeUint32Value(*limits.MaxConcurrentRequests)
 	}
+	if limits.MaxRetries != nil {
+		upstreamLimits.MaxRetries = response.MakeUint32Value(*limits.MaxRetries)
+	}
 
 	return upstreamLimits
 }
 
+// injectOutlierDetectionToCluster applies the outlier detection configured on
+// a service-resolver to the endpoint group. Fields already set from the
+// downstream's upstream configuration take precedence.
+func injectOutlierDetectionToCluster(phc *structs.PassiveHealthCheck, dc *pbproxystate.DynamicEndpointGroupConfig) {
+	if phc == nil {
+		return
+	}
+
+	od := makeOutlierDetection(phc, nil, true)
+	if dc.OutlierDetection != nil {
+		proto.Merge(od, dc.OutlierDetection)
+	}
+	dc.OutlierDetection = od
+}
+
+// injectLBToCluster applies the load balancer configuration to the cluster.
+// Callers pass the configuration with the mesh-wide defaults already merged
+// in, see structs.LoadBalancer.WithDefaultRingHashSizes.
 func injectLBToCluster(ec *structs.LoadBalancer, dc *pbproxystate.DynamicEndpointGroupConfig) error {
 	if ec == nil {
 		return nil
@@ -1130,7 +1297,7 @@ func injectLBToCluster(ec *structs.LoadBalancer, dc *pbproxystate.DynamicEndpoin
 
 		dc.LbPolicy = rh
 
-		if ec.RingHashConfig != nil {
+		if c := ec.RingHashConfig; c != nil && !c.OnlyHeaderName() {
 			rh.RingHash.MinimumRingSize = &wrapperspb.UInt64Value{Value: ec.RingHashConfig.MinimumRingSize}
 			rh.RingHash.MaximumRingSize = &wrapperspb.UInt64Value{Value: ec.RingHashConfig.MaximumRingSize}
 		}
@@ -1139,6 +1306,17 @@ func injectLBToCluster(ec *structs.LoadBalancer, dc *pbproxystate.DynamicEndpoin
 			Maglev: &pbproxystate.LBPolicyMaglev{},
 		}
 
+	case structs.LBPolicyClusterProvided:
+		if ec.CustomClusterType == "" {
+			return fmt.Errorf("load balancer policy %q requires a custom cluster type", ec.Policy)
+		}
+		dc.LbPolicy = &pbproxystate.DynamicEndpointGroupConfig_ClusterProvided{
+			ClusterProvided: &pbproxystate.LBPolicyClusterProvided{
+				CustomClusterType:       ec.CustomClusterType,
+				CustomClusterConfigJson: ec.CustomClusterConfigJSON,
+			},
+		}
+
 	default:
 		return fmt.Errorf("unsupported load balancer policy %q", ec.Policy)
 	}
@@ -1158,7 +1336,16 @@ func generatePeeredClusterName(uid proxycfg.UpstreamID, tb *pbpeering.PeeringTru
 }
 
 func (s *Converter) getTargetClusterName(upstreamsSnapshot *proxycfg.ConfigSnapshotUpstreams, chain *structs.CompiledDiscoveryChain, tid string, forMeshGateway bool) string {
-	target := chain.Targets[tid]
+	target, ok := chain.Targets[tid]
+	if !ok {
+		// The target was excluded from the compiled chain, so no cluster
+		// is generated for it.
+		s.Logger.Debug("skipping discovery chain target missing from the chain",
+			"chain", chain.ServiceName,
+			"target", tid,
+		)
+		return ""
+	}
 	clusterName := target.Name
 	targetUID := proxycfg.NewUpstreamIDFromTargetID(tid)
 	if targetUID.Peer != "" {
@@ -1215,6 +1402,12 @@ func makeOutlierDetection(p *structs.PassiveHealthCheck, override *structs.Passi
 		if p.BaseEjectionTime != nil {
 			od.BaseEjectionTime = durationpb.New(*p.BaseEjectionTime)
 		}
+		if p.SplitExternalLocalOriginErrors {
+			od.SplitExternalLocalOriginErrors = true
+		}
+		if p.ConsecutiveLocalOriginFailure != nil {
+			od.ConsecutiveLocalOriginFailure = &wrapperspb.UInt32Value{Value: *p.ConsecutiveLocalOriginFailure}
+		}
 	}
 
 	if override == nil {
@@ -1244,6 +1437,12 @@ func makeOutlierDetection(p *structs.PassiveHealthCheck, override *structs.Passi
 	if override.BaseEjectionTime != nil {
 		od.BaseEjectionTime = durationpb.New(*override.BaseEjectionTime)
 	}
+	if override.SplitExternalLocalOriginErrors {
+		od.SplitExternalLocalOriginErrors = true
+	}
+	if override.ConsecutiveLocalOriginFailure != nil {
+		od.ConsecutiveLocalOriginFailure = &wrapperspb.UInt32Value{Value: *override.ConsecutiveLocalOriginFailure}
+	}
 
 	return od
 }
@@ -4,6 +4,7 @@
 package proxystateconverter
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/go-hclog"
@@ -21,7 +22,21 @@ import (
 type Converter struct {
 	Logger     hclog.Logger
 	CfgFetcher configfetcher.ConfigFetcher
-	proxyState *proxytracker.ProxyState
+	// SupportedKinds is the set of proxy kinds the converter is able to
+	// translate. Snapshots of any other kind are rejected with an
+	// UnsupportedKindError.
+	SupportedKinds []structs.ServiceKind
+	proxyState     *proxytracker.ProxyState
+}
+
+// UnsupportedKindError is returned when a snapshot's kind cannot be converted
+// into a ProxyState.
+type UnsupportedKindError struct {
+	Kind structs.ServiceKind
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return fmt.Sprintf("unsupported service kind %q", e.Kind)
 }
 
 func NewConverter(
@@ -29,8 +44,9 @@ func NewConverter(
 	cfgFetcher configfetcher.ConfigFetcher,
 ) *Converter {
 	return &Converter{
-		Logger:     logger,
-		CfgFetcher: cfgFetcher,
+		Logger:         logger,
+		CfgFetcher:     cfgFetcher,
+		SupportedKinds: []structs.ServiceKind{structs.ServiceKindConnectProxy},
 		proxyState: &proxytracker.ProxyState{
 			ProxyState: &pbmesh.ProxyState{
 				Listeners: make([]*pbproxystate.Listener, 0),
@@ -43,14 +59,34 @@ func NewConverter(
 }
 
 func (g *Converter) ProxyStateFromSnapshot(cfgSnap *proxycfg.ConfigSnapshot) (*proxytracker.ProxyState, error) {
+	if cfgSnap == nil {
+		return nil, errors.New("nil config given")
+	}
+	if !g.supportsKind(cfgSnap.Kind) {
+		return nil, &UnsupportedKindError{Kind: cfgSnap.Kind}
+	}
+
 	err := g.resourcesFromSnapshot(cfgSnap)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate FullProxyState: %v", err)
 	}
 
+	if err := validateProxyState(g.proxyState.ProxyState); err != nil {
+		return nil, err
+	}
+
 	return g.proxyState, nil
 }
 
+func (g *Converter) supportsKind(kind structs.ServiceKind) bool {
+	for _, k := range g.SupportedKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Converter) resourcesFromSnapshot(cfgSnap *proxycfg.ConfigSnapshot) error {
 	err := g.tlsConfigFromSnapshot(cfgSnap)
 	if err != nil {
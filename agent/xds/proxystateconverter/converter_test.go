@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxystateconverter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/proxycfg"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbmesh/v2beta1/pbproxystate"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func TestConverter_ProxyStateFromSnapshot_UnsupportedKind(t *testing.T) {
+	tests := map[string]struct {
+		snap *proxycfg.ConfigSnapshot
+		kind structs.ServiceKind
+	}{
+		"mesh-gateway": {
+			snap: proxycfg.TestConfigSnapshotMeshGateway(t, "default", nil, nil),
+			kind: structs.ServiceKindMeshGateway,
+		},
+		"terminating-gateway": {
+			snap: proxycfg.TestConfigSnapshotTerminatingGateway(t, true, nil, nil),
+			kind: structs.ServiceKindTerminatingGateway,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			converter := NewConverter(testutil.Logger(t), nil)
+
+			proxyState, err := converter.ProxyStateFromSnapshot(tc.snap)
+			require.Nil(t, proxyState)
+
+			var kindErr *UnsupportedKindError
+			require.True(t, errors.As(err, &kindErr), "unexpected error: %v", err)
+			require.Equal(t, tc.kind, kindErr.Kind)
+		})
+	}
+}
+
+func TestConverter_ProxyStateFromSnapshot_Validation(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		converter := NewConverter(testutil.Logger(t), nil)
+
+		proxyState, err := converter.ProxyStateFromSnapshot(proxycfg.TestConfigSnapshot(t, nil, nil))
+		require.NoError(t, err)
+		require.NotNil(t, proxyState)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		converter := NewConverter(testutil.Logger(t), nil)
+
+		// Seed the converter with resources that reference things the
+		// snapshot will never produce.
+		converter.proxyState.Clusters[""] = &pbproxystate.Cluster{}
+		converter.proxyState.Clusters["renamed"] = &pbproxystate.Cluster{Name: "other"}
+		converter.proxyState.Routes["bogus-route"] = &pbproxystate.Route{
+			VirtualHosts: []*pbproxystate.VirtualHost{{
+				Name: "bogus",
+				RouteRules: []*pbproxystate.RouteRule{{
+					Destination: &pbproxystate.RouteDestination{
+						Destination: &pbproxystate.RouteDestination_WeightedClusters{
+							WeightedClusters: &pbproxystate.L7WeightedClusterGroup{
+								Clusters: []*pbproxystate.L7WeightedDestinationCluster{
+									{Name: "missing-cluster"},
+								},
+							},
+						},
+					},
+				}},
+			}},
+		}
+
+		converter.proxyState.Listeners = append(converter.proxyState.Listeners, &pbproxystate.Listener{
+			Name: "bogus-listener",
+			DefaultRouter: &pbproxystate.Router{
+				Destination: &pbproxystate.Router_L7{
+					L7: &pbproxystate.L7Destination{
+						Route: &pbproxystate.L7DestinationRoute{Name: "missing-route"},
+					},
+				},
+			},
+		})
+
+		proxyState, err := converter.ProxyStateFromSnapshot(proxycfg.TestConfigSnapshot(t, nil, nil))
+		require.Nil(t, proxyState)
+
+		var validationErr *ProxyStateValidationError
+		require.True(t, errors.As(err, &validationErr), "unexpected error: %v", err)
+		require.ElementsMatch(t, []string{
+			"cluster with empty name",
+			`cluster "other" is stored under name "renamed"`,
+			`listener "bogus-listener" references unknown route "missing-route"`,
+			`route "bogus-route" references unknown cluster "missing-cluster"`,
+		}, validationErr.Violations)
+	})
+}
@@ -566,6 +566,12 @@ func makeEndpointsForLoadAssignment(cfgSnap *proxycfg.ConfigSnapshot,
 				endpoint := makeHostPortEndpoint(addr, port)
 				endpoint.HealthStatus = healthStatus
 				endpoint.LoadBalancingWeight = response.MakeUint32Value(weight)
+				if locality := ep.GeoLocality(); locality != nil {
+					endpoint.Locality = &pbproxystate.Locality{
+						Region: locality.Region,
+						Zone:   locality.Zone,
+					}
+				}
 
 				pbEndpoints = append(pbEndpoints, endpoint)
 			}
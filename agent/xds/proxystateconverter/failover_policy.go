@@ -70,6 +70,11 @@ func (s *Converter) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapshot, chain
 		return discoChainTargets{}, err
 	}
 
+	if _, ok := chain.Targets[primaryTargetID]; !ok {
+		// Nothing is generated for a resolver whose target was excluded
+		// from the chain.
+		return discoChainTargets{}, nil
+	}
 	failoverTargets.baseClusterName = s.getTargetClusterName(upstreamsSnapshot, chain, primaryTargetID, forMeshGateway)
 
 	tids := []string{primaryTargetID}
@@ -85,7 +90,11 @@ func (s *Converter) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapshot, chain
 	}
 
 	for _, tid := range tids {
-		target := chain.Targets[tid]
+		target, ok := chain.Targets[tid]
+		if !ok {
+			s.Logger.Debug("skipping failover target missing from the discovery chain", "target", tid)
+			continue
+		}
 		targetUID := proxycfg.NewUpstreamIDFromTargetID(tid)
 		ti := targetInfo{TargetID: tid, PrioritizeByLocality: target.PrioritizeByLocality}
 
@@ -116,13 +125,17 @@ func (s *Converter) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapshot, chain
 		} else {
 			ti.SNI = target.SNI
 			ti.RootPEMs = cfgSnap.RootPEMs()
-			ti.SpiffeIDs = []string{connect.SpiffeIDService{
-				Host:       cfgSnap.Roots.TrustDomain,
-				Namespace:  target.Namespace,
-				Partition:  target.Partition,
-				Datacenter: target.Datacenter,
-				Service:    target.Service,
-			}.URI().String()}
+			if len(target.CustomSANs) > 0 {
+				ti.SpiffeIDs = target.CustomSANs
+			} else {
+				ti.SpiffeIDs = []string{connect.SpiffeIDService{
+					Host:       cfgSnap.Roots.TrustDomain,
+					Namespace:  target.Namespace,
+					Partition:  target.Partition,
+					Datacenter: target.Datacenter,
+					Service:    target.Service,
+				}.URI().String()}
+			}
 		}
 		failoverTargets.targets = append(failoverTargets.targets, ti)
 	}
@@ -160,6 +160,7 @@ func (s *Converter) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.ConfigSn
 				filterName:  filterName,
 				protocol:    cfg.Protocol,
 				useRDS:      useRDS,
+				retryPolicy: cfg.RetryPolicy,
 				//tracing:     tracing,
 			})
 			if err != nil {
@@ -194,6 +195,7 @@ func (s *Converter) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.ConfigSn
 			filterName:  filterName,
 			protocol:    cfg.Protocol,
 			useRDS:      useRDS,
+			retryPolicy: cfg.RetryPolicy,
 			//tracing:     tracing,
 		})
 		if err != nil {
@@ -362,10 +364,11 @@ func (s *Converter) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.ConfigSn
 					upstreamCfg.DestinationName,
 					upstreamCfg.DestinationNamespace,
 					upstreamCfg.DestinationPeer),
-				routeName:  uid.EnvoyID(),
-				protocol:   cfg.Protocol,
-				useRDS:     false,
-				statPrefix: "upstream_peered.",
+				routeName:   uid.EnvoyID(),
+				protocol:    cfg.Protocol,
+				useRDS:      false,
+				statPrefix:  "upstream_peered.",
+				retryPolicy: cfg.RetryPolicy,
 			})
 			if err != nil {
 				return err
@@ -402,9 +405,10 @@ func (s *Converter) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.ConfigSn
 				uid.Name,
 				uid.NamespaceOrDefault(),
 				uid.Peer),
-			protocol:   cfg.Protocol,
-			useRDS:     false,
-			statPrefix: "upstream_peered.",
+			protocol:    cfg.Protocol,
+			useRDS:      false,
+			statPrefix:  "upstream_peered.",
+			retryPolicy: cfg.RetryPolicy,
 			//tracing:    tracing,
 		})
 		if err != nil {
@@ -540,6 +544,7 @@ func (s *Converter) listenersFromSnapshotConnectProxy(cfgSnap *proxycfg.ConfigSn
 			filterName:  uid.EnvoyID(),
 			routeName:   uid.EnvoyID(),
 			protocol:    cfg.Protocol,
+			retryPolicy: cfg.RetryPolicy,
 			//tracing:     tracing,
 		})
 		if err != nil {
@@ -1224,6 +1229,7 @@ type routerOpts struct {
 	statPrefix           string
 	forwardClientDetails bool
 	forwardClientPolicy  pbproxystate.XFCCPolicy
+	retryPolicy          *structs.UpstreamRetryPolicy
 	//tracing              *envoy_http_v3.HttpConnectionManager_Tracing
 }
 
@@ -1243,6 +1249,7 @@ func (g *Converter) makeUpstreamRouter(opts routerOpts) (*pbproxystate.Router, e
 		statPrefix:           opts.statPrefix,
 		forwardClientDetails: opts.forwardClientDetails,
 		forwardClientPolicy:  opts.forwardClientPolicy,
+		retryPolicy:          opts.retryPolicy,
 		//tracing:              opts.tracing,
 		//accessLogs:           opts.accessLogs,
 		logger: g.Logger,
@@ -1385,6 +1392,7 @@ type destinationOpts struct {
 	httpAuthzFilters     []*envoy_http_v3.HttpFilter
 	idleTimeoutMs        *int
 	requestTimeoutMs     *int
+	retryPolicy          *structs.UpstreamRetryPolicy
 	routeName            string
 	routePath            string
 	// TODO(proxystate): tracing support will be added later
@@ -1521,6 +1529,10 @@ func (g *Converter) makeL7Destination(opts destinationOpts) (*pbproxystate.L7Des
 			TimeoutConfig: timeoutCfg,
 		}
 
+		if opts.retryPolicy != nil {
+			r.DestinationConfiguration.RetryPolicy = makeUpstreamRetryPolicy(opts.retryPolicy)
+		}
+
 		// If a path is provided, do not match on a catch-all prefix
 		if opts.routePath != "" {
 			routeRule.Match.PathMatch = &pbproxystate.PathMatch{
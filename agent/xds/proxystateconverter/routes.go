@@ -68,6 +68,16 @@ func (s *Converter) routesForConnectProxy(cfgSnap *proxycfg.ConfigSnapshot) erro
 			continue
 		}
 
+		if upstream, _ := cfgSnap.ConnectProxy.GetUpstream(uid, &cfgSnap.ProxyID.EnterpriseMeta); upstream != nil {
+			cfg, err := structs.ParseUpstreamConfigNoDefaults(upstream.Config)
+			if err != nil {
+				// Don't hard fail on a config typo, just warn. The parse func returns
+				// default config if there is an error so it's safe to continue.
+				s.Logger.Warn("failed to parse", "upstream", uid, "error", err)
+			}
+			injectUpstreamRetryPolicy(virtualHost, cfg.RetryPolicy)
+		}
+
 		route := &pbproxystate.Route{
 			VirtualHosts: []*pbproxystate.VirtualHost{virtualHost},
 		}
@@ -369,6 +379,41 @@ func (s *Converter) makeUpstreamHostForDiscoveryChain(
 	return host, nil
 }
 
+// injectUpstreamRetryPolicy sets the upstream's retry policy on every route
+// rule in the virtual host that does not already have one from its
+// service-router destination.
+func injectUpstreamRetryPolicy(virtualHost *pbproxystate.VirtualHost, policy *structs.UpstreamRetryPolicy) {
+	if policy == nil {
+		return
+	}
+	for _, rule := range virtualHost.RouteRules {
+		dest := rule.GetDestination()
+		if dest == nil {
+			continue
+		}
+		if dest.DestinationConfiguration == nil {
+			dest.DestinationConfiguration = &pbproxystate.DestinationConfiguration{}
+		}
+		if dest.DestinationConfiguration.RetryPolicy == nil {
+			dest.DestinationConfiguration.RetryPolicy = makeUpstreamRetryPolicy(policy)
+		}
+	}
+}
+
+func makeUpstreamRetryPolicy(policy *structs.UpstreamRetryPolicy) *pbproxystate.RetryPolicy {
+	retryPolicy := &pbproxystate.RetryPolicy{
+		RetryOn:              strings.Join(policy.RetryConditions(), ","),
+		RetriableStatusCodes: policy.RetriableStatusCodes,
+	}
+	if policy.NumRetries > 0 {
+		retryPolicy.NumRetries = response.MakeUint32Value(int(policy.NumRetries))
+	}
+	if policy.PerTryTimeout != nil && *policy.PerTryTimeout > 0 {
+		retryPolicy.PerTryTimeout = durationpb.New(*policy.PerTryTimeout)
+	}
+	return retryPolicy
+}
+
 func getRetryPolicyForDestination(destination *structs.ServiceRouteDestination) *pbproxystate.RetryPolicy {
 	retryPolicy := &pbproxystate.RetryPolicy{}
 	if destination.NumRetries > 0 {
@@ -598,8 +643,9 @@ func injectLBToDestinationConfiguration(lb *structs.LoadBalancer, destinationCon
 		return nil
 	}
 
-	result := make([]*pbproxystate.LoadBalancerHashPolicy, 0, len(lb.HashPolicies))
-	for _, policy := range lb.HashPolicies {
+	hashPolicies := lb.EffectiveHashPolicies()
+	result := make([]*pbproxystate.LoadBalancerHashPolicy, 0, len(hashPolicies))
+	for _, policy := range hashPolicies {
 		if policy.SourceIP {
 			p := &pbproxystate.LoadBalancerHashPolicy{
 				Policy: &pbproxystate.LoadBalancerHashPolicy_ConnectionProperties{
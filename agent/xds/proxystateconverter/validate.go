@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxystateconverter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pbmesh "github.com/hashicorp/consul/proto-public/pbmesh/v2beta1"
+	"github.com/hashicorp/consul/proto-public/pbmesh/v2beta1/pbproxystate"
+)
+
+// ProxyStateValidationError is returned when a converted ProxyState is not
+// internally consistent, for example when a route points at a cluster that
+// was never generated. Every violation found is listed rather than only the
+// first one.
+type ProxyStateValidationError struct {
+	Violations []string
+}
+
+func (e *ProxyStateValidationError) Error() string {
+	return fmt.Sprintf("invalid proxy state: %s", strings.Join(e.Violations, "; "))
+}
+
+// validateProxyState checks the cross references within a ProxyState:
+// cluster names must be non-empty and unique, listeners must reference
+// routes that exist, and listeners and routes must reference clusters that
+// exist.
+func validateProxyState(ps *pbmesh.ProxyState) error {
+	var violations []string
+	addf := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	// Clusters are keyed by name, so the map keys are unique by
+	// construction. A cluster may additionally carry its own name, which
+	// must then agree with its key so that two entries can't claim the same
+	// cluster.
+	clusterOwners := make(map[string]string)
+	for _, key := range sortedKeys(ps.Clusters) {
+		if key == "" {
+			addf("cluster with empty name")
+			continue
+		}
+		name := ps.Clusters[key].GetName()
+		if name == "" {
+			name = key
+		} else if name != key {
+			addf("cluster %q is stored under name %q", name, key)
+		}
+		if owner, ok := clusterOwners[name]; ok {
+			addf("cluster name %q is used by both %q and %q", name, owner, key)
+			continue
+		}
+		clusterOwners[name] = key
+	}
+
+	checkCluster := func(name, source string) {
+		if name == "" {
+			addf("%s references a cluster with an empty name", source)
+			return
+		}
+		if _, ok := ps.Clusters[name]; !ok {
+			addf("%s references unknown cluster %q", source, name)
+		}
+	}
+
+	for _, l := range ps.Listeners {
+		source := fmt.Sprintf("listener %q", l.GetName())
+		routers := append([]*pbproxystate.Router{}, l.GetRouters()...)
+		if l.GetDefaultRouter() != nil {
+			routers = append(routers, l.GetDefaultRouter())
+		}
+		for _, router := range routers {
+			switch {
+			case router.GetL4() != nil:
+				l4 := router.GetL4()
+				if c := l4.GetCluster(); c != nil {
+					checkCluster(c.GetName(), source)
+				}
+				for _, c := range l4.GetWeightedClusters().GetClusters() {
+					checkCluster(c.GetName(), source)
+				}
+			case router.GetL7() != nil:
+				routeName := router.GetL7().GetRoute().GetName()
+				if routeName == "" {
+					addf("%s references a route with an empty name", source)
+				} else if _, ok := ps.Routes[routeName]; !ok {
+					addf("%s references unknown route %q", source, routeName)
+				}
+			}
+		}
+	}
+
+	for _, routeName := range sortedKeys(ps.Routes) {
+		source := fmt.Sprintf("route %q", routeName)
+		for _, vh := range ps.Routes[routeName].GetVirtualHosts() {
+			for _, rule := range vh.GetRouteRules() {
+				dest := rule.GetDestination()
+				if c := dest.GetCluster(); c != nil {
+					checkCluster(c.GetName(), source)
+				}
+				for _, c := range dest.GetWeightedClusters().GetClusters() {
+					checkCluster(c.GetName(), source)
+				}
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ProxyStateValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -6,6 +6,7 @@ package xds
 import (
 	"fmt"
 
+	envoy_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	"github.com/hashicorp/consul/agent/xds/configfetcher"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/protobuf/proto"
@@ -23,6 +24,11 @@ type ResourceGenerator struct {
 	IncrementalXDS bool
 
 	ProxyFeatures xdscommon.SupportedProxyFeatures
+
+	// ClusterMutator, if set, is called with every generated cluster so that
+	// external modules can modify clusters without changing how they are
+	// generated. An error from the mutator fails cluster generation.
+	ClusterMutator func(cluster *envoy_cluster_v3.Cluster, snap *proxycfg.ConfigSnapshot) error
 }
 
 func NewResourceGenerator(
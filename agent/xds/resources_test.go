@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	goversion "github.com/hashicorp/go-version"
 	testinf "github.com/mitchellh/go-testing-interface"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -20,6 +21,7 @@ import (
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 
+	"github.com/hashicorp/consul/agent/configentry"
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/consul/discoverychain"
 	"github.com/hashicorp/consul/agent/proxycfg"
@@ -28,9 +30,11 @@ import (
 	"github.com/hashicorp/consul/agent/xds/response"
 	"github.com/hashicorp/consul/agent/xds/testcommon"
 	"github.com/hashicorp/consul/agent/xdsv2"
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/types"
+	"github.com/hashicorp/consul/version"
 )
 
 var testTypeUrlToPrettyName = map[string]string{
@@ -204,6 +208,55 @@ func TestAllResourcesFromSnapshot(t *testing.T) {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-grpc-transcoding",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Config["protocol"] = "grpc"
+					ns.Proxy.Config["grpc_transcoding"] = map[string]interface{}{
+						"proto_descriptor": "/etc/envoy/bookstore.pb",
+						"services":         []interface{}{"bookstore.Bookstore"},
+					}
+				}, nil)
+			},
+			// TODO(proxystate): GRPCTranscoding is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-grpc-transcoding-and-l7-intentions",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Config["protocol"] = "grpc"
+					ns.Proxy.Config["grpc_transcoding"] = map[string]interface{}{
+						"proto_descriptor": "/etc/envoy/bookstore.pb",
+						"services":         []interface{}{"bookstore.Bookstore"},
+					}
+				}, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "intentions",
+						Result: structs.SimplifiedIntentions{
+							{
+								ID:              "l7-get-shelf",
+								SourceNS:        structs.WildcardSpecifier,
+								SourceName:      structs.WildcardSpecifier,
+								DestinationNS:   "default",
+								DestinationName: "web",
+								Permissions: []*structs.IntentionPermission{
+									{
+										Action: structs.IntentionActionAllow,
+										HTTP: &structs.IntentionHTTPPermission{
+											PathExact: "/bookstore.Bookstore/GetShelf",
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			// TODO(proxystate): GRPCTranscoding is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "listener-bind-address",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -253,6 +306,83 @@ func TestAllResourcesFromSnapshot(t *testing.T) {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-upstream-overrides",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					// Merge a service-defaults upstream override for db the same
+					// way the agent does before the proxy is registered. The
+					// connect timeout override is applied when the discovery
+					// chain is compiled, which connect-proxy-with-chain-and-overrides
+					// covers, so this checks settings read from the merged config.
+					merged, err := configentry.MergeServiceConfig(&structs.ServiceConfigResponse{
+						UpstreamConfigs: structs.OpaqueUpstreamConfigs{{
+							Upstream: structs.PeeredServiceName{ServiceName: structs.NewServiceName("db", nil)},
+							Config: map[string]interface{}{
+								"limits": map[string]interface{}{
+									"max_connections":         100,
+									"max_pending_requests":    50,
+									"max_concurrent_requests": 25,
+								},
+								"passive_health_check": map[string]interface{}{
+									"interval":     "5s",
+									"max_failures": 3,
+								},
+							},
+						}},
+					}, ns)
+					require.NoError(t, err)
+					*ns = *merged
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-weighted-endpoints",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				dbUID := proxycfg.NewUpstreamID(&structs.TestUpstreams(t, false)[0])
+
+				// The first instance is passing and gets its passing weight, the
+				// second has a warning check and gets its warning weight.
+				nodes := proxycfg.TestUpstreamNodes(t, "db")
+				nodes[0].Service.Weights = &structs.Weights{Passing: 5, Warning: 1}
+				nodes[1].Service.Weights = &structs.Weights{Passing: 5, Warning: 2}
+				nodes[1].Checks = structs.HealthChecks{{
+					Node:        nodes[1].Node.Node,
+					CheckID:     "db:check",
+					ServiceID:   nodes[1].Service.ID,
+					ServiceName: nodes[1].Service.Service,
+					Status:      api.HealthWarning,
+				}}
+
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{{
+					CorrelationID: "upstream-target:db.default.default.dc1:" + dbUID.String(),
+					Result: &structs.IndexedCheckServiceNodes{
+						Nodes: nodes,
+					},
+				}})
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-endpoint-lb-metadata-tags",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				dbUID := proxycfg.NewUpstreamID(&structs.TestUpstreams(t, false)[0])
+
+				nodes := proxycfg.TestUpstreamNodes(t, "db")
+				nodes[0].Service.Tags = []string{"primary", "envoy.lb.group=canary", "envoy.lb.version=v2"}
+				nodes[1].Service.Tags = []string{"envoy.lb.group=stable"}
+
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{{
+					CorrelationID: "upstream-target:db.default.default.dc1:" + dbUID.String(),
+					Result: &structs.IndexedCheckServiceNodes{
+						Nodes: nodes,
+					},
+				}})
+			},
+			// TODO(proxystate): endpoint metadata is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "http2-public-listener",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -311,6 +441,49 @@ func TestAllResourcesFromSnapshot(t *testing.T) {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "http-public-listener-response-headers-to-remove",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t,
+					func(ns *structs.NodeService) {
+						ns.Proxy.Config["protocol"] = "http"
+					},
+					[]proxycfg.UpdateEvent{
+						{
+							CorrelationID: "mesh",
+							Result: &structs.ConfigEntryResponse{
+								Entry: &structs.MeshConfigEntry{
+									HTTP: &structs.MeshHTTPConfig{
+										ResponseHeadersToRemove: []string{"Server", "X-Powered-By"},
+									},
+								},
+							},
+						},
+					})
+			},
+			// TODO(proxystate): ResponseHeadersToRemove is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-mesh-track-cluster-stats",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								Telemetry: &structs.MeshTelemetryConfig{
+									TrackClusterStats: &structs.ClusterStatsConfig{
+										RequestResponseSizes: true,
+										TimeoutBudgets:       true,
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+		},
 		{
 			name: "http-listener-with-timeouts",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -332,6 +505,27 @@ func TestAllResourcesFromSnapshot(t *testing.T) {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-intentions-deny-all",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "intentions",
+						Result: structs.SimplifiedIntentions{
+							{
+								ID:              "deny-all",
+								SourceNS:        structs.WildcardSpecifier,
+								SourceName:      structs.WildcardSpecifier,
+								DestinationNS:   "default",
+								DestinationName: "web",
+								Action:          structs.IntentionActionDeny,
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-upstream-defaults",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -409,6 +603,29 @@ func getConnectProxyTransparentProxyGoldenTestCases() []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "transparent-proxy-with-tcp-keepalives",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotTransparentProxyHTTPUpstream(t, func(ns *structs.NodeService) {
+					// Represent keepalives configured in the upstream defaults
+					// of service-defaults, which apply to implicit upstreams.
+					ns.Proxy.Upstreams = append(ns.Proxy.Upstreams, structs.Upstream{
+						CentrallyConfigured:  true,
+						DestinationNamespace: structs.WildcardSpecifier,
+						DestinationName:      structs.WildcardSpecifier,
+						Config: map[string]interface{}{
+							"protocol": "http",
+							"envoy_upstream_tcp_keepalive": map[string]interface{}{
+								"keepalive_time":     120,
+								"keepalive_interval": 30,
+								"keepalive_probes":   5,
+							},
+						},
+					})
+				})
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name:             "transparent-proxy-with-resolver-redirect-upstream",
 			create:           proxycfg.TestConfigSnapshotTransparentProxyResolverRedirectUpstream,
@@ -453,6 +670,39 @@ func getConnectProxyDiscoChainTests(enterprise bool) []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-chain-and-mesh-connect-timeout",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								ConnectTimeout: structs.DurationPointer(12 * time.Second),
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			// The resolver's connect timeout takes precedence over the mesh default.
+			name: "connect-proxy-with-chain-and-resolver-connect-timeout-over-mesh",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								ConnectTimeout: structs.DurationPointer(12 * time.Second),
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-with-chain-external-sni",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -467,6 +717,23 @@ func getConnectProxyDiscoChainTests(enterprise bool) []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-chain-and-failover-excluded-target",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				snap := proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover", enterprise, nil, nil)
+				// Exclude the failover target from the compiled chain. Only
+				// the primary target gets a cluster.
+				for _, chain := range snap.ConnectProxy.DiscoveryChain {
+					for id, target := range chain.Targets {
+						if target.Service == "fail" {
+							delete(chain.Targets, id)
+						}
+					}
+				}
+				return snap
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-exported-to-peers",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -506,95 +773,858 @@ func getConnectProxyDiscoChainTests(enterprise bool) []goldenTestCase {
 			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-chain-and-overrides",
+			name: "connect-proxy-with-service-defaults-protocol-grpc",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple-with-overrides", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "db",
+						Protocol: "grpc",
+					},
+				)
 			},
 			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-chain-and-failover",
+			name: "connect-proxy-with-geo-locality-endpoints",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "geo-locality", enterprise, nil, nil)
 			},
-			// TODO(proxystate): requires routes work
-			alsoRunTestForV2: false,
+			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-failover-through-remote-gateway",
+			name: "connect-proxy-lb-in-resolver-cluster-provided",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-remote-gateway", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						LoadBalancer: &structs.LoadBalancer{
+							Policy:            structs.LBPolicyClusterProvided,
+							CustomClusterType: "envoy.clusters.redis",
+						},
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
-			alsoRunTestForV2: false,
+			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-failover-through-remote-gateway-triggered",
+			name: "connect-proxy-lb-in-resolver-cluster-provided-typed-config",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-remote-gateway-triggered", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						LoadBalancer: &structs.LoadBalancer{
+							Policy:                  structs.LBPolicyClusterProvided,
+							CustomClusterType:       "envoy.clusters.redis",
+							CustomClusterConfigJSON: `{"@type": "type.googleapis.com/envoy.extensions.clusters.redis.v3.RedisClusterConfig", "clusterRefreshRate": "5s"}`,
+						},
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
-			alsoRunTestForV2: false,
+			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-double-failover-through-remote-gateway",
+			name: "connect-proxy-with-chain-custom-sans",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-remote-gateway", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						CustomSANs: []string{
+							"spiffe://cluster.local/ns/default/sa/db",
+							"spiffe://legacy.example.com/db",
+						},
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
-			alsoRunTestForV2: false,
+			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-double-failover-through-remote-gateway-triggered",
+			name: "connect-proxy-with-chain-cluster-filter-chain-metadata",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-remote-gateway-triggered", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						ClusterFilterChainMetadata: map[string]string{
+							"policy": "pci",
+							"zone":   "restricted",
+						},
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
+			// TODO(proxystate): cluster metadata is not yet supported in the converter.
 			alsoRunTestForV2: false,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-failover-through-local-gateway",
+			name: "connect-proxy-with-cluster-metadata",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-local-gateway", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					for i := range ns.Proxy.Upstreams {
+						// The prepared_query upstream is initialized without a Config map.
+						if ns.Proxy.Upstreams[i].Config == nil {
+							ns.Proxy.Upstreams[i].Config = map[string]interface{}{}
+						}
+						ns.Proxy.Upstreams[i].Config["envoy_cluster_metadata"] = map[string]interface{}{
+							"version": "v2",
+							"stage":   "canary",
+						}
+					}
+				}, nil)
 			},
-			// TODO(proxystate): requires routes work
+			// TODO(proxystate): cluster metadata is not yet supported in the converter.
 			alsoRunTestForV2: false,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-failover-through-local-gateway-triggered",
+			name: "connect-proxy-with-chain-and-mesh-per-connection-buffer-limit",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-local-gateway-triggered", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								PerConnectionBufferLimitBytes: uint32ptr(32768),
+							},
+						},
+					},
+				})
 			},
-			// TODO(proxystate): requires routes work
+			// TODO(proxystate): connection buffer limits are not yet supported in the converter.
 			alsoRunTestForV2: false,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-double-failover-through-local-gateway",
+			// The resolver's buffer limit takes precedence over the mesh default.
+			name: "connect-proxy-with-chain-and-resolver-per-connection-buffer-limit-over-mesh",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-local-gateway", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								PerConnectionBufferLimitBytes: uint32ptr(32768),
+							},
+						},
+					},
+				},
+					&structs.ServiceResolverConfigEntry{
+						Kind:                          structs.ServiceResolver,
+						Name:                          "db",
+						PerConnectionBufferLimitBytes: 1048576,
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
+			// TODO(proxystate): connection buffer limits are not yet supported in the converter.
 			alsoRunTestForV2: false,
 		},
 		{
-			name: "connect-proxy-with-tcp-chain-double-failover-through-local-gateway-triggered",
+			name: "connect-proxy-with-chain-and-resolver-passive-health-check",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-local-gateway-triggered", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						PassiveHealthCheck: &structs.PassiveHealthCheck{
+							Interval:                       10 * time.Second,
+							MaxFailures:                    3,
+							MaxEjectionPercent:             uint32ptr(50),
+							BaseEjectionTime:               durationPtr(45 * time.Second),
+							SplitExternalLocalOriginErrors: true,
+							ConsecutiveLocalOriginFailure:  uint32ptr(7),
+						},
+					},
+				)
 			},
-			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: true,
+		},
+		{
+			// The downstream's upstream config takes precedence over the
+			// resolver for each field it sets.
+			name: "connect-proxy-with-chain-and-upstream-passive-health-check-over-resolver",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["passive_health_check"] = map[string]interface{}{
+						"max_failures":       10,
+						"base_ejection_time": "5s",
+					}
+				}, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						PassiveHealthCheck: &structs.PassiveHealthCheck{
+							Interval:         10 * time.Second,
+							MaxFailures:      3,
+							BaseEjectionTime: durationPtr(45 * time.Second),
+						},
+					},
+				)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-chain-grpc-health-check",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "grpc"
+				}, nil,
+					&structs.ServiceResolverConfigEntry{
+						Kind: structs.ServiceResolver,
+						Name: "db",
+						GRPCHealthCheck: &structs.GRPCHealthCheckConfig{
+							ServiceName:     "db.v1.DB",
+							AuthorityHeader: "db.internal",
+						},
+					},
+				)
+			},
+			// TODO(proxystate): active health checks are not yet supported in the converter.
 			alsoRunTestForV2: false,
 		},
 		{
-			name: "splitter-with-resolver-redirect",
+			name: "connect-proxy-with-chain-and-router-virtual-host-rate-limit",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "db",
+						Protocol: "http",
+					},
+					&structs.ServiceRouterConfigEntry{
+						Kind: structs.ServiceRouter,
+						Name: "db",
+						Routes: []structs.ServiceRoute{
+							{
+								Match: &structs.ServiceRouteMatch{
+									HTTP: &structs.ServiceRouteHTTPMatch{PathPrefix: "/admin"},
+								},
+								Destination: &structs.ServiceRouteDestination{
+									RequestTimeout: 5 * time.Second,
+								},
+							},
+						},
+						VirtualHostRateLimit: &structs.VirtualHostRateLimitConfig{
+							RequestsPerSecond: 100,
+							RequestsMaxBurst:  200,
+						},
+					},
+				)
+			},
+			// TODO(proxystate): virtual host rate limits are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			// Without RequestsMaxBurst the bucket holds one second of requests.
+			name: "connect-proxy-with-chain-and-router-virtual-host-rate-limit-default-burst",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, nil,
+					&structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "db",
+						Protocol: "http",
+					},
+					&structs.ServiceRouterConfigEntry{
+						Kind: structs.ServiceRouter,
+						Name: "db",
+						Routes: []structs.ServiceRoute{
+							{
+								Match: &structs.ServiceRouteMatch{
+									HTTP: &structs.ServiceRouteHTTPMatch{PathPrefix: "/admin"},
+								},
+							},
+						},
+						VirtualHostRateLimit: &structs.VirtualHostRateLimitConfig{
+							RequestsPerSecond: 100,
+						},
+					},
+				)
+			},
+			// TODO(proxystate): virtual host rate limits are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-keepalives",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_tcp_keepalive"] = map[string]interface{}{
+						"keepalive_time":     120,
+						"keepalive_interval": 30,
+						"keepalive_probes":   5,
+					}
+					// Unset fields keep the operating system defaults.
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"envoy_upstream_tcp_keepalive": map[string]interface{}{
+							"keepalive_time": 60,
+						},
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-chain-and-mesh-tcp-keepalive",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								TCPKeepalive: &structs.TCPKeepaliveConfig{
+									Time:     60,
+									Interval: 10,
+									Probes:   3,
+								},
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			// The upstream's keepalive config replaces the mesh default.
+			name: "connect-proxy-with-chain-and-upstream-tcp-keepalive-over-mesh",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_tcp_keepalive"] = map[string]interface{}{
+						"keepalive_time": 120,
+					}
+				}, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								TCPKeepalive: &structs.TCPKeepaliveConfig{
+									Time:     60,
+									Interval: 10,
+									Probes:   3,
+								},
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-chain-http-max-header-count",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_max_header_count"] = 64
+				}, nil)
+			},
+			// TODO(proxystate): upstream max header count is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-http2-max-header-count",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http2"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_max_header_count"] = 64
+				}, nil)
+			},
+			// TODO(proxystate): upstream max header count is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-http-header-key-format-default",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_header_key_format"] = "default"
+				}, nil)
+			},
+			// TODO(proxystate): upstream header key format is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-http-header-key-format-proper-case-words",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_header_key_format"] = "proper_case_words"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_max_header_count"] = 64
+				}, nil)
+			},
+			// TODO(proxystate): upstream header key format is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-cluster-warm-up-duration",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_warm_up_duration_ms"] = 30000
+				}, nil)
+			},
+			// TODO(proxystate): cluster warm up duration is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-http2-window-sizes",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "grpc"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_http2_initial_stream_window_size"] = 1048576
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_http2_initial_connection_window_size"] = 4194304
+				}, nil)
+			},
+			// TODO(proxystate): upstream HTTP/2 window sizes are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-http2-override-stream-error",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http2"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_override_stream_error"] = true
+				}, nil)
+			},
+			// TODO(proxystate): upstream HTTP/2 stream error overrides are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-upstream-http-version-auto",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http2"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_http2_initial_stream_window_size"] = 1048576
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_http_version_auto"] = true
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol":                         "http",
+						"envoy_upstream_header_key_format": "proper_case_words",
+						"envoy_upstream_http_version_auto": true,
+					}
+				}, nil)
+			},
+			// TODO(proxystate): upstream HTTP version auto config is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-grpc-max-stream-duration",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "grpc"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_max_stream_duration_ms"] = 60000
+				}, nil)
+			},
+			// TODO(proxystate): upstream max stream duration is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-upstream-idle-timeout",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_idle_timeout"] = "90s"
+					// A zero timeout leaves Envoy's default in place.
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol":                    "http",
+						"envoy_upstream_idle_timeout": "0s",
+					}
+				}, nil)
+			},
+			// TODO(proxystate): upstream idle timeouts are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-upstream-cluster-warming",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_warmup_timeout"] = "30s"
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_wait_for_warm_on_init"] = true
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"envoy_cluster_warmup_timeout":        "15s",
+						"envoy_cluster_wait_for_warm_on_init": true,
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-upstream-cluster-warming-zero-values",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					// A zero timeout waits for endpoints indefinitely, and
+					// false stops initialization from waiting on the cluster.
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_warmup_timeout"] = "0s"
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_wait_for_warm_on_init"] = false
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"envoy_cluster_warmup_timeout":        "0s",
+						"envoy_cluster_wait_for_warm_on_init": false,
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-mesh-http-idle-timeout",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "http",
+					}
+				}, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								HTTPIdleTimeout: structs.DurationPointer(2 * time.Minute),
+							},
+						},
+					},
+				})
+			},
+			// TODO(proxystate): upstream idle timeouts are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			// The upstream's idle timeout replaces the mesh default.
+			name: "connect-proxy-with-upstream-idle-timeout-over-mesh",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_idle_timeout"] = "90s"
+					// An explicit zero restores Envoy's default.
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol":                    "http",
+						"envoy_upstream_idle_timeout": "0s",
+					}
+				}, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								HTTPIdleTimeout: structs.DurationPointer(2 * time.Minute),
+							},
+						},
+					},
+				})
+			},
+			// TODO(proxystate): upstream idle timeouts are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-upstream-max-requests-per-connection",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_max_requests_per_connection"] = 1000
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "http2",
+						"envoy_upstream_max_requests_per_connection": 100,
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-upstream-active-health-check-http",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http"
+					ns.Proxy.Upstreams[0].Config["active_health_check"] = map[string]interface{}{
+						"type":              "http",
+						"path":              "/health",
+						"interval":          "5s",
+						"healthy_threshold": 3,
+					}
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "http2",
+						"active_health_check": map[string]interface{}{
+							"type": "http",
+						},
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-upstream-active-health-check-tcp",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["active_health_check"] = map[string]interface{}{
+						"type": "tcp",
+					}
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"active_health_check": map[string]interface{}{
+							"type":                "tcp",
+							"timeout":             "1s",
+							"unhealthy_threshold": 5,
+						},
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-upstream-active-health-check-grpc",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "grpc"
+					ns.Proxy.Upstreams[0].Config["active_health_check"] = map[string]interface{}{
+						"type":     "grpc",
+						"interval": "15s",
+					}
+					// gRPC health checks need HTTP/2, so this one is ignored.
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "tcp",
+						"active_health_check": map[string]interface{}{
+							"type": "grpc",
+						},
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-custom-sni",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_sni"] = "db.tenant-a.example.com"
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"envoy_upstream_sni": "geo-cache.tenant-a.example.com",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			// The failover target in dc2 is reached through a mesh gateway and
+			// keeps the SNI the gateway routes on.
+			name: "connect-proxy-with-custom-sni-and-failover-through-remote-gateway",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-remote-gateway", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_sni"] = "db.tenant-a.example.com"
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-local-address",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_local_address"] = "10.0.0.5"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_local_port"] = 9000
+				}, nil)
+			},
+			// TODO(proxystate): upstream local address is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-extra-source-addresses",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_local_address"] = "10.0.0.5"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_extra_source_addresses"] = []interface{}{
+						map[string]interface{}{
+							"address": "[2001:db8::5]",
+							"port":    9001,
+						},
+					}
+				}, nil)
+			},
+			// TODO(proxystate): upstream local address is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-local-address-ipv6",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_local_address"] = "[2001:db8::5]"
+				}, nil)
+			},
+			// TODO(proxystate): upstream local address is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-tcp-fast-open",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_tcp_fast_open"] = true
+				}, nil)
+			},
+			// TODO(proxystate): upstream TCP Fast Open is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-per-downstream-connection-pool",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["protocol"] = "http2"
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_per_downstream_connection_pool"] = true
+				}, nil)
+			},
+			// TODO(proxystate): per downstream connection pools are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-upstream-stats-prefix",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_stats_prefix"] = "db_upstream"
+				}, nil)
+			},
+			// TODO(proxystate): cluster stats prefixes are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-and-failover-upstream-stats-prefix",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_cluster_stats_prefix"] = "db_upstream"
+				}, nil)
+			},
+			// TODO(proxystate): cluster stats prefixes are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-and-overrides",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "simple-with-overrides", enterprise, nil, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-chain-and-failover",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-chain-and-failover-overprovisioning-factor",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-with-overprovisioning-factor", enterprise, nil, nil)
+			},
+			// TODO(proxystate): failover overprovisioning factor is not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-failover-through-remote-gateway",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-remote-gateway", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-failover-through-remote-gateway-triggered",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-remote-gateway-triggered", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-double-failover-through-remote-gateway",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-remote-gateway", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-double-failover-through-remote-gateway-triggered",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-remote-gateway-triggered", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-failover-through-local-gateway",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-local-gateway", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-failover-through-local-gateway-triggered",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-local-gateway-triggered", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-double-failover-through-local-gateway",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-local-gateway", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "connect-proxy-with-tcp-chain-double-failover-through-local-gateway-triggered",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "failover-through-double-local-gateway-triggered", enterprise, nil, nil)
+			},
+			// TODO(proxystate): requires routes work
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "splitter-with-resolver-redirect",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "splitter-with-resolver-redirect-multidc", enterprise, nil, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-lb-in-resolver",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "lb-resolver", enterprise, nil, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-lb-in-resolver-ring-hash-header",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "lb-resolver-ring-hash-header", enterprise, nil, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			// The resolver sets no ring sizes, so the mesh default applies.
+			name: "connect-proxy-lb-in-resolver-with-mesh-ring-hash-defaults",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "splitter-with-resolver-redirect-multidc", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "lb-resolver-ring-hash-header", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								DefaultRingHashConfig: &structs.RingHashConfig{
+									MinimumRingSize: 1024,
+									MaximumRingSize: 8192,
+								},
+							},
+						},
+					},
+				})
 			},
 			alsoRunTestForV2: true,
 		},
 		{
-			name: "connect-proxy-lb-in-resolver",
+			// The resolver's own ring sizes take precedence over the mesh default.
+			name: "connect-proxy-lb-in-resolver-ring-hash-over-mesh-defaults",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
-				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "lb-resolver", enterprise, nil, nil)
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "lb-resolver", enterprise, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								DefaultRingHashConfig: &structs.RingHashConfig{
+									MinimumRingSize: 1024,
+									MaximumRingSize: 8192,
+								},
+							},
+						},
+					},
+				})
 			},
 			alsoRunTestForV2: true,
 		},
@@ -639,6 +1669,52 @@ func getConnectProxyDiscoChainTests(enterprise bool) []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-chain-and-router-upstream-retry-policy",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "chain-and-router", enterprise, func(ns *structs.NodeService) {
+					// Routes whose destination sets its own retries keep them.
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_retry_policy"] = map[string]interface{}{
+						"num_retries":            3,
+						"retry_on":               "5xx,reset",
+						"retriable_status_codes": []interface{}{503, 504},
+						"per_try_timeout":        "2s",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-grpc-router-upstream-retry-policy",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "grpc-router", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_retry_policy"] = map[string]interface{}{
+						"num_retries":     2,
+						"retry_on":        "cancelled,unavailable",
+						"per_try_timeout": "500ms",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-default-chain-upstream-retry-policy",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotDiscoveryChain(t, "default", enterprise, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[0].Config["envoy_upstream_retry_policy"] = map[string]interface{}{
+						"num_retries":            4,
+						"retriable_status_codes": []interface{}{503},
+					}
+				}, nil,
+					&structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "db",
+						Protocol: "http",
+					},
+				)
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-route-to-lb-resolver",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -733,6 +1809,68 @@ func getConnectProxyDiscoChainTests(enterprise bool) []goldenTestCase {
 	}
 }
 
+// meshGatewayV2CoverageDeadline is the Consul version by which every mesh
+// gateway golden test case is expected to also run against the v2 xDS
+// generator. Bump it if mesh gateway support in the proxystate converter
+// slips to a later release.
+const meshGatewayV2CoverageDeadline = "1.21.0"
+
+// meshGatewayV2Exclusions lists the mesh gateway golden test cases that do not
+// run against the v2 xDS generator yet, because the proxystate converter does
+// not support mesh gateways. It must be empty from meshGatewayV2CoverageDeadline.
+var meshGatewayV2Exclusions = []string{
+	"mesh-gateway",
+	"mesh-gateway-with-limits",
+	"mesh-gateway-using-federation-states",
+	"mesh-gateway-using-federation-control-plane",
+	"mesh-gateway-newer-information-in-federation-states",
+	"mesh-gateway-older-information-in-federation-states",
+	"mesh-gateway-no-services",
+	"mesh-gateway-service-subsets",
+	"mesh-gateway-service-subsets2",
+	"mesh-gateway-empty-service-subset",
+	"mesh-gateway-empty-service-subset-fallback",
+	"mesh-gateway-default-service-subset",
+	"mesh-gateway-ignore-extra-resolvers",
+	"mesh-gateway-service-timeouts",
+	"mesh-gateway-non-hash-lb-injected",
+	"mesh-gateway-custom-dns-refresh",
+	"mesh-gateway-hash-lb-ignored",
+	"mesh-gateway-tcp-keepalives",
+	"mesh-gateway-tagged-addresses",
+	"mesh-gateway-custom-addresses",
+	"mesh-gateway-with-exported-peered-services",
+	"mesh-gateway-with-exported-peered-services-http",
+	"mesh-gateway-with-exported-peered-services-http-with-router",
+	"mesh-gateway-with-custom-alpn",
+	"mesh-gateway-peering-control-plane",
+	"mesh-gateway-with-imported-peered-services",
+	"mesh-gateway-with-peer-through-mesh-gateway-enabled",
+}
+
+// TestMeshGatewayV2Coverage keeps meshGatewayV2Exclusions in step with the
+// mesh gateway golden test cases, so that a new case cannot quietly skip the
+// v2 path and a case that starts running against v2 is dropped from the list.
+// Once Consul reaches meshGatewayV2CoverageDeadline it also fails while any
+// case is still excluded, so that none are left out of the v2 path for good.
+func TestMeshGatewayV2Coverage(t *testing.T) {
+	var excluded []string
+	for _, tc := range append(getMeshGatewayGoldenTestCases(), getMeshGatewayPeeringGoldenTestCases()...) {
+		if !tc.alsoRunTestForV2 {
+			excluded = append(excluded, tc.name)
+		}
+	}
+	require.ElementsMatch(t, meshGatewayV2Exclusions, excluded)
+
+	current, err := goversion.NewVersion(version.Version)
+	require.NoError(t, err)
+	deadline := goversion.Must(goversion.NewVersion(meshGatewayV2CoverageDeadline))
+	if current.LessThan(deadline) {
+		return
+	}
+	require.Empty(t, excluded, "mesh gateway test cases must set alsoRunTestForV2 from Consul %s", deadline)
+}
+
 func getMeshGatewayGoldenTestCases() []goldenTestCase {
 	return []goldenTestCase{
 		{
@@ -807,6 +1945,22 @@ func getMeshGatewayGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): mesh gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name: "mesh-gateway-empty-service-subset",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotMeshGateway(t, "empty-service-subset", nil, nil)
+			},
+			// TODO(proxystate): mesh gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
+		{
+			name: "mesh-gateway-empty-service-subset-fallback",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotMeshGateway(t, "empty-service-subset-fallback", nil, nil)
+			},
+			// TODO(proxystate): mesh gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "mesh-gateway-default-service-subset",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -839,6 +1993,19 @@ func getMeshGatewayGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): mesh gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name: "mesh-gateway-custom-dns-refresh",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotMeshGateway(t, "default", func(ns *structs.NodeService) {
+					ns.Proxy.Config = map[string]interface{}{
+						"envoy_dns_discovery_type":  "STRICT_DNS",
+						"envoy_dns_refresh_rate_ms": 1000,
+					}
+				}, nil)
+			},
+			// TODO(proxystate): mesh gateways will come at a later date.
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "mesh-gateway-hash-lb-ignored",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -922,6 +2089,30 @@ func getMeshGatewayPeeringGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): mesh gateways will come at a later date.
 			alsoRunTestForV2: false,
 		},
+		{
+			name: "mesh-gateway-with-custom-alpn",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotPeeredMeshGateway(t, "default-services-http", nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								TLS: &structs.MeshTLSConfig{
+									Incoming: &structs.MeshDirectionalTLSConfig{
+										ALPNProtocols: []string{"h2"},
+									},
+									Outgoing: &structs.MeshDirectionalTLSConfig{
+										ALPNProtocols: []string{"h2", "http/1.1"},
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			// TODO(proxystate): mesh gateways will come at a later date.
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "mesh-gateway-peering-control-plane",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -1834,6 +3025,87 @@ func getCustomConfigurationGoldenTestCases(enterprise bool) []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "connect-proxy-with-prepared-query-protocol-http",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "http",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-prepared-query-protocol-http2",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "http2",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			name: "connect-proxy-with-prepared-query-protocol-grpc",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"protocol": "grpc",
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
+		{
+			// The prepared query upstream's own list takes precedence over
+			// the mesh-wide outgoing list used by the discovery chain.
+			name: "connect-proxy-with-custom-alpn",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Upstreams[1].Config = map[string]interface{}{
+						"envoy_upstream_alpn_protocols": []string{"http/1.1"},
+					}
+				}, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								TLS: &structs.MeshTLSConfig{
+									Incoming: &structs.MeshDirectionalTLSConfig{
+										ALPNProtocols: []string{"h2"},
+									},
+									Outgoing: &structs.MeshDirectionalTLSConfig{
+										ALPNProtocols: []string{"h2", "http/1.1"},
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			// TODO(proxystate): ALPN protocols are not yet supported in the converter.
+			alsoRunTestForV2: false,
+		},
+		{
+			// The mesh default applies to both the discovery chain and the
+			// prepared query upstream when neither sets its own timeout.
+			name: "connect-proxy-with-custom-connect-timeout",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								ConnectTimeout: structs.DurationPointer(12 * time.Second),
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "custom-passive-healthcheck",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -1932,6 +3204,24 @@ func getCustomConfigurationGoldenTestCases(enterprise bool) []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			name: "custom-limits-max-retries",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					for i := range ns.Proxy.Upstreams {
+						if ns.Proxy.Upstreams[i].Config == nil {
+							ns.Proxy.Upstreams[i].Config = map[string]interface{}{}
+						}
+
+						ns.Proxy.Upstreams[i].Config["limits"] = map[string]interface{}{
+							"max_concurrent_requests": 700,
+							"max_retries":             5,
+						}
+					}
+				}, nil)
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-with-default-chain-and-custom-cluster",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -2123,6 +3413,12 @@ func getTerminatingGatewayPeeringGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): terminating gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "terminating-gateway-proxy-protocol",
+			create: proxycfg.TestConfigSnapshotTerminatingGatewayProxyProtocol,
+			// TODO(proxystate): terminating gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name:   "terminating-gateway-http2-upstream",
 			create: proxycfg.TestConfigSnapshotTerminatingGatewayHTTP2,
@@ -2153,6 +3449,12 @@ func getTerminatingGatewayPeeringGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): terminating gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "terminating-gateway-lb-config-cluster-provided",
+			create: proxycfg.TestConfigSnapshotTerminatingGatewayLBConfigClusterProvided,
+			// TODO(proxystate): terminating gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "terminating-gateway-tcp-keepalives",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -2247,6 +3549,19 @@ func getTerminatingGatewayPeeringGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): terminating gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name: "terminating-gateway-logical-dns-with-custom-resolvers",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshotTerminatingGateway(t, true, func(ns *structs.NodeService) {
+					ns.Proxy.Config = map[string]interface{}{
+						"envoy_dns_discovery_type": "LOGICAL_DNS",
+						"envoy_dns_resolvers":      []interface{}{"10.0.0.53", "[2001:db8::53]:5353"},
+					}
+				}, nil)
+			},
+			// TODO(proxystate): terminating gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "terminating-gateway-custom-and-tagged-addresses",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -2668,6 +3983,12 @@ func getIngressGatewayGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): terminating gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "ingress-gateway-shared-upstream",
+			create: proxycfg.TestConfigSnapshotIngress_MultipleListenersSharedUpstream,
+			// TODO(proxystate): ingress gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "ingress-config-entry-nil",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -2718,6 +4039,12 @@ func getIngressGatewayGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): ingress gateway will come at a later time
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "ingress-gateway-wildcard-listener",
+			create: proxycfg.TestConfigSnapshotIngress_WildcardListener,
+			// TODO(proxystate): ingress gateway will come at a later time
+			alsoRunTestForV2: false,
+		},
 		{
 			name:   "ingress-grpc-multiple-services",
 			create: proxycfg.TestConfigSnapshotIngress_GRPCMultipleServices,
@@ -2979,6 +4306,44 @@ func getAccessLogsGoldenTestCases() []goldenTestCase {
 					nil)
 			},
 		},
+		{
+			name: "access-logs-filters-status-code-range",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Config["protocol"] = "http"
+					ns.Proxy.AccessLogs = structs.AccessLogsConfig{
+						Enabled: true,
+						AccessLogFilters: []structs.AccessLogFilter{
+							{StatusCodeRange: &structs.AccessLogStatusCodeRange{Min: 400, Max: 599}},
+						},
+					}
+				},
+					nil)
+			},
+		},
+		{
+			name: "access-logs-filters-combined",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, func(ns *structs.NodeService) {
+					ns.Proxy.Config["protocol"] = "grpc"
+					ns.Proxy.AccessLogs = structs.AccessLogsConfig{
+						Enabled: true,
+						AccessLogFilters: []structs.AccessLogFilter{
+							{StatusCodeRange: &structs.AccessLogStatusCodeRange{Min: 500}},
+							{GRPCStatusCode: &structs.AccessLogGRPCStatusFilter{
+								Statuses: []string{"OK", "CANCELED"},
+								Exclude:  true,
+							}},
+							{RuntimeFilter: &structs.AccessLogRuntimeFilter{
+								RuntimeKey:     "access_log.sample",
+								PercentSampled: 25,
+							}},
+						},
+					}
+				},
+					nil)
+			},
+		},
 	}
 }
 
@@ -3130,6 +4495,31 @@ func getTLSGoldenTestCases() []goldenTestCase {
 			},
 			alsoRunTestForV2: true,
 		},
+		{
+			// Upstream clusters must only pick up the outgoing settings, and
+			// the public listener only the incoming ones.
+			name: "connect-proxy-with-tls-incoming-and-outgoing-min-version",
+			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
+				return proxycfg.TestConfigSnapshot(t, nil, []proxycfg.UpdateEvent{
+					{
+						CorrelationID: "mesh",
+						Result: &structs.ConfigEntryResponse{
+							Entry: &structs.MeshConfigEntry{
+								TLS: &structs.MeshTLSConfig{
+									Incoming: &structs.MeshDirectionalTLSConfig{
+										TLSMinVersion: types.TLSv1_3,
+									},
+									Outgoing: &structs.MeshDirectionalTLSConfig{
+										TLSMinVersion: types.TLSv1_2,
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			alsoRunTestForV2: true,
+		},
 		{
 			name: "connect-proxy-with-tproxy-and-permissive-mtls",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -3169,6 +4559,12 @@ func getPeeredGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): peering will come at a later date.
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "connect-proxy-with-service-account-upstream",
+			create: proxycfg.TestConfigSnapshotPeeringWithServiceAccount,
+			// TODO(proxystate): peering will come at a later date.
+			alsoRunTestForV2: false,
+		},
 		{
 			name: "connect-proxy-with-peered-upstreams-http2",
 			create: func(t testinf.T) *proxycfg.ConfigSnapshot {
@@ -3177,6 +4573,12 @@ func getPeeredGoldenTestCases() []goldenTestCase {
 			// TODO(proxystate): peering will come at a later date.
 			alsoRunTestForV2: false,
 		},
+		{
+			name:   "connect-proxy-peer-trust-bundle-pending",
+			create: proxycfg.TestConfigSnapshotPeeringTrustBundlePending,
+			// TODO(proxystate): peering will come at a later date.
+			alsoRunTestForV2: false,
+		},
 		{
 			name:   "transparent-proxy-with-peered-upstreams",
 			create: proxycfg.TestConfigSnapshotPeeringTProxy,
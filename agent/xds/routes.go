@@ -13,7 +13,9 @@ import (
 
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/hashicorp/consul/agent/xds/config"
 	"github.com/hashicorp/consul/agent/xds/response"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -73,6 +76,16 @@ func (s *ResourceGenerator) routesForConnectProxy(cfgSnap *proxycfg.ConfigSnapsh
 			continue
 		}
 
+		if upstream, _ := cfgSnap.ConnectProxy.GetUpstream(uid, &cfgSnap.ProxyID.EnterpriseMeta); upstream != nil {
+			cfg, err := structs.ParseUpstreamConfigNoDefaults(upstream.Config)
+			if err != nil {
+				// Don't hard fail on a config typo, just warn. The parse func returns
+				// default config if there is an error so it's safe to continue.
+				s.Logger.Warn("failed to parse", "upstream", uid, "error", err)
+			}
+			injectUpstreamRetryPolicy(virtualHost, cfg.RetryPolicy)
+		}
+
 		route := &envoy_route_v3.RouteConfiguration{
 			Name:         uid.EnvoyID(),
 			VirtualHosts: []*envoy_route_v3.VirtualHost{virtualHost},
@@ -795,9 +808,45 @@ func (s *ResourceGenerator) makeUpstreamRouteForDiscoveryChain(
 		Routes:  routes,
 	}
 
+	if startNode.IsRouter() && startNode.VirtualHostRateLimit != nil {
+		rateLimit, err := makeVirtualHostLocalRateLimit(routeName, startNode.VirtualHostRateLimit)
+		if err != nil {
+			return nil, err
+		}
+		host.TypedPerFilterConfig = map[string]*anypb.Any{
+			localRateLimitFilterName: rateLimit,
+		}
+	}
+
 	return host, nil
 }
 
+// makeVirtualHostLocalRateLimit returns the per virtual host configuration for
+// the local rate limit HTTP filter. Every request to the virtual host draws
+// from a single token bucket that refills once per second.
+func makeVirtualHostLocalRateLimit(statPrefix string, cfg *structs.VirtualHostRateLimitConfig) (*anypb.Any, error) {
+	return anypb.New(&envoy_local_ratelimit_v3.LocalRateLimit{
+		StatPrefix: statPrefix,
+		TokenBucket: &envoy_type_v3.TokenBucket{
+			MaxTokens:     cfg.MaxBurst(),
+			TokensPerFill: wrapperspb.UInt32(cfg.RequestsPerSecond),
+			FillInterval:  durationpb.New(time.Second),
+		},
+		FilterEnabled: &envoy_core_v3.RuntimeFractionalPercent{
+			DefaultValue: &envoy_type_v3.FractionalPercent{
+				Numerator:   100,
+				Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+			},
+		},
+		FilterEnforced: &envoy_core_v3.RuntimeFractionalPercent{
+			DefaultValue: &envoy_type_v3.FractionalPercent{
+				Numerator:   100,
+				Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+			},
+		},
+	})
+}
+
 func getRetryPolicyForDestination(destination *structs.ServiceRouteDestination) *envoy_route_v3.RetryPolicy {
 	retryPolicy := &envoy_route_v3.RetryPolicy{}
 	if destination.NumRetries > 0 {
@@ -835,6 +884,34 @@ func getRetryPolicyForDestination(destination *structs.ServiceRouteDestination)
 	return retryPolicy
 }
 
+// injectUpstreamRetryPolicy sets the upstream's retry policy on every route in
+// the virtual host that does not already have one from its service-router
+// destination.
+func injectUpstreamRetryPolicy(virtualHost *envoy_route_v3.VirtualHost, policy *structs.UpstreamRetryPolicy) {
+	if policy == nil {
+		return
+	}
+	for _, route := range virtualHost.Routes {
+		if action := route.GetRoute(); action != nil && action.RetryPolicy == nil {
+			action.RetryPolicy = makeUpstreamRetryPolicy(policy)
+		}
+	}
+}
+
+func makeUpstreamRetryPolicy(policy *structs.UpstreamRetryPolicy) *envoy_route_v3.RetryPolicy {
+	retryPolicy := &envoy_route_v3.RetryPolicy{
+		RetryOn:              strings.Join(policy.RetryConditions(), ","),
+		RetriableStatusCodes: policy.RetriableStatusCodes,
+	}
+	if policy.NumRetries > 0 {
+		retryPolicy.NumRetries = response.MakeUint32Value(int(policy.NumRetries))
+	}
+	if policy.PerTryTimeout != nil && *policy.PerTryTimeout > 0 {
+		retryPolicy.PerTryTimeout = durationpb.New(*policy.PerTryTimeout)
+	}
+	return retryPolicy
+}
+
 func makeRouteMatchForDiscoveryRoute(discoveryRoute *structs.DiscoveryRoute) *envoy_route_v3.RouteMatch {
 	match := discoveryRoute.Definition.Match
 	if match == nil || match.IsEmpty() {
@@ -1075,8 +1152,9 @@ func injectLBToRouteAction(lb *structs.LoadBalancer, action *envoy_route_v3.Rout
 		return nil
 	}
 
-	result := make([]*envoy_route_v3.RouteAction_HashPolicy, 0, len(lb.HashPolicies))
-	for _, policy := range lb.HashPolicies {
+	hashPolicies := lb.EffectiveHashPolicies()
+	result := make([]*envoy_route_v3.RouteAction_HashPolicy, 0, len(hashPolicies))
+	for _, policy := range hashPolicies {
 		if policy.SourceIP {
 			result = append(result, &envoy_route_v3.RouteAction_HashPolicy{
 				PolicySpecifier: &envoy_route_v3.RouteAction_HashPolicy_ConnectionProperties_{
@@ -13,8 +13,10 @@ import (
 	envoy_aggregate_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
 	"github.com/hashicorp/consul/proto-public/pbmesh/v2beta1/pbproxystate"
@@ -117,6 +119,15 @@ func (pr *ProxyResources) makeEnvoyDynamicClusterAndEndpoint(name string, protoc
 		}
 		addEnvoyCircuitBreakers(dynamic.Config.CircuitBreakers, cluster)
 		addEnvoyOutlierDetection(dynamic.Config.OutlierDetection, cluster)
+		if err := addEnvoyMaxRequestsPerConnection(protocol, dynamic.Config.MaxRequestsPerConnection, cluster); err != nil {
+			return nil, nil, err
+		}
+		addEnvoyHealthCheck(protocol, dynamic.Config.HealthCheck, cluster)
+		if dynamic.Config.WarmupTimeout != nil {
+			cluster.EdsClusterConfig.EdsConfig.InitialFetchTimeout = dynamic.Config.WarmupTimeout
+		}
+		cluster.WaitForWarmOnInit = dynamic.Config.WaitForWarmOnInit
+		addEnvoyUpstreamConnectionOptions(dynamic.Config.UpstreamConnectionOptions, cluster)
 
 		err := addEnvoyLBToCluster(dynamic.Config, cluster)
 		if err != nil {
@@ -185,6 +196,8 @@ func (pr *ProxyResources) makeEnvoyPassthroughCluster(name string, protocol pbpr
 		ConnectTimeout:       passthrough.Config.ConnectTimeout,
 		LbPolicy:             envoy_cluster_v3.Cluster_CLUSTER_PROVIDED,
 		ClusterDiscoveryType: &envoy_cluster_v3.Cluster_Type{Type: envoy_cluster_v3.Cluster_ORIGINAL_DST},
+		// Passthrough endpoints are fully dynamic, one per original destination.
+		IgnoreHealthOnHostRemoval: true,
 	}
 	if passthrough.OutboundTls != nil {
 		envoyTransportSocket, err := pr.makeEnvoyTransportSocket(passthrough.OutboundTls)
@@ -309,6 +322,102 @@ func addHttpProtocolOptions(protocol pbproxystate.Protocol, c *envoy_cluster_v3.
 	return nil
 }
 
+// addEnvoyHealthCheck adds an active health check to the cluster. HTTP health checks against HTTP/2 and gRPC upstreams
+// use the HTTP/2 codec.
+func addEnvoyHealthCheck(protocol pbproxystate.Protocol, hc *pbproxystate.HealthCheck, c *envoy_cluster_v3.Cluster) {
+	if hc == nil {
+		return
+	}
+
+	check := &envoy_core_v3.HealthCheck{
+		Timeout:            hc.Timeout,
+		Interval:           hc.Interval,
+		UnhealthyThreshold: hc.UnhealthyThreshold,
+		HealthyThreshold:   hc.HealthyThreshold,
+	}
+	switch checker := hc.HealthChecker.(type) {
+	case *pbproxystate.HealthCheck_Http:
+		httpCheck := &envoy_core_v3.HealthCheck_HttpHealthCheck{
+			Path: checker.Http.GetPath(),
+		}
+		if protocol == pbproxystate.Protocol_PROTOCOL_HTTP2 || protocol == pbproxystate.Protocol_PROTOCOL_GRPC {
+			httpCheck.CodecClientType = envoy_type_v3.CodecClientType_HTTP2
+		}
+		check.HealthChecker = &envoy_core_v3.HealthCheck_HttpHealthCheck_{HttpHealthCheck: httpCheck}
+	case *pbproxystate.HealthCheck_Tcp:
+		check.HealthChecker = &envoy_core_v3.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: &envoy_core_v3.HealthCheck_TcpHealthCheck{},
+		}
+	case *pbproxystate.HealthCheck_Grpc:
+		check.HealthChecker = &envoy_core_v3.HealthCheck_GrpcHealthCheck_{
+			GrpcHealthCheck: &envoy_core_v3.HealthCheck_GrpcHealthCheck{},
+		}
+	default:
+		return
+	}
+	c.HealthChecks = append(c.HealthChecks, check)
+}
+
+// addEnvoyUpstreamConnectionOptions enables TCP keepalives on connections to the cluster's endpoints.
+func addEnvoyUpstreamConnectionOptions(opts *pbproxystate.UpstreamConnectionOptions, c *envoy_cluster_v3.Cluster) {
+	if opts == nil {
+		return
+	}
+	c.UpstreamConnectionOptions = &envoy_cluster_v3.UpstreamConnectionOptions{
+		TcpKeepalive: &envoy_core_v3.TcpKeepalive{
+			KeepaliveTime:     opts.TcpKeepaliveTime,
+			KeepaliveInterval: opts.TcpKeepaliveInterval,
+			KeepaliveProbes:   opts.TcpKeepaliveProbes,
+		},
+	}
+}
+
+// addEnvoyMaxRequestsPerConnection caps the number of requests sent on a single connection to an HTTP upstream. If the
+// cluster has no HTTP protocol options yet, HTTP/1.1 options are added.
+func addEnvoyMaxRequestsPerConnection(protocol pbproxystate.Protocol, maxRequests *wrapperspb.UInt32Value, c *envoy_cluster_v3.Cluster) error {
+	if maxRequests == nil {
+		return nil
+	}
+	switch protocol {
+	case pbproxystate.Protocol_PROTOCOL_HTTP, pbproxystate.Protocol_PROTOCOL_HTTP2, pbproxystate.Protocol_PROTOCOL_GRPC:
+	default:
+		return nil
+	}
+
+	const key = "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
+
+	cfg := &envoy_upstreams_v3.HttpProtocolOptions{}
+	if existing, ok := c.TypedExtensionProtocolOptions[key]; ok {
+		if err := existing.UnmarshalTo(cfg); err != nil {
+			return err
+		}
+	} else {
+		cfg.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+			ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+				ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+					HttpProtocolOptions: &envoy_core_v3.Http1ProtocolOptions{},
+				},
+			},
+		}
+	}
+
+	if cfg.CommonHttpProtocolOptions == nil {
+		cfg.CommonHttpProtocolOptions = &envoy_core_v3.HttpProtocolOptions{}
+	}
+	cfg.CommonHttpProtocolOptions.MaxRequestsPerConnection = maxRequests
+
+	any, err := anypb.New(cfg)
+	if err != nil {
+		return err
+	}
+	if c.TypedExtensionProtocolOptions == nil {
+		c.TypedExtensionProtocolOptions = make(map[string]*anypb.Any)
+	}
+	c.TypedExtensionProtocolOptions[key] = any
+
+	return nil
+}
+
 // addEnvoyOutlierDetection will add outlier detection config to the cluster, and if nil, add empty OutlierDetection to
 // enable it with default values
 func addEnvoyOutlierDetection(outlierDetection *pbproxystate.OutlierDetection, c *envoy_cluster_v3.Cluster) {
@@ -316,11 +425,13 @@ func addEnvoyOutlierDetection(outlierDetection *pbproxystate.OutlierDetection, c
 		return
 	}
 	od := &envoy_cluster_v3.OutlierDetection{
-		BaseEjectionTime:         outlierDetection.GetBaseEjectionTime(),
-		Consecutive_5Xx:          outlierDetection.GetConsecutive_5Xx(),
-		EnforcingConsecutive_5Xx: outlierDetection.GetEnforcingConsecutive_5Xx(),
-		Interval:                 outlierDetection.GetInterval(),
-		MaxEjectionPercent:       outlierDetection.GetMaxEjectionPercent(),
+		BaseEjectionTime:               outlierDetection.GetBaseEjectionTime(),
+		Consecutive_5Xx:                outlierDetection.GetConsecutive_5Xx(),
+		EnforcingConsecutive_5Xx:       outlierDetection.GetEnforcingConsecutive_5Xx(),
+		Interval:                       outlierDetection.GetInterval(),
+		MaxEjectionPercent:             outlierDetection.GetMaxEjectionPercent(),
+		SplitExternalLocalOriginErrors: outlierDetection.GetSplitExternalLocalOriginErrors(),
+		ConsecutiveLocalOriginFailure:  outlierDetection.GetConsecutiveLocalOriginFailure(),
 	}
 	c.OutlierDetection = od
 
@@ -336,6 +447,7 @@ func addEnvoyCircuitBreakers(circuitBreakers *pbproxystate.CircuitBreakers, c *e
 		threshold.MaxConnections = circuitBreakers.UpstreamLimits.MaxConnections
 		threshold.MaxPendingRequests = circuitBreakers.UpstreamLimits.MaxPendingRequests
 		threshold.MaxRequests = circuitBreakers.UpstreamLimits.MaxConcurrentRequests
+		threshold.MaxRetries = circuitBreakers.UpstreamLimits.MaxRetries
 
 		c.CircuitBreakers = &envoy_cluster_v3.CircuitBreakers{
 			Thresholds: []*envoy_cluster_v3.CircuitBreakers_Thresholds{threshold},
@@ -370,7 +482,7 @@ func addEnvoyLBToCluster(dynamicConfig *pbproxystate.DynamicEndpointGroupConfig,
 		c.LbPolicy = envoy_cluster_v3.Cluster_RING_HASH
 
 		lb := dynamicConfig.LbPolicy.(*pbproxystate.DynamicEndpointGroupConfig_RingHash)
-		if lb.RingHash != nil {
+		if lb.RingHash != nil && (lb.RingHash.MinimumRingSize != nil || lb.RingHash.MaximumRingSize != nil) {
 			c.LbConfig = &envoy_cluster_v3.Cluster_RingHashLbConfig_{
 				RingHashLbConfig: &envoy_cluster_v3.Cluster_RingHashLbConfig{
 					MinimumRingSize: lb.RingHash.MinimumRingSize,
@@ -381,6 +493,26 @@ func addEnvoyLBToCluster(dynamicConfig *pbproxystate.DynamicEndpointGroupConfig,
 	case *pbproxystate.DynamicEndpointGroupConfig_Maglev:
 		c.LbPolicy = envoy_cluster_v3.Cluster_MAGLEV
 
+	case *pbproxystate.DynamicEndpointGroupConfig_ClusterProvided:
+		c.LbPolicy = envoy_cluster_v3.Cluster_CLUSTER_PROVIDED
+
+		customType := &envoy_cluster_v3.Cluster_CustomClusterType{
+			Name: d.ClusterProvided.GetCustomClusterType(),
+		}
+		if cfgJSON := d.ClusterProvided.GetCustomClusterConfigJson(); cfgJSON != "" {
+			var typedConfig anypb.Any
+			if err := protojson.Unmarshal([]byte(cfgJSON), &typedConfig); err != nil {
+				return fmt.Errorf("failed to parse custom cluster config for cluster %q: %w", c.Name, err)
+			}
+			customType.TypedConfig = &typedConfig
+		}
+
+		// The custom cluster type is responsible for discovering its own hosts.
+		c.ClusterDiscoveryType = &envoy_cluster_v3.Cluster_ClusterType{
+			ClusterType: customType,
+		}
+		c.EdsClusterConfig = nil
+
 	default:
 		return fmt.Errorf("unsupported load balancer policy %q for cluster %q", d, c.Name)
 	}
@@ -34,13 +34,43 @@ func makeEnvoyEndpoint(endpoint *pbproxystate.Endpoint) *envoy_endpoint_v3.Endpo
 	}
 }
 
+// makeEnvoyClusterLoadAssignment groups endpoints by locality, preserving the
+// order in which each locality first appears.
 func makeEnvoyClusterLoadAssignment(clusterName string, endpoints []*pbproxystate.Endpoint) *envoy_endpoint_v3.ClusterLoadAssignment {
-	localityLbEndpoints := &envoy_endpoint_v3.LocalityLbEndpoints{}
+	type localityKey struct {
+		region, zone string
+	}
+
+	localityLbEndpoints := []*envoy_endpoint_v3.LocalityLbEndpoints{{}}
+	index := make(map[localityKey]int)
 	for _, endpoint := range endpoints {
-		localityLbEndpoints.LbEndpoints = append(localityLbEndpoints.LbEndpoints, makeEnvoyLbEndpoint(endpoint))
+		key := localityKey{
+			region: endpoint.GetLocality().GetRegion(),
+			zone:   endpoint.GetLocality().GetZone(),
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(index)
+			index[key] = i
+			if i > 0 {
+				localityLbEndpoints = append(localityLbEndpoints, &envoy_endpoint_v3.LocalityLbEndpoints{})
+			}
+			localityLbEndpoints[i].Locality = makeEnvoyLocality(endpoint.GetLocality())
+		}
+		localityLbEndpoints[i].LbEndpoints = append(localityLbEndpoints[i].LbEndpoints, makeEnvoyLbEndpoint(endpoint))
 	}
 	return &envoy_endpoint_v3.ClusterLoadAssignment{
 		ClusterName: clusterName,
-		Endpoints:   []*envoy_endpoint_v3.LocalityLbEndpoints{localityLbEndpoints},
+		Endpoints:   localityLbEndpoints,
+	}
+}
+
+func makeEnvoyLocality(locality *pbproxystate.Locality) *envoy_core_v3.Locality {
+	if locality.GetRegion() == "" && locality.GetZone() == "" {
+		return nil
+	}
+	return &envoy_core_v3.Locality{
+		Region: locality.GetRegion(),
+		Zone:   locality.GetZone(),
 	}
 }
@@ -5,6 +5,7 @@ package xdsv2
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/protobuf/proto"
@@ -44,7 +45,7 @@ type ProxyResources struct {
 	envoyResources map[string]map[string]proto.Message
 }
 
-func (g *ResourceGenerator) AllResourcesFromIR(proxyState *proxytracker.ProxyState) (map[string][]proto.Message, error) {
+func newProxyResources(proxyState *proxytracker.ProxyState) *ProxyResources {
 	pr := &ProxyResources{
 		proxyState:     proxyState,
 		envoyResources: make(map[string]map[string]proto.Message),
@@ -53,6 +54,11 @@ func (g *ResourceGenerator) AllResourcesFromIR(proxyState *proxytracker.ProxySta
 	pr.envoyResources[xdscommon.RouteType] = make(map[string]proto.Message)
 	pr.envoyResources[xdscommon.ClusterType] = make(map[string]proto.Message)
 	pr.envoyResources[xdscommon.EndpointType] = make(map[string]proto.Message)
+	return pr
+}
+
+func (g *ResourceGenerator) AllResourcesFromIR(proxyState *proxytracker.ProxyState) (map[string][]proto.Message, error) {
+	pr := newProxyResources(proxyState)
 
 	err := pr.makeEnvoyResourceGraphsStartingFromListeners()
 	if err != nil {
@@ -70,6 +76,32 @@ func (g *ResourceGenerator) AllResourcesFromIR(proxyState *proxytracker.ProxySta
 	return envoyResources, nil
 }
 
+// ListenersFromIR returns only the listeners for the given ProxyState, sorted
+// by name. It is the v2 counterpart of the v1 listenersFromSnapshot.
+//
+// Routes, clusters and endpoints reachable from the listeners are still
+// walked, since L7 listeners reference them, but they are discarded.
+func (g *ResourceGenerator) ListenersFromIR(proxyState *proxytracker.ProxyState) ([]proto.Message, error) {
+	pr := newProxyResources(proxyState)
+
+	err := pr.makeEnvoyResourceGraphsStartingFromListeners()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate listeners for ProxyState: %v", err)
+	}
+
+	names := make([]string, 0, len(pr.envoyResources[xdscommon.ListenerType]))
+	for name := range pr.envoyResources[xdscommon.ListenerType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	listeners := make([]proto.Message, 0, len(names))
+	for _, name := range names {
+		listeners = append(listeners, pr.envoyResources[xdscommon.ListenerType][name])
+	}
+	return listeners, nil
+}
+
 // convertResourceMapsToResourceArrays will convert map[string]map[string]proto.Message, which is used to
 // prevent duplicate resource being created, to map[string][]proto.Message which is used by Delta server.
 func convertResourceMapsToResourceArrays(resourceMap map[string]map[string]proto.Message) map[string][]proto.Message {
@@ -150,6 +150,49 @@ func (suite *resourceTestSuite) TestAllResourcesFromIR_XDSGoldenFileInputs() {
 	})
 }
 
+// TestListenersFromIR tests that ListenersFromIR() returns the same listeners,
+// in name order, as AllResourcesFromIR() for connect proxy and transparent
+// proxy shapes.
+func (suite *resourceTestSuite) TestListenersFromIR() {
+	suite.runTestCaseWithTenancies(func(tenancy *pbresource.Tenancy) {
+		inputPath := "../../internal/mesh/internal/controllers/xds"
+
+		cases := []string{
+			"destination/l4-implicit-and-explicit-destinations-tproxy",
+			"destination/l4-single-destination-ip-port-bind-address",
+			"destination/l4-single-implicit-destination-tproxy",
+			"destination/mixed-multi-destination",
+			"source/l7-expose-paths",
+			"source/local-and-inbound-connections",
+		}
+
+		for _, name := range cases {
+			suite.Run(name, func() {
+				testFile := fmt.Sprintf("%s-%s-%s.golden", name, tenancy.Partition, tenancy.Namespace)
+				inputFilePath := fmt.Sprintf("%s/testdata/%s", inputPath, testFile)
+				inputValueInput := golden.GetBytesAtFilePath(suite.T(), inputFilePath)
+
+				ps := jsonToProxyState(suite.T(), inputValueInput)
+				generator := NewResourceGenerator(testutil.Logger(suite.T()))
+				listeners, err := generator.ListenersFromIR(&proxytracker.ProxyState{ProxyState: ps})
+				require.NoError(suite.T(), err)
+				require.Len(suite.T(), listeners, len(ps.Listeners))
+
+				require.True(suite.T(), sort.SliceIsSorted(listeners, func(i, j int) bool {
+					return listeners[i].(*envoy_listener_v3.Listener).Name < listeners[j].(*envoy_listener_v3.Listener).Name
+				}))
+
+				resp, err := response.CreateResponse(xdscommon.ListenerType, "00000001", "00000001", listeners)
+				require.NoError(suite.T(), err)
+				gotJSON := protoToJSON(suite.T(), resp)
+
+				expectedJSON := golden.Get(suite.T(), gotJSON, fmt.Sprintf("listeners/%s", testFile))
+				require.JSONEq(suite.T(), expectedJSON, gotJSON)
+			})
+		}
+	})
+}
+
 func protoToJSON(t *testing.T, pb proto.Message) string {
 	t.Helper()
 	m := protojson.MarshalOptions{
@@ -456,6 +456,7 @@ func makeEnvoyRetryPolicyFromProxystateRetryPolicy(psRetryPolicy *pbproxystate.R
 		NumRetries:           psRetryPolicy.GetNumRetries(),
 		RetriableStatusCodes: psRetryPolicy.GetRetriableStatusCodes(),
 		RetryOn:              psRetryPolicy.GetRetryOn(),
+		PerTryTimeout:        psRetryPolicy.GetPerTryTimeout(),
 	}
 }
 
@@ -115,6 +115,8 @@ type GatewayService struct {
 	KeyFile      string   `json:",omitempty"`
 	SNI          string   `json:",omitempty"`
 	FromWildcard bool     `json:",omitempty"`
+
+	ProxyProtocolVersion string `json:",omitempty"`
 }
 
 // Catalog can be used to query the Catalog endpoints
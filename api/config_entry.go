@@ -198,6 +198,49 @@ type AccessLogsConfig struct {
 	// Defining Both is invalid.
 	JSONFormat string `json:",omitempty" alias:"json_format"`
 	TextFormat string `json:",omitempty" alias:"text_format"`
+
+	// AccessLogFilters restricts which requests are logged. A request is only
+	// logged when it matches every filter. Filters do not apply to listener
+	// logs for rejected connections.
+	AccessLogFilters []AccessLogFilter `json:",omitempty" alias:"access_log_filters"`
+}
+
+// AccessLogFilter is a single access log filter. Exactly one of its fields
+// must be set.
+type AccessLogFilter struct {
+	// StatusCodeRange matches requests whose HTTP response code is within
+	// the range.
+	StatusCodeRange *AccessLogStatusCodeRange `json:",omitempty" alias:"status_code_range"`
+
+	// GRPCStatusCode matches requests by their gRPC status.
+	GRPCStatusCode *AccessLogGRPCStatusFilter `json:",omitempty" alias:"grpc_status_code"`
+
+	// RuntimeFilter matches a percentage of requests that can be adjusted
+	// at runtime through an Envoy runtime key.
+	RuntimeFilter *AccessLogRuntimeFilter `json:",omitempty" alias:"runtime_filter"`
+}
+
+// AccessLogStatusCodeRange is an inclusive range of HTTP response codes.
+// Leaving Min or Max at zero leaves that end of the range open.
+type AccessLogStatusCodeRange struct {
+	Min int `json:",omitempty"`
+	Max int `json:",omitempty"`
+}
+
+// AccessLogGRPCStatusFilter matches requests that completed with one of
+// Statuses, or with any other status when Exclude is set.
+type AccessLogGRPCStatusFilter struct {
+	// Statuses are gRPC status names as Envoy spells them, such as "OK",
+	// "CANCELED" or "UNAVAILABLE".
+	Statuses []string `json:",omitempty"`
+	Exclude  bool     `json:",omitempty"`
+}
+
+// AccessLogRuntimeFilter samples PercentSampled percent of requests. The
+// percentage can be overridden through the RuntimeKey Envoy runtime value.
+type AccessLogRuntimeFilter struct {
+	RuntimeKey     string `json:",omitempty" alias:"runtime_key"`
+	PercentSampled int    `json:",omitempty" alias:"percent_sampled"`
 }
 
 type UpstreamConfiguration struct {
@@ -256,12 +299,121 @@ type UpstreamConfig struct {
 	// be monitored for removal from the load balancing pool.
 	PassiveHealthCheck *PassiveHealthCheck `json:",omitempty" alias:"passive_health_check"`
 
+	// ActiveHealthCheck configures the proxy to probe upstream proxy instances
+	// itself and remove the ones that fail from the load balancing pool.
+	ActiveHealthCheck *ActiveHealthCheck `json:",omitempty" alias:"active_health_check"`
+
 	// MeshGatewayConfig controls how Mesh Gateways are configured and used
 	MeshGateway MeshGatewayConfig `json:",omitempty" alias:"mesh_gateway" `
 
 	// BalanceOutboundConnections indicates that the proxy should attempt to evenly distribute
 	// outbound connections across worker threads. Only used by envoy proxies.
 	BalanceOutboundConnections string `json:",omitempty" alias:"balance_outbound_connections"`
+
+	// MaxHeaderCount limits the number of headers the proxy accepts on HTTP
+	// responses from this upstream. Only used by envoy proxies.
+	MaxHeaderCount int `json:",omitempty" alias:"envoy_upstream_max_header_count"`
+
+	// ClusterWarmUpDurationMs is the number of milliseconds over which traffic
+	// to a newly added upstream endpoint is gradually increased. Only used by
+	// envoy proxies.
+	ClusterWarmUpDurationMs int `json:",omitempty" alias:"envoy_cluster_warm_up_duration_ms"`
+
+	// WarmupTimeout is how long a new cluster for this upstream waits for its
+	// endpoints before the proxy considers it warm. Zero waits indefinitely.
+	// Only used by envoy proxies.
+	WarmupTimeout *time.Duration `json:",omitempty" alias:"envoy_cluster_warmup_timeout"`
+
+	// WaitForWarmOnInit controls whether the proxy waits for the clusters of
+	// this upstream to warm before it finishes initializing. Only used by
+	// envoy proxies.
+	WaitForWarmOnInit *bool `json:",omitempty" alias:"envoy_cluster_wait_for_warm_on_init"`
+
+	// HTTP2InitialStreamWindowSize is the initial HTTP/2 flow control window
+	// size, in bytes, for each stream to this upstream. Only used by envoy
+	// proxies.
+	HTTP2InitialStreamWindowSize int `json:",omitempty" alias:"envoy_upstream_http2_initial_stream_window_size"`
+
+	// HTTP2InitialConnectionWindowSize is the initial HTTP/2 flow control
+	// window size, in bytes, for each connection to this upstream. Only used by
+	// envoy proxies.
+	HTTP2InitialConnectionWindowSize int `json:",omitempty" alias:"envoy_upstream_http2_initial_connection_window_size"`
+
+	// HTTP2OverrideStreamError makes the proxy reset only the offending stream,
+	// rather than the whole connection, when this upstream sends a malformed
+	// HTTP/2 message. Only used by envoy proxies.
+	HTTP2OverrideStreamError bool `json:",omitempty" alias:"envoy_upstream_override_stream_error"`
+
+	// HTTPVersionAuto makes the proxy negotiate HTTP/1.1 or HTTP/2 with this
+	// upstream using ALPN. Only used by envoy proxies.
+	HTTPVersionAuto bool `json:",omitempty" alias:"envoy_upstream_http_version_auto"`
+
+	// MaxStreamDurationMs is the maximum number of milliseconds a single
+	// request or stream to this upstream may last before it is reset. Only used
+	// by envoy proxies.
+	MaxStreamDurationMs int `json:",omitempty" alias:"envoy_upstream_max_stream_duration_ms"`
+
+	// IdleTimeout is how long a connection to this upstream may have no
+	// active requests before the proxy closes it. Only used by envoy proxies.
+	IdleTimeout *time.Duration `json:",omitempty" alias:"envoy_upstream_idle_timeout"`
+
+	// MaxRequestsPerConnection is the number of requests the proxy sends on
+	// a single connection to this upstream before closing it. Only used by
+	// envoy proxies.
+	MaxRequestsPerConnection *uint32 `json:",omitempty" alias:"envoy_upstream_max_requests_per_connection"`
+
+	// LocalAddress is the source IP address the proxy binds to when opening
+	// connections to this upstream. Only used by envoy proxies.
+	LocalAddress string `json:",omitempty" alias:"envoy_upstream_local_address"`
+
+	// LocalPort is the source port the proxy binds to when opening connections
+	// to this upstream. Requires LocalAddress. Only used by envoy proxies.
+	LocalPort int `json:",omitempty" alias:"envoy_upstream_local_port"`
+
+	// ExtraSourceAddresses are further source addresses the proxy may bind
+	// to, of the other IP family from LocalAddress. Requires LocalAddress.
+	// Only used by envoy proxies.
+	ExtraSourceAddresses []UpstreamSourceAddress `json:",omitempty" alias:"envoy_upstream_extra_source_addresses"`
+
+	// TCPFastOpen enables TCP Fast Open on connections to this upstream. Only
+	// used by envoy proxies running on Linux.
+	TCPFastOpen bool `json:",omitempty" alias:"envoy_upstream_tcp_fast_open"`
+
+	// HeaderKeyFormat controls the casing of header names sent to this
+	// upstream over HTTP/1.1, either "default" or "proper_case_words". Only
+	// used by envoy proxies and only for the http protocol.
+	HeaderKeyFormat string `json:",omitempty" alias:"envoy_upstream_header_key_format"`
+
+	// TCPKeepalive enables TCP keepalives on connections to this upstream,
+	// replacing the mesh-wide default. Only used by envoy proxies.
+	TCPKeepalive *TCPKeepaliveConfig `json:",omitempty" alias:"envoy_upstream_tcp_keepalive"`
+
+	// PerDownstreamConnectionPool gives each downstream connection its own
+	// pool of connections to this upstream. Only used by envoy proxies.
+	PerDownstreamConnectionPool bool `json:",omitempty" alias:"envoy_upstream_per_downstream_connection_pool"`
+
+	// ClusterStatsPrefix replaces the cluster name in the stats envoy emits
+	// for the clusters of this upstream. Only used by envoy proxies.
+	ClusterStatsPrefix string `json:",omitempty" alias:"envoy_cluster_stats_prefix"`
+
+	// ALPNProtocols replaces the protocols advertised with ALPN on mTLS
+	// connections to this upstream. Only used by envoy proxies.
+	ALPNProtocols []string `json:",omitempty" alias:"envoy_upstream_alpn_protocols"`
+
+	// SNI replaces the server name sent in the TLS handshake with this
+	// upstream. Only used by envoy proxies.
+	SNI string `json:",omitempty" alias:"envoy_upstream_sni"`
+
+	// ClusterMetadata is attached to the clusters of this upstream as filter
+	// metadata under the "envoy.lb" namespace, where access logs and envoy
+	// extensions can read it. It is not used by subset load balancing, which
+	// matches endpoint metadata. Only used by envoy proxies.
+	ClusterMetadata map[string]string `json:",omitempty" alias:"envoy_cluster_metadata"`
+
+	// RetryPolicy sets how the proxy retries requests to this upstream. It is
+	// overridden by retries set on a service-router destination. Only used by
+	// envoy proxies and only for HTTP-like protocols.
+	RetryPolicy *UpstreamRetryPolicy `json:",omitempty" alias:"envoy_upstream_retry_policy"`
 }
 
 // DestinationConfig represents a virtual service, i.e. one that is external to Consul
@@ -295,6 +447,76 @@ type PassiveHealthCheck struct {
 	// multiplied by the number of times the host has been ejected and is capped by
 	// max_ejection_time (Default 300s). Defaults to 30000ms or 30s.
 	BaseEjectionTime *time.Duration `json:",omitempty" alias:"base_ejection_time"`
+
+	// SplitExternalLocalOriginErrors counts locally originated errors, such
+	// as connection timeouts and resets, separately from errors returned by
+	// the upstream host.
+	SplitExternalLocalOriginErrors bool `json:",omitempty" alias:"split_external_local_origin_errors"`
+
+	// ConsecutiveLocalOriginFailure is the count of consecutive locally
+	// originated failures that results in a host being removed from the pool.
+	// Requires SplitExternalLocalOriginErrors. Defaults to 5.
+	ConsecutiveLocalOriginFailure *uint32 `json:",omitempty" alias:"consecutive_local_origin_failure"`
+}
+
+// ActiveHealthCheck configures the proxy to send health check probes to each
+// instance of an upstream.
+type ActiveHealthCheck struct {
+	// Type is the kind of probe to send: "http", "tcp" or "grpc".
+	Type string `json:",omitempty"`
+
+	// Interval between probes of each upstream instance. Defaults to 10s.
+	Interval time.Duration `json:",omitempty"`
+
+	// Timeout is how long to wait for a response to a probe. Defaults to 5s.
+	Timeout time.Duration `json:",omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed probes that
+	// marks an instance unhealthy. Defaults to 2.
+	UnhealthyThreshold uint32 `json:",omitempty" alias:"unhealthy_threshold"`
+
+	// HealthyThreshold is the number of consecutive successful probes that
+	// marks an unhealthy instance healthy again. Defaults to 2.
+	HealthyThreshold uint32 `json:",omitempty" alias:"healthy_threshold"`
+
+	// Path is the HTTP path requested by http probes. Defaults to "/".
+	Path string `json:",omitempty"`
+}
+
+// UpstreamSourceAddress is a source address, and optionally port, that the
+// proxy may bind to when connecting to an upstream.
+type UpstreamSourceAddress struct {
+	Address string `json:",omitempty"`
+
+	// Port defaults to an ephemeral port.
+	Port int `json:",omitempty"`
+}
+
+// UpstreamRetryPolicy describes how the proxy retries failed requests to an
+// upstream.
+type UpstreamRetryPolicy struct {
+	NumRetries uint32 `json:",omitempty" alias:"num_retries"`
+
+	// RetryOn is a comma separated list of envoy retry conditions.
+	RetryOn string `json:",omitempty" alias:"retry_on"`
+
+	RetriableStatusCodes []uint32       `json:",omitempty" alias:"retriable_status_codes"`
+	PerTryTimeout        *time.Duration `json:",omitempty" alias:"per_try_timeout"`
+}
+
+// TCPKeepaliveConfig enables TCP keepalives on upstream connections. Any
+// field left as zero uses the operating system's default.
+type TCPKeepaliveConfig struct {
+	// Time is the number of seconds a connection must be idle before
+	// keepalive probes are sent.
+	Time uint32 `json:",omitempty" alias:"keepalive_time"`
+
+	// Interval is the number of seconds between keepalive probes.
+	Interval uint32 `json:",omitempty" alias:"keepalive_interval"`
+
+	// Probes is the number of unanswered probes after which the connection
+	// is considered dead.
+	Probes uint32 `json:",omitempty" alias:"keepalive_probes"`
 }
 
 // UpstreamLimits describes the limits that are associated with a specific
@@ -314,6 +536,10 @@ type UpstreamLimits struct {
 	// to the upstream cluster at a point in time. This is mostly applicable to HTTP/2
 	// clusters since all HTTP/1.1 requests are limited by MaxConnections.
 	MaxConcurrentRequests *int `alias:"max_concurrent_requests"`
+
+	// MaxRetries is the maximum number of retries that can be outstanding to
+	// the upstream cluster at a point in time.
+	MaxRetries *int `alias:"max_retries"`
 }
 
 // RateLimits is rate limiting configuration that is applied to
@@ -358,6 +584,19 @@ type InstanceLevelRouteRateLimits struct {
 	RequestsMaxBurst  int `alias:"requests_max_burst"`
 }
 
+// GRPCTranscodingConfig lets a gRPC service accept HTTP/1.1 JSON requests. The
+// service's sidecar transcodes them to gRPC before they reach the application.
+type GRPCTranscodingConfig struct {
+	// ProtoDescriptor is the path, on the proxy's host, of a binary
+	// FileDescriptorSet describing Services and their google.api.http
+	// annotations.
+	ProtoDescriptor string `json:",omitempty" alias:"proto_descriptor"`
+
+	// Services are the fully qualified names of the gRPC services to
+	// transcode, such as "bookstore.Bookstore".
+	Services []string `json:",omitempty"`
+}
+
 type ServiceConfigEntry struct {
 	Kind                      string
 	Name                      string
@@ -377,6 +616,7 @@ type ServiceConfigEntry struct {
 	LocalRequestTimeoutMs     int                     `json:",omitempty" alias:"local_request_timeout_ms"`
 	BalanceInboundConnections string                  `json:",omitempty" alias:"balance_inbound_connections"`
 	RateLimits                *RateLimits             `json:",omitempty" alias:"rate_limits"`
+	GRPCTranscoding           *GRPCTranscodingConfig  `json:",omitempty" alias:"grpc_transcoding"`
 	EnvoyExtensions           []EnvoyExtension        `json:",omitempty" alias:"envoy_extensions"`
 	Meta                      map[string]string       `json:",omitempty"`
 	CreateIndex               uint64
@@ -18,11 +18,22 @@ type ServiceRouterConfigEntry struct {
 
 	Routes []ServiceRoute `json:",omitempty"`
 
+	// VirtualHostRateLimit limits the rate of requests each downstream proxy
+	// sends to this service across all of its routes.
+	VirtualHostRateLimit *VirtualHostRateLimitConfig `json:",omitempty" alias:"virtual_host_rate_limit"`
+
 	Meta        map[string]string `json:",omitempty"`
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// VirtualHostRateLimitConfig is a local, per-proxy token bucket rate limit
+// applied to every route of a virtual host.
+type VirtualHostRateLimitConfig struct {
+	RequestsPerSecond uint32 `json:",omitempty" alias:"requests_per_second"`
+	RequestsMaxBurst  uint32 `json:",omitempty" alias:"requests_max_burst"`
+}
+
 func (e *ServiceRouterConfigEntry) GetKind() string            { return e.Kind }
 func (e *ServiceRouterConfigEntry) GetName() string            { return e.Name }
 func (e *ServiceRouterConfigEntry) GetPartition() string       { return e.Partition }
@@ -183,6 +194,36 @@ type ServiceResolverConfigEntry struct {
 	// issuing requests to this upstream service.
 	LoadBalancer *LoadBalancer `json:",omitempty" alias:"load_balancer"`
 
+	// CustomSANs is a list of URI SANs that upstream certificates for this
+	// service are validated against, instead of the SPIFFE ID Consul derives
+	// from the service name.
+	CustomSANs []string `json:",omitempty" alias:"custom_sans"`
+
+	// ClusterFilterChainMetadata is attached to the Envoy clusters for this
+	// service under the "envoy.network_policy" filter metadata namespace.
+	ClusterFilterChainMetadata map[string]string `json:",omitempty" alias:"cluster_filter_chain_metadata"`
+
+	// PerConnectionBufferLimitBytes is the soft limit on the size of each
+	// connection's read and write buffers for the Envoy clusters of this
+	// service. It overrides the mesh-wide default.
+	PerConnectionBufferLimitBytes uint32 `json:",omitempty" alias:"per_connection_buffer_limit_bytes"`
+
+	// GRPCHealthCheck enables active gRPC health checking of this service's
+	// instances by the proxies calling it. Only used for the http2 and grpc
+	// protocols.
+	GRPCHealthCheck *GRPCHealthCheckConfig `json:",omitempty" alias:"grpc_health_check"`
+
+	// PassiveHealthCheck configures outlier detection for the Envoy clusters
+	// of this service. Upstream configuration set by a downstream takes
+	// precedence for each field it sets.
+	PassiveHealthCheck *PassiveHealthCheck `json:",omitempty" alias:"passive_health_check"`
+
+	// EmptySubsetAction determines what happens when a subset's filter
+	// matches no service instances. Valid values are "error" (the default)
+	// and "fallback_to_default". The fallback is only applied by terminating
+	// and mesh gateways.
+	EmptySubsetAction string `json:",omitempty" alias:"empty_subset_action"`
+
 	Meta        map[string]string `json:",omitempty"`
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -268,6 +309,10 @@ type ServiceResolverFailover struct {
 	Targets       []ServiceResolverFailoverTarget `json:",omitempty"`
 	Policy        *ServiceResolverFailoverPolicy  `json:",omitempty"`
 	SamenessGroup string                          `json:",omitempty" alias:"sameness_group"`
+
+	// OverprovisioningFactor tunes how early traffic moves from the primary
+	// target to the failover targets. If zero, Envoy's default of 140 is used.
+	OverprovisioningFactor uint32 `json:",omitempty" alias:"overprovisioning_factor"`
 }
 
 type ServiceResolverFailoverTarget struct {
@@ -293,6 +338,18 @@ type ServiceResolverPrioritizeByLocality struct {
 	Mode string `json:",omitempty"`
 }
 
+// GRPCHealthCheckConfig configures active health checks that call the
+// grpc.health.v1.Health/Check method on each instance of a service.
+type GRPCHealthCheckConfig struct {
+	// ServiceName is the name of the gRPC service reported in the health
+	// check request. It is required.
+	ServiceName string `json:",omitempty" alias:"service_name"`
+
+	// AuthorityHeader is the value of the :authority header sent with the
+	// health check request. If empty, the name of the cluster is used.
+	AuthorityHeader string `json:",omitempty" alias:"authority_header"`
+}
+
 // LoadBalancer determines the load balancing policy and configuration for services
 // issuing requests to this upstream service.
 type LoadBalancer struct {
@@ -311,6 +368,15 @@ type LoadBalancer struct {
 	// If no hash policies are present, or none are successfully evaluated,
 	// then a random backend host will be selected.
 	HashPolicies []HashPolicy `json:",omitempty" alias:"hash_policies"`
+
+	// CustomClusterType is the name of an Envoy custom cluster type extension
+	// that provides its own load balancing. Only valid with the
+	// "cluster_provided" policy.
+	CustomClusterType string `json:",omitempty" alias:"custom_cluster_type"`
+
+	// CustomClusterConfigJSON is the JSON encoded typed config of the custom
+	// cluster type, including its "@type" field.
+	CustomClusterConfigJSON string `json:",omitempty" alias:"custom_cluster_config_json"`
 }
 
 // RingHashConfig contains configuration for the "ring_hash" policy type
@@ -320,6 +386,10 @@ type RingHashConfig struct {
 
 	// MaximumRingSize determines the maximum number of entries in the hash ring
 	MaximumRingSize uint64 `json:",omitempty" alias:"maximum_ring_size"`
+
+	// HeaderName is the name of a request header to hash on. It is added to the
+	// route hash policies in addition to any configured HashPolicies.
+	HeaderName string `json:",omitempty" alias:"header_name"`
 }
 
 // LeastRequestConfig contains configuration for the "least_request" policy type
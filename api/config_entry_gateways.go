@@ -218,6 +218,11 @@ type LinkedService struct {
 
 	// SNI is the optional name to specify during the TLS handshake with a linked service.
 	SNI string `json:",omitempty"`
+
+	// ProxyProtocolVersion makes the gateway send a PROXY protocol header,
+	// either "v1" or "v2", at the start of each connection to the linked
+	// service so that it can see the original client address.
+	ProxyProtocolVersion string `json:",omitempty" alias:"proxy_protocol_version"`
 }
 
 func (g *TerminatingGatewayConfigEntry) GetKind() string            { return g.Kind }
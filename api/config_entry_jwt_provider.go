@@ -205,7 +205,9 @@ type JWKSCluster struct {
 	// DiscoveryType refers to the service discovery type to use for resolving the cluster.
 	//
 	// This defaults to STRICT_DNS.
-	// Other options include STATIC, LOGICAL_DNS, EDS or ORIGINAL_DST.
+	// Other options include STATIC, LOGICAL_DNS, or ORIGINAL_DST.
+	// EDS is not supported because the cluster's only endpoint comes from
+	// the JWKS URI.
 	DiscoveryType ClusterDiscoveryType `json:",omitempty" alias:"discovery_type"`
 
 	// TLSCertificates refers to the data containing certificate authority certificates to use
@@ -218,6 +220,47 @@ type JWKSCluster struct {
 	// The timeout for new network connections to hosts in the cluster.
 	// If not set, a default value of 5s will be used.
 	ConnectTimeout time.Duration `json:",omitempty" alias:"connect_timeout"`
+
+	// SNI is the server name to present during the TLS handshake with the
+	// JWKS host. This is useful when the host's certificate is issued for a
+	// different name than the one in the URI, for example behind a CDN.
+	// If not set, no SNI is sent.
+	SNI string `json:",omitempty"`
+
+	// SocketOptions are additional socket options applied to connections
+	// made to the JWKS host, for example to set DSCP markings.
+	SocketOptions []SocketOption `json:",omitempty" alias:"socket_options"`
+
+	// ClusterName overrides the name of the cluster used to fetch the JWKS.
+	// If not set, the name is derived from the provider name. The name must
+	// not be used by another provider or by a cluster Consul generates for
+	// the proxy; a conflicting cluster is skipped with a warning.
+	ClusterName string `json:",omitempty" alias:"cluster_name"`
+
+	// DNSRefreshRate is how often the JWKS host name is resolved again when
+	// DiscoveryType is STRICT_DNS or LOGICAL_DNS. Must be greater than 1ms.
+	// If not set, Envoy's default of 5s is used.
+	DNSRefreshRate *time.Duration `json:",omitempty" alias:"dns_refresh_rate"`
+}
+
+// SocketOption is a raw socket option passed to setsockopt when connecting
+// to the JWKS host. Exactly one of IntValue or BufferValue is used; when
+// BufferValue is set it takes precedence.
+type SocketOption struct {
+	// Description is an optional name for the option used for debugging.
+	Description string `json:",omitempty"`
+
+	// Level is the level value passed to setsockopt, such as IPPROTO_IP.
+	Level int64 `json:",omitempty"`
+
+	// Name is the numeric option name passed to setsockopt, such as IP_TOS.
+	Name int64 `json:",omitempty"`
+
+	// IntValue is the integer value of the option.
+	IntValue int64 `json:",omitempty" alias:"int_value"`
+
+	// BufferValue is the raw bytes value of the option.
+	BufferValue []byte `json:",omitempty" alias:"buffer_value"`
 }
 
 type ClusterDiscoveryType string
@@ -236,6 +279,13 @@ type JWKSTLSCertificate struct {
 	//
 	// Exactly one of Filename, EnvironmentVariable, InlineString or InlineBytes must be specified.
 	TrustedCA *JWKSTLSCertTrustedCA `json:",omitempty" alias:"trusted_ca"`
+
+	// ClientCertificate is the certificate chain presented to JWKS hosts that
+	// require mutual TLS. It must be set together with PrivateKey.
+	ClientCertificate *JWKSTLSCertData `json:",omitempty" alias:"client_certificate"`
+
+	// PrivateKey is the private key for ClientCertificate.
+	PrivateKey *JWKSTLSCertData `json:",omitempty" alias:"private_key"`
 }
 
 // JWKSTLSCertTrustedCA defines TLS certificate data containing certificate authority certificates
@@ -249,6 +299,17 @@ type JWKSTLSCertTrustedCA struct {
 	InlineBytes         []byte `json:",omitempty" alias:"inline_bytes"`
 }
 
+// JWKSTLSCertData defines where to read a client certificate chain or
+// private key for mutual TLS with the JWKS host.
+//
+// Exactly one of Filename, EnvironmentVariable, InlineString or InlineBytes must be specified.
+type JWKSTLSCertData struct {
+	Filename            string `json:",omitempty" alias:"filename"`
+	EnvironmentVariable string `json:",omitempty" alias:"environment_variable"`
+	InlineString        string `json:",omitempty" alias:"inline_string"`
+	InlineBytes         []byte `json:",omitempty" alias:"inline_bytes"`
+}
+
 type JWKSTLSCertProviderInstance struct {
 	// InstanceName refers to the certificate provider instance name
 	//
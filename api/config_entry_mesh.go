@@ -5,6 +5,9 @@ package api
 
 import (
 	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 // MeshConfigEntry manages the global configuration for all service mesh
@@ -32,6 +35,28 @@ type MeshConfigEntry struct {
 
 	Peering *PeeringMeshConfig `json:",omitempty"`
 
+	Telemetry *MeshTelemetryConfig `json:",omitempty"`
+
+	// ConnectTimeout is the default timeout for new network connections to
+	// upstream services.
+	ConnectTimeout *time.Duration `json:",omitempty" alias:"connect_timeout"`
+
+	// PerConnectionBufferLimitBytes is the default soft limit on the size of
+	// each upstream connection's read and write buffers for all clusters.
+	PerConnectionBufferLimitBytes *uint32 `json:",omitempty" alias:"per_connection_buffer_limit_bytes"`
+
+	// TCPKeepalive is the default TCP keepalive configuration for connections
+	// from sidecar proxies to their upstreams.
+	TCPKeepalive *TCPKeepaliveConfig `json:",omitempty" alias:"tcp_keepalive"`
+
+	// DefaultRingHashConfig is the default ring size for clusters that use
+	// the ring_hash load balancing policy without ring sizes of their own.
+	DefaultRingHashConfig *RingHashConfig `json:",omitempty" alias:"default_ring_hash_config"`
+
+	// HTTPIdleTimeout is the default time a connection to an HTTP upstream
+	// may have no active requests before it is closed.
+	HTTPIdleTimeout *time.Duration `json:",omitempty" alias:"http_idle_timeout"`
+
 	Meta map[string]string `json:",omitempty"`
 
 	// CreateIndex is the Raft index this entry was created at. This is a
@@ -57,16 +82,30 @@ type MeshDirectionalTLSConfig struct {
 	TLSMinVersion string   `json:",omitempty" alias:"tls_min_version"`
 	TLSMaxVersion string   `json:",omitempty" alias:"tls_max_version"`
 	CipherSuites  []string `json:",omitempty" alias:"cipher_suites"`
+	ALPNProtocols []string `json:",omitempty" alias:"alpn_protocols"`
 }
 
 type MeshHTTPConfig struct {
 	SanitizeXForwardedClientCert bool `alias:"sanitize_x_forwarded_client_cert"`
+
+	// ResponseHeadersToRemove lists headers that every sidecar strips from
+	// the HTTP responses its local service sends.
+	ResponseHeadersToRemove []string `json:",omitempty" alias:"response_headers_to_remove"`
 }
 
 type PeeringMeshConfig struct {
 	PeerThroughMeshGateways bool `json:",omitempty" alias:"peer_through_mesh_gateways"`
 }
 
+type MeshTelemetryConfig struct {
+	TrackClusterStats *ClusterStatsConfig `json:",omitempty" alias:"track_cluster_stats"`
+}
+
+type ClusterStatsConfig struct {
+	RequestResponseSizes bool `json:",omitempty" alias:"request_response_sizes"`
+	TimeoutBudgets       bool `json:",omitempty" alias:"timeout_budgets"`
+}
+
 func (e *MeshConfigEntry) GetKind() string            { return MeshConfig }
 func (e *MeshConfigEntry) GetName() string            { return MeshConfigMesh }
 func (e *MeshConfigEntry) GetPartition() string       { return e.Partition }
@@ -76,15 +115,55 @@ func (e *MeshConfigEntry) GetCreateIndex() uint64     { return e.CreateIndex }
 func (e *MeshConfigEntry) GetModifyIndex() uint64     { return e.ModifyIndex }
 
 // MarshalJSON adds the Kind field so that the JSON can be decoded back into the
-// correct type.
+// correct type, and writes the timeouts as duration strings.
 func (e *MeshConfigEntry) MarshalJSON() ([]byte, error) {
 	type Alias MeshConfigEntry
 	source := &struct {
-		Kind string
+		Kind            string
+		ConnectTimeout  string `json:",omitempty"`
+		HTTPIdleTimeout string `json:",omitempty"`
 		*Alias
 	}{
 		Kind:  MeshConfig,
 		Alias: (*Alias)(e),
 	}
+	if e.ConnectTimeout != nil {
+		source.ConnectTimeout = e.ConnectTimeout.String()
+	}
+	if e.HTTPIdleTimeout != nil {
+		source.HTTPIdleTimeout = e.HTTPIdleTimeout.String()
+	}
 	return json.Marshal(source)
 }
+
+func (e *MeshConfigEntry) UnmarshalJSON(data []byte) error {
+	type Alias MeshConfigEntry
+	aux := &struct {
+		ConnectTimeout  string
+		HTTPIdleTimeout string
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var merr *multierror.Error
+	if aux.ConnectTimeout != "" {
+		d, err := time.ParseDuration(aux.ConnectTimeout)
+		if err != nil {
+			merr = multierror.Append(merr, err)
+		} else {
+			e.ConnectTimeout = &d
+		}
+	}
+	if aux.HTTPIdleTimeout != "" {
+		d, err := time.ParseDuration(aux.HTTPIdleTimeout)
+		if err != nil {
+			merr = multierror.Append(merr, err)
+		} else {
+			e.HTTPIdleTimeout = &d
+		}
+	}
+	return merr.ErrorOrNil()
+}
@@ -404,6 +404,27 @@ func (in *LBPolicyMaglev) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using LBPolicyClusterProvided within kubernetes types, where deepcopy-gen is used.
+func (in *LBPolicyClusterProvided) DeepCopyInto(out *LBPolicyClusterProvided) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBPolicyClusterProvided. Required by controller-gen.
+func (in *LBPolicyClusterProvided) DeepCopy() *LBPolicyClusterProvided {
+	if in == nil {
+		return nil
+	}
+	out := new(LBPolicyClusterProvided)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new LBPolicyClusterProvided. Required by controller-gen.
+func (in *LBPolicyClusterProvided) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using CircuitBreakers within kubernetes types, where deepcopy-gen is used.
 func (in *CircuitBreakers) DeepCopyInto(out *CircuitBreakers) {
 	proto.Reset(out)
@@ -467,6 +488,90 @@ func (in *OutlierDetection) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using HealthCheck within kubernetes types, where deepcopy-gen is used.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck. Required by controller-gen.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck. Required by controller-gen.
+func (in *HealthCheck) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HTTPHealthCheck within kubernetes types, where deepcopy-gen is used.
+func (in *HTTPHealthCheck) DeepCopyInto(out *HTTPHealthCheck) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHealthCheck. Required by controller-gen.
+func (in *HTTPHealthCheck) DeepCopy() *HTTPHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHealthCheck. Required by controller-gen.
+func (in *HTTPHealthCheck) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TCPHealthCheck within kubernetes types, where deepcopy-gen is used.
+func (in *TCPHealthCheck) DeepCopyInto(out *TCPHealthCheck) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPHealthCheck. Required by controller-gen.
+func (in *TCPHealthCheck) DeepCopy() *TCPHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TCPHealthCheck. Required by controller-gen.
+func (in *TCPHealthCheck) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using GRPCHealthCheck within kubernetes types, where deepcopy-gen is used.
+func (in *GRPCHealthCheck) DeepCopyInto(out *GRPCHealthCheck) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCHealthCheck. Required by controller-gen.
+func (in *GRPCHealthCheck) DeepCopy() *GRPCHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new GRPCHealthCheck. Required by controller-gen.
+func (in *GRPCHealthCheck) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using UpstreamConnectionOptions within kubernetes types, where deepcopy-gen is used.
 func (in *UpstreamConnectionOptions) DeepCopyInto(out *UpstreamConnectionOptions) {
 	proto.Reset(out)
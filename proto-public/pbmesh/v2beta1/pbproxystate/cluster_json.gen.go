@@ -214,6 +214,17 @@ func (this *LBPolicyMaglev) UnmarshalJSON(b []byte) error {
 	return ClusterUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for LBPolicyClusterProvided
+func (this *LBPolicyClusterProvided) MarshalJSON() ([]byte, error) {
+	str, err := ClusterMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for LBPolicyClusterProvided
+func (this *LBPolicyClusterProvided) UnmarshalJSON(b []byte) error {
+	return ClusterUnmarshaler.Unmarshal(b, this)
+}
+
 // MarshalJSON is a custom marshaler for CircuitBreakers
 func (this *CircuitBreakers) MarshalJSON() ([]byte, error) {
 	str, err := ClusterMarshaler.Marshal(this)
@@ -247,6 +258,50 @@ func (this *OutlierDetection) UnmarshalJSON(b []byte) error {
 	return ClusterUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for HealthCheck
+func (this *HealthCheck) MarshalJSON() ([]byte, error) {
+	str, err := ClusterMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HealthCheck
+func (this *HealthCheck) UnmarshalJSON(b []byte) error {
+	return ClusterUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for HTTPHealthCheck
+func (this *HTTPHealthCheck) MarshalJSON() ([]byte, error) {
+	str, err := ClusterMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HTTPHealthCheck
+func (this *HTTPHealthCheck) UnmarshalJSON(b []byte) error {
+	return ClusterUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for TCPHealthCheck
+func (this *TCPHealthCheck) MarshalJSON() ([]byte, error) {
+	str, err := ClusterMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TCPHealthCheck
+func (this *TCPHealthCheck) UnmarshalJSON(b []byte) error {
+	return ClusterUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for GRPCHealthCheck
+func (this *GRPCHealthCheck) MarshalJSON() ([]byte, error) {
+	str, err := ClusterMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for GRPCHealthCheck
+func (this *GRPCHealthCheck) UnmarshalJSON(b []byte) error {
+	return ClusterUnmarshaler.Unmarshal(b, this)
+}
+
 // MarshalJSON is a custom marshaler for UpstreamConnectionOptions
 func (this *UpstreamConnectionOptions) MarshalJSON() ([]byte, error) {
 	str, err := ClusterMarshaler.Marshal(this)
@@ -26,3 +26,13 @@ func (msg *Endpoint) MarshalBinary() ([]byte, error) {
 func (msg *Endpoint) UnmarshalBinary(b []byte) error {
 	return proto.Unmarshal(b, msg)
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (msg *Locality) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(msg)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (msg *Locality) UnmarshalBinary(b []byte) error {
+	return proto.Unmarshal(b, msg)
+}
@@ -46,3 +46,24 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 func (in *Endpoint) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
+
+// DeepCopyInto supports using Locality within kubernetes types, where deepcopy-gen is used.
+func (in *Locality) DeepCopyInto(out *Locality) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Locality. Required by controller-gen.
+func (in *Locality) DeepCopy() *Locality {
+	if in == nil {
+		return nil
+	}
+	out := new(Locality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Locality. Required by controller-gen.
+func (in *Locality) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
@@ -27,6 +27,17 @@ func (this *Endpoint) UnmarshalJSON(b []byte) error {
 	return EndpointsUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for Locality
+func (this *Locality) MarshalJSON() ([]byte, error) {
+	str, err := EndpointsMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Locality
+func (this *Locality) UnmarshalJSON(b []byte) error {
+	return EndpointsUnmarshaler.Unmarshal(b, this)
+}
+
 var (
 	EndpointsMarshaler   = &protojson.MarshalOptions{}
 	EndpointsUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
@@ -136,6 +136,28 @@ func APIGatewayTLSConfigurationFromStructs(t *structs.APIGatewayTLSConfiguration
 	s.MinVersion = tlsVersionFromStructs(t.MinVersion)
 	s.CipherSuites = cipherSuitesFromStructs(t.CipherSuites)
 }
+func ActiveHealthCheckToStructs(s *ActiveHealthCheck, t *structs.ActiveHealthCheck) {
+	if s == nil {
+		return
+	}
+	t.Type = s.Type
+	t.Interval = structs.DurationFromProto(s.Interval)
+	t.Timeout = structs.DurationFromProto(s.Timeout)
+	t.UnhealthyThreshold = s.UnhealthyThreshold
+	t.HealthyThreshold = s.HealthyThreshold
+	t.Path = s.Path
+}
+func ActiveHealthCheckFromStructs(t *structs.ActiveHealthCheck, s *ActiveHealthCheck) {
+	if s == nil {
+		return
+	}
+	s.Type = t.Type
+	s.Interval = structs.DurationToProto(t.Interval)
+	s.Timeout = structs.DurationToProto(t.Timeout)
+	s.UnhealthyThreshold = t.UnhealthyThreshold
+	s.HealthyThreshold = t.HealthyThreshold
+	s.Path = t.Path
+}
 func BoundAPIGatewayToStructs(s *BoundAPIGateway, t *structs.BoundAPIGatewayConfigEntry) {
 	if s == nil {
 		return
@@ -218,6 +240,20 @@ func BoundAPIGatewayListenerFromStructs(t *structs.BoundAPIGatewayListener, s *B
 		}
 	}
 }
+func ClusterStatsConfigToStructs(s *ClusterStatsConfig, t *structs.ClusterStatsConfig) {
+	if s == nil {
+		return
+	}
+	t.RequestResponseSizes = s.RequestResponseSizes
+	t.TimeoutBudgets = s.TimeoutBudgets
+}
+func ClusterStatsConfigFromStructs(t *structs.ClusterStatsConfig, s *ClusterStatsConfig) {
+	if s == nil {
+		return
+	}
+	s.RequestResponseSizes = t.RequestResponseSizes
+	s.TimeoutBudgets = t.TimeoutBudgets
+}
 func ConditionToStructs(s *Condition, t *structs.Condition) {
 	if s == nil {
 		return
@@ -426,6 +462,34 @@ func FileSystemCertificateFromStructs(t *structs.FileSystemCertificateConfigEntr
 	s.Meta = t.Meta
 	s.Hash = t.Hash
 }
+func GRPCHealthCheckConfigToStructs(s *GRPCHealthCheckConfig, t *structs.GRPCHealthCheckConfig) {
+	if s == nil {
+		return
+	}
+	t.ServiceName = s.ServiceName
+	t.AuthorityHeader = s.AuthorityHeader
+}
+func GRPCHealthCheckConfigFromStructs(t *structs.GRPCHealthCheckConfig, s *GRPCHealthCheckConfig) {
+	if s == nil {
+		return
+	}
+	s.ServiceName = t.ServiceName
+	s.AuthorityHeader = t.AuthorityHeader
+}
+func GRPCTranscodingConfigToStructs(s *GRPCTranscodingConfig, t *structs.GRPCTranscodingConfig) {
+	if s == nil {
+		return
+	}
+	t.ProtoDescriptor = s.ProtoDescriptor
+	t.Services = s.Services
+}
+func GRPCTranscodingConfigFromStructs(t *structs.GRPCTranscodingConfig, s *GRPCTranscodingConfig) {
+	if s == nil {
+		return
+	}
+	s.ProtoDescriptor = t.ProtoDescriptor
+	s.Services = t.Services
+}
 func GatewayServiceTLSConfigToStructs(s *GatewayServiceTLSConfig, t *structs.GatewayServiceTLSConfig) {
 	if s == nil {
 		return
@@ -1353,6 +1417,17 @@ func JWKSClusterToStructs(s *JWKSCluster, t *structs.JWKSCluster) {
 		t.TLSCertificates = &x
 	}
 	t.ConnectTimeout = structs.DurationFromProto(s.ConnectTimeout)
+	t.SNI = s.SNI
+	{
+		t.SocketOptions = make([]structs.SocketOption, len(s.SocketOptions))
+		for i := range s.SocketOptions {
+			if s.SocketOptions[i] != nil {
+				SocketOptionToStructs(s.SocketOptions[i], &t.SocketOptions[i])
+			}
+		}
+	}
+	t.ClusterName = s.ClusterName
+	t.DNSRefreshRate = structs.DurationPointerFromProto(s.DNSRefreshRate)
 }
 func JWKSClusterFromStructs(t *structs.JWKSCluster, s *JWKSCluster) {
 	if s == nil {
@@ -1365,6 +1440,19 @@ func JWKSClusterFromStructs(t *structs.JWKSCluster, s *JWKSCluster) {
 		s.TLSCertificates = &x
 	}
 	s.ConnectTimeout = structs.DurationToProto(t.ConnectTimeout)
+	s.SNI = t.SNI
+	{
+		s.SocketOptions = make([]*SocketOption, len(t.SocketOptions))
+		for i := range t.SocketOptions {
+			{
+				var x SocketOption
+				SocketOptionFromStructs(&t.SocketOptions[i], &x)
+				s.SocketOptions[i] = &x
+			}
+		}
+	}
+	s.ClusterName = t.ClusterName
+	s.DNSRefreshRate = structs.DurationPointerToProto(t.DNSRefreshRate)
 }
 func JWKSRetryPolicyToStructs(s *JWKSRetryPolicy, t *structs.JWKSRetryPolicy) {
 	if s == nil {
@@ -1388,6 +1476,24 @@ func JWKSRetryPolicyFromStructs(t *structs.JWKSRetryPolicy, s *JWKSRetryPolicy)
 		s.RetryPolicyBackOff = &x
 	}
 }
+func JWKSTLSCertDataToStructs(s *JWKSTLSCertData, t *structs.JWKSTLSCertData) {
+	if s == nil {
+		return
+	}
+	t.Filename = s.Filename
+	t.EnvironmentVariable = s.EnvironmentVariable
+	t.InlineString = s.InlineString
+	t.InlineBytes = s.InlineBytes
+}
+func JWKSTLSCertDataFromStructs(t *structs.JWKSTLSCertData, s *JWKSTLSCertData) {
+	if s == nil {
+		return
+	}
+	s.Filename = t.Filename
+	s.EnvironmentVariable = t.EnvironmentVariable
+	s.InlineString = t.InlineString
+	s.InlineBytes = t.InlineBytes
+}
 func JWKSTLSCertProviderInstanceToStructs(s *JWKSTLSCertProviderInstance, t *structs.JWKSTLSCertProviderInstance) {
 	if s == nil {
 		return
@@ -1434,6 +1540,16 @@ func JWKSTLSCertificateToStructs(s *JWKSTLSCertificate, t *structs.JWKSTLSCertif
 		JWKSTLSCertTrustedCAToStructs(s.TrustedCA, &x)
 		t.TrustedCA = &x
 	}
+	if s.ClientCertificate != nil {
+		var x structs.JWKSTLSCertData
+		JWKSTLSCertDataToStructs(s.ClientCertificate, &x)
+		t.ClientCertificate = &x
+	}
+	if s.PrivateKey != nil {
+		var x structs.JWKSTLSCertData
+		JWKSTLSCertDataToStructs(s.PrivateKey, &x)
+		t.PrivateKey = &x
+	}
 }
 func JWKSTLSCertificateFromStructs(t *structs.JWKSTLSCertificate, s *JWKSTLSCertificate) {
 	if s == nil {
@@ -1449,6 +1565,16 @@ func JWKSTLSCertificateFromStructs(t *structs.JWKSTLSCertificate, s *JWKSTLSCert
 		JWKSTLSCertTrustedCAFromStructs(t.TrustedCA, &x)
 		s.TrustedCA = &x
 	}
+	if t.ClientCertificate != nil {
+		var x JWKSTLSCertData
+		JWKSTLSCertDataFromStructs(t.ClientCertificate, &x)
+		s.ClientCertificate = &x
+	}
+	if t.PrivateKey != nil {
+		var x JWKSTLSCertData
+		JWKSTLSCertDataFromStructs(t.PrivateKey, &x)
+		s.PrivateKey = &x
+	}
 }
 func JWTCacheConfigToStructs(s *JWTCacheConfig, t *structs.JWTCacheConfig) {
 	if s == nil {
@@ -1661,6 +1787,8 @@ func LoadBalancerToStructs(s *LoadBalancer, t *structs.LoadBalancer) {
 			}
 		}
 	}
+	t.CustomClusterType = s.CustomClusterType
+	t.CustomClusterConfigJSON = s.CustomClusterConfigJSON
 }
 func LoadBalancerFromStructs(t *structs.LoadBalancer, s *LoadBalancer) {
 	if s == nil {
@@ -1687,6 +1815,8 @@ func LoadBalancerFromStructs(t *structs.LoadBalancer, s *LoadBalancer) {
 			}
 		}
 	}
+	s.CustomClusterType = t.CustomClusterType
+	s.CustomClusterConfigJSON = t.CustomClusterConfigJSON
 }
 func LocalJWKSToStructs(s *LocalJWKS, t *structs.LocalJWKS) {
 	if s == nil {
@@ -1725,6 +1855,24 @@ func MeshConfigToStructs(s *MeshConfig, t *structs.MeshConfigEntry) {
 		PeeringMeshConfigToStructs(s.Peering, &x)
 		t.Peering = &x
 	}
+	if s.Telemetry != nil {
+		var x structs.MeshTelemetryConfig
+		MeshTelemetryConfigToStructs(s.Telemetry, &x)
+		t.Telemetry = &x
+	}
+	t.ConnectTimeout = structs.DurationPointerFromProto(s.ConnectTimeout)
+	t.PerConnectionBufferLimitBytes = pointerToUint32FromNonZeroUint32(s.PerConnectionBufferLimitBytes)
+	if s.TCPKeepalive != nil {
+		var x structs.TCPKeepaliveConfig
+		TCPKeepaliveConfigToStructs(s.TCPKeepalive, &x)
+		t.TCPKeepalive = &x
+	}
+	if s.DefaultRingHashConfig != nil {
+		var x structs.RingHashConfig
+		RingHashConfigToStructs(s.DefaultRingHashConfig, &x)
+		t.DefaultRingHashConfig = &x
+	}
+	t.HTTPIdleTimeout = structs.DurationPointerFromProto(s.HTTPIdleTimeout)
 	t.Meta = s.Meta
 	t.Hash = s.Hash
 }
@@ -1753,6 +1901,24 @@ func MeshConfigFromStructs(t *structs.MeshConfigEntry, s *MeshConfig) {
 		PeeringMeshConfigFromStructs(t.Peering, &x)
 		s.Peering = &x
 	}
+	if t.Telemetry != nil {
+		var x MeshTelemetryConfig
+		MeshTelemetryConfigFromStructs(t.Telemetry, &x)
+		s.Telemetry = &x
+	}
+	s.ConnectTimeout = structs.DurationPointerToProto(t.ConnectTimeout)
+	s.PerConnectionBufferLimitBytes = uint32FromPointerToUint32(t.PerConnectionBufferLimitBytes)
+	if t.TCPKeepalive != nil {
+		var x TCPKeepaliveConfig
+		TCPKeepaliveConfigFromStructs(t.TCPKeepalive, &x)
+		s.TCPKeepalive = &x
+	}
+	if t.DefaultRingHashConfig != nil {
+		var x RingHashConfig
+		RingHashConfigFromStructs(t.DefaultRingHashConfig, &x)
+		s.DefaultRingHashConfig = &x
+	}
+	s.HTTPIdleTimeout = structs.DurationPointerToProto(t.HTTPIdleTimeout)
 	s.Meta = t.Meta
 	s.Hash = t.Hash
 }
@@ -1763,6 +1929,7 @@ func MeshDirectionalTLSConfigToStructs(s *MeshDirectionalTLSConfig, t *structs.M
 	t.TLSMinVersion = tlsVersionToStructs(s.TLSMinVersion)
 	t.TLSMaxVersion = tlsVersionToStructs(s.TLSMaxVersion)
 	t.CipherSuites = cipherSuitesToStructs(s.CipherSuites)
+	t.ALPNProtocols = s.ALPNProtocols
 }
 func MeshDirectionalTLSConfigFromStructs(t *structs.MeshDirectionalTLSConfig, s *MeshDirectionalTLSConfig) {
 	if s == nil {
@@ -1771,6 +1938,7 @@ func MeshDirectionalTLSConfigFromStructs(t *structs.MeshDirectionalTLSConfig, s
 	s.TLSMinVersion = tlsVersionFromStructs(t.TLSMinVersion)
 	s.TLSMaxVersion = tlsVersionFromStructs(t.TLSMaxVersion)
 	s.CipherSuites = cipherSuitesFromStructs(t.CipherSuites)
+	s.ALPNProtocols = t.ALPNProtocols
 }
 func MeshGatewayConfigToStructs(s *MeshGatewayConfig, t *structs.MeshGatewayConfig) {
 	if s == nil {
@@ -1789,12 +1957,14 @@ func MeshHTTPConfigToStructs(s *MeshHTTPConfig, t *structs.MeshHTTPConfig) {
 		return
 	}
 	t.SanitizeXForwardedClientCert = s.SanitizeXForwardedClientCert
+	t.ResponseHeadersToRemove = s.ResponseHeadersToRemove
 }
 func MeshHTTPConfigFromStructs(t *structs.MeshHTTPConfig, s *MeshHTTPConfig) {
 	if s == nil {
 		return
 	}
 	s.SanitizeXForwardedClientCert = t.SanitizeXForwardedClientCert
+	s.ResponseHeadersToRemove = t.ResponseHeadersToRemove
 }
 func MeshTLSConfigToStructs(s *MeshTLSConfig, t *structs.MeshTLSConfig) {
 	if s == nil {
@@ -1826,6 +1996,26 @@ func MeshTLSConfigFromStructs(t *structs.MeshTLSConfig, s *MeshTLSConfig) {
 		s.Outgoing = &x
 	}
 }
+func MeshTelemetryConfigToStructs(s *MeshTelemetryConfig, t *structs.MeshTelemetryConfig) {
+	if s == nil {
+		return
+	}
+	if s.TrackClusterStats != nil {
+		var x structs.ClusterStatsConfig
+		ClusterStatsConfigToStructs(s.TrackClusterStats, &x)
+		t.TrackClusterStats = &x
+	}
+}
+func MeshTelemetryConfigFromStructs(t *structs.MeshTelemetryConfig, s *MeshTelemetryConfig) {
+	if s == nil {
+		return
+	}
+	if t.TrackClusterStats != nil {
+		var x ClusterStatsConfig
+		ClusterStatsConfigFromStructs(t.TrackClusterStats, &x)
+		s.TrackClusterStats = &x
+	}
+}
 func PassiveHealthCheckToStructs(s *PassiveHealthCheck, t *structs.PassiveHealthCheck) {
 	if s == nil {
 		return
@@ -1835,6 +2025,8 @@ func PassiveHealthCheckToStructs(s *PassiveHealthCheck, t *structs.PassiveHealth
 	t.EnforcingConsecutive5xx = pointerToUint32FromUint32(s.EnforcingConsecutive5Xx)
 	t.MaxEjectionPercent = pointerToUint32FromUint32(s.MaxEjectionPercent)
 	t.BaseEjectionTime = structs.DurationPointerFromProto(s.BaseEjectionTime)
+	t.SplitExternalLocalOriginErrors = s.SplitExternalLocalOriginErrors
+	t.ConsecutiveLocalOriginFailure = pointerToUint32FromUint32(s.ConsecutiveLocalOriginFailure)
 }
 func PassiveHealthCheckFromStructs(t *structs.PassiveHealthCheck, s *PassiveHealthCheck) {
 	if s == nil {
@@ -1845,6 +2037,8 @@ func PassiveHealthCheckFromStructs(t *structs.PassiveHealthCheck, s *PassiveHeal
 	s.EnforcingConsecutive5Xx = uint32FromPointerToUint32(t.EnforcingConsecutive5xx)
 	s.MaxEjectionPercent = uint32FromPointerToUint32(t.MaxEjectionPercent)
 	s.BaseEjectionTime = structs.DurationPointerToProto(t.BaseEjectionTime)
+	s.SplitExternalLocalOriginErrors = t.SplitExternalLocalOriginErrors
+	s.ConsecutiveLocalOriginFailure = uint32FromPointerToUint32(t.ConsecutiveLocalOriginFailure)
 }
 func PeeringMeshConfigToStructs(s *PeeringMeshConfig, t *structs.PeeringMeshConfig) {
 	if s == nil {
@@ -1970,6 +2164,7 @@ func RingHashConfigToStructs(s *RingHashConfig, t *structs.RingHashConfig) {
 	}
 	t.MinimumRingSize = s.MinimumRingSize
 	t.MaximumRingSize = s.MaximumRingSize
+	t.HeaderName = s.HeaderName
 }
 func RingHashConfigFromStructs(t *structs.RingHashConfig, s *RingHashConfig) {
 	if s == nil {
@@ -1977,6 +2172,7 @@ func RingHashConfigFromStructs(t *structs.RingHashConfig, s *RingHashConfig) {
 	}
 	s.MinimumRingSize = t.MinimumRingSize
 	s.MaximumRingSize = t.MaximumRingSize
+	s.HeaderName = t.HeaderName
 }
 func SamenessGroupToStructs(s *SamenessGroup, t *structs.SamenessGroupConfigEntry) {
 	if s == nil {
@@ -2068,6 +2264,11 @@ func ServiceDefaultsToStructs(s *ServiceDefaults, t *structs.ServiceConfigEntry)
 		RateLimitsToStructs(s.RateLimits, &x)
 		t.RateLimits = &x
 	}
+	if s.GRPCTranscoding != nil {
+		var x structs.GRPCTranscodingConfig
+		GRPCTranscodingConfigToStructs(s.GRPCTranscoding, &x)
+		t.GRPCTranscoding = &x
+	}
 	t.EnvoyExtensions = EnvoyExtensionsToStructs(s.EnvoyExtensions)
 	t.Meta = s.Meta
 	t.Hash = s.Hash
@@ -2114,6 +2315,11 @@ func ServiceDefaultsFromStructs(t *structs.ServiceConfigEntry, s *ServiceDefault
 		RateLimitsFromStructs(t.RateLimits, &x)
 		s.RateLimits = &x
 	}
+	if t.GRPCTranscoding != nil {
+		var x GRPCTranscodingConfig
+		GRPCTranscodingConfigFromStructs(t.GRPCTranscoding, &x)
+		s.GRPCTranscoding = &x
+	}
 	s.EnvoyExtensions = EnvoyExtensionsFromStructs(t.EnvoyExtensions)
 	s.Meta = t.Meta
 	s.Hash = t.Hash
@@ -2204,6 +2410,20 @@ func ServiceResolverToStructs(s *ServiceResolver, t *structs.ServiceResolverConf
 		LoadBalancerToStructs(s.LoadBalancer, &x)
 		t.LoadBalancer = &x
 	}
+	t.CustomSANs = s.CustomSANs
+	t.ClusterFilterChainMetadata = s.ClusterFilterChainMetadata
+	t.PerConnectionBufferLimitBytes = s.PerConnectionBufferLimitBytes
+	if s.GRPCHealthCheck != nil {
+		var x structs.GRPCHealthCheckConfig
+		GRPCHealthCheckConfigToStructs(s.GRPCHealthCheck, &x)
+		t.GRPCHealthCheck = &x
+	}
+	if s.PassiveHealthCheck != nil {
+		var x structs.PassiveHealthCheck
+		PassiveHealthCheckToStructs(s.PassiveHealthCheck, &x)
+		t.PassiveHealthCheck = &x
+	}
+	t.EmptySubsetAction = s.EmptySubsetAction
 	t.Meta = s.Meta
 	t.Hash = s.Hash
 }
@@ -2253,6 +2473,20 @@ func ServiceResolverFromStructs(t *structs.ServiceResolverConfigEntry, s *Servic
 		LoadBalancerFromStructs(t.LoadBalancer, &x)
 		s.LoadBalancer = &x
 	}
+	s.CustomSANs = t.CustomSANs
+	s.ClusterFilterChainMetadata = t.ClusterFilterChainMetadata
+	s.PerConnectionBufferLimitBytes = t.PerConnectionBufferLimitBytes
+	if t.GRPCHealthCheck != nil {
+		var x GRPCHealthCheckConfig
+		GRPCHealthCheckConfigFromStructs(t.GRPCHealthCheck, &x)
+		s.GRPCHealthCheck = &x
+	}
+	if t.PassiveHealthCheck != nil {
+		var x PassiveHealthCheck
+		PassiveHealthCheckFromStructs(t.PassiveHealthCheck, &x)
+		s.PassiveHealthCheck = &x
+	}
+	s.EmptySubsetAction = t.EmptySubsetAction
 	s.Meta = t.Meta
 	s.Hash = t.Hash
 }
@@ -2278,6 +2512,7 @@ func ServiceResolverFailoverToStructs(s *ServiceResolverFailover, t *structs.Ser
 		t.Policy = &x
 	}
 	t.SamenessGroup = s.SamenessGroup
+	t.OverprovisioningFactor = s.OverprovisioningFactor
 }
 func ServiceResolverFailoverFromStructs(t *structs.ServiceResolverFailover, s *ServiceResolverFailover) {
 	if s == nil {
@@ -2303,6 +2538,7 @@ func ServiceResolverFailoverFromStructs(t *structs.ServiceResolverFailover, s *S
 		s.Policy = &x
 	}
 	s.SamenessGroup = t.SamenessGroup
+	s.OverprovisioningFactor = t.OverprovisioningFactor
 }
 func ServiceResolverFailoverPolicyToStructs(s *ServiceResolverFailoverPolicy, t *structs.ServiceResolverFailoverPolicy) {
 	if s == nil {
@@ -2390,6 +2626,26 @@ func ServiceResolverSubsetFromStructs(t *structs.ServiceResolverSubset, s *Servi
 	s.Filter = t.Filter
 	s.OnlyPassing = t.OnlyPassing
 }
+func SocketOptionToStructs(s *SocketOption, t *structs.SocketOption) {
+	if s == nil {
+		return
+	}
+	t.Description = s.Description
+	t.Level = s.Level
+	t.Name = s.Name
+	t.IntValue = s.IntValue
+	t.BufferValue = s.BufferValue
+}
+func SocketOptionFromStructs(t *structs.SocketOption, s *SocketOption) {
+	if s == nil {
+		return
+	}
+	s.Description = t.Description
+	s.Level = t.Level
+	s.Name = t.Name
+	s.IntValue = t.IntValue
+	s.BufferValue = t.BufferValue
+}
 func SourceIntentionToStructs(s *SourceIntention, t *structs.SourceIntention) {
 	if s == nil {
 		return
@@ -2472,6 +2728,22 @@ func StatusFromStructs(t *structs.Status, s *Status) {
 		}
 	}
 }
+func TCPKeepaliveConfigToStructs(s *TCPKeepaliveConfig, t *structs.TCPKeepaliveConfig) {
+	if s == nil {
+		return
+	}
+	t.Time = s.Time
+	t.Interval = s.Interval
+	t.Probes = s.Probes
+}
+func TCPKeepaliveConfigFromStructs(t *structs.TCPKeepaliveConfig, s *TCPKeepaliveConfig) {
+	if s == nil {
+		return
+	}
+	s.Time = t.Time
+	s.Interval = t.Interval
+	s.Probes = t.Probes
+}
 func TCPRouteToStructs(s *TCPRoute, t *structs.TCPRouteConfigEntry) {
 	if s == nil {
 		return
@@ -2617,10 +2889,53 @@ func UpstreamConfigToStructs(s *UpstreamConfig, t *structs.UpstreamConfig) {
 		PassiveHealthCheckToStructs(s.PassiveHealthCheck, &x)
 		t.PassiveHealthCheck = &x
 	}
+	if s.ActiveHealthCheck != nil {
+		var x structs.ActiveHealthCheck
+		ActiveHealthCheckToStructs(s.ActiveHealthCheck, &x)
+		t.ActiveHealthCheck = &x
+	}
 	if s.MeshGateway != nil {
 		MeshGatewayConfigToStructs(s.MeshGateway, &t.MeshGateway)
 	}
 	t.BalanceOutboundConnections = s.BalanceOutboundConnections
+	t.MaxHeaderCount = int(s.MaxHeaderCount)
+	t.ClusterWarmUpDurationMs = int(s.ClusterWarmUpDurationMs)
+	t.WarmupTimeout = structs.DurationPointerFromProto(s.WarmupTimeout)
+	t.WaitForWarmOnInit = s.WaitForWarmOnInit
+	t.HTTP2InitialStreamWindowSize = int(s.HTTP2InitialStreamWindowSize)
+	t.HTTP2InitialConnectionWindowSize = int(s.HTTP2InitialConnectionWindowSize)
+	t.HTTP2OverrideStreamError = s.HTTP2OverrideStreamError
+	t.HTTPVersionAuto = s.HTTPVersionAuto
+	t.MaxStreamDurationMs = int(s.MaxStreamDurationMs)
+	t.IdleTimeout = structs.DurationPointerFromProto(s.IdleTimeout)
+	t.MaxRequestsPerConnection = pointerToUint32FromNonZeroUint32(s.MaxRequestsPerConnection)
+	t.LocalAddress = s.LocalAddress
+	t.LocalPort = int(s.LocalPort)
+	{
+		t.ExtraSourceAddresses = make([]structs.UpstreamSourceAddress, len(s.ExtraSourceAddresses))
+		for i := range s.ExtraSourceAddresses {
+			if s.ExtraSourceAddresses[i] != nil {
+				UpstreamSourceAddressToStructs(s.ExtraSourceAddresses[i], &t.ExtraSourceAddresses[i])
+			}
+		}
+	}
+	t.TCPFastOpen = s.TCPFastOpen
+	t.HeaderKeyFormat = s.HeaderKeyFormat
+	if s.TCPKeepalive != nil {
+		var x structs.TCPKeepaliveConfig
+		TCPKeepaliveConfigToStructs(s.TCPKeepalive, &x)
+		t.TCPKeepalive = &x
+	}
+	t.PerDownstreamConnectionPool = s.PerDownstreamConnectionPool
+	t.ClusterStatsPrefix = s.ClusterStatsPrefix
+	t.ALPNProtocols = s.ALPNProtocols
+	t.SNI = s.SNI
+	t.ClusterMetadata = s.ClusterMetadata
+	if s.RetryPolicy != nil {
+		var x structs.UpstreamRetryPolicy
+		UpstreamRetryPolicyToStructs(s.RetryPolicy, &x)
+		t.RetryPolicy = &x
+	}
 }
 func UpstreamConfigFromStructs(t *structs.UpstreamConfig, s *UpstreamConfig) {
 	if s == nil {
@@ -2643,12 +2958,57 @@ func UpstreamConfigFromStructs(t *structs.UpstreamConfig, s *UpstreamConfig) {
 		PassiveHealthCheckFromStructs(t.PassiveHealthCheck, &x)
 		s.PassiveHealthCheck = &x
 	}
+	if t.ActiveHealthCheck != nil {
+		var x ActiveHealthCheck
+		ActiveHealthCheckFromStructs(t.ActiveHealthCheck, &x)
+		s.ActiveHealthCheck = &x
+	}
 	{
 		var x MeshGatewayConfig
 		MeshGatewayConfigFromStructs(&t.MeshGateway, &x)
 		s.MeshGateway = &x
 	}
 	s.BalanceOutboundConnections = t.BalanceOutboundConnections
+	s.MaxHeaderCount = int32(t.MaxHeaderCount)
+	s.ClusterWarmUpDurationMs = int32(t.ClusterWarmUpDurationMs)
+	s.WarmupTimeout = structs.DurationPointerToProto(t.WarmupTimeout)
+	s.WaitForWarmOnInit = t.WaitForWarmOnInit
+	s.HTTP2InitialStreamWindowSize = int32(t.HTTP2InitialStreamWindowSize)
+	s.HTTP2InitialConnectionWindowSize = int32(t.HTTP2InitialConnectionWindowSize)
+	s.HTTP2OverrideStreamError = t.HTTP2OverrideStreamError
+	s.HTTPVersionAuto = t.HTTPVersionAuto
+	s.MaxStreamDurationMs = int32(t.MaxStreamDurationMs)
+	s.IdleTimeout = structs.DurationPointerToProto(t.IdleTimeout)
+	s.MaxRequestsPerConnection = uint32FromPointerToUint32(t.MaxRequestsPerConnection)
+	s.LocalAddress = t.LocalAddress
+	s.LocalPort = int32(t.LocalPort)
+	{
+		s.ExtraSourceAddresses = make([]*UpstreamSourceAddress, len(t.ExtraSourceAddresses))
+		for i := range t.ExtraSourceAddresses {
+			{
+				var x UpstreamSourceAddress
+				UpstreamSourceAddressFromStructs(&t.ExtraSourceAddresses[i], &x)
+				s.ExtraSourceAddresses[i] = &x
+			}
+		}
+	}
+	s.TCPFastOpen = t.TCPFastOpen
+	s.HeaderKeyFormat = t.HeaderKeyFormat
+	if t.TCPKeepalive != nil {
+		var x TCPKeepaliveConfig
+		TCPKeepaliveConfigFromStructs(t.TCPKeepalive, &x)
+		s.TCPKeepalive = &x
+	}
+	s.PerDownstreamConnectionPool = t.PerDownstreamConnectionPool
+	s.ClusterStatsPrefix = t.ClusterStatsPrefix
+	s.ALPNProtocols = t.ALPNProtocols
+	s.SNI = t.SNI
+	s.ClusterMetadata = t.ClusterMetadata
+	if t.RetryPolicy != nil {
+		var x UpstreamRetryPolicy
+		UpstreamRetryPolicyFromStructs(t.RetryPolicy, &x)
+		s.RetryPolicy = &x
+	}
 }
 func UpstreamConfigurationToStructs(s *UpstreamConfiguration, t *structs.UpstreamConfiguration) {
 	if s == nil {
@@ -2697,6 +3057,7 @@ func UpstreamLimitsToStructs(s *UpstreamLimits, t *structs.UpstreamLimits) {
 	t.MaxConnections = pointerToIntFromInt32(s.MaxConnections)
 	t.MaxPendingRequests = pointerToIntFromInt32(s.MaxPendingRequests)
 	t.MaxConcurrentRequests = pointerToIntFromInt32(s.MaxConcurrentRequests)
+	t.MaxRetries = pointerToIntFromInt32(s.MaxRetries)
 }
 func UpstreamLimitsFromStructs(t *structs.UpstreamLimits, s *UpstreamLimits) {
 	if s == nil {
@@ -2705,4 +3066,37 @@ func UpstreamLimitsFromStructs(t *structs.UpstreamLimits, s *UpstreamLimits) {
 	s.MaxConnections = int32FromPointerToInt(t.MaxConnections)
 	s.MaxPendingRequests = int32FromPointerToInt(t.MaxPendingRequests)
 	s.MaxConcurrentRequests = int32FromPointerToInt(t.MaxConcurrentRequests)
+	s.MaxRetries = int32FromPointerToInt(t.MaxRetries)
+}
+func UpstreamRetryPolicyToStructs(s *UpstreamRetryPolicy, t *structs.UpstreamRetryPolicy) {
+	if s == nil {
+		return
+	}
+	t.NumRetries = s.NumRetries
+	t.RetryOn = s.RetryOn
+	t.RetriableStatusCodes = s.RetriableStatusCodes
+	t.PerTryTimeout = structs.DurationPointerFromProto(s.PerTryTimeout)
+}
+func UpstreamRetryPolicyFromStructs(t *structs.UpstreamRetryPolicy, s *UpstreamRetryPolicy) {
+	if s == nil {
+		return
+	}
+	s.NumRetries = t.NumRetries
+	s.RetryOn = t.RetryOn
+	s.RetriableStatusCodes = t.RetriableStatusCodes
+	s.PerTryTimeout = structs.DurationPointerToProto(t.PerTryTimeout)
+}
+func UpstreamSourceAddressToStructs(s *UpstreamSourceAddress, t *structs.UpstreamSourceAddress) {
+	if s == nil {
+		return
+	}
+	t.Address = s.Address
+	t.Port = int(s.Port)
+}
+func UpstreamSourceAddressFromStructs(t *structs.UpstreamSourceAddress, s *UpstreamSourceAddress) {
+	if s == nil {
+		return
+	}
+	s.Address = t.Address
+	s.Port = int32(t.Port)
 }
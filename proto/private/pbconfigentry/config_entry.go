@@ -355,6 +355,15 @@ func pointerToUint32FromUint32(ui32 uint32) *uint32 {
 	return &i
 }
 
+// pointerToUint32FromNonZeroUint32 is for optional fields where zero is not a
+// valid setting, so an unset proto field stays unset.
+func pointerToUint32FromNonZeroUint32(ui32 uint32) *uint32 {
+	if ui32 == 0 {
+		return nil
+	}
+	return &ui32
+}
+
 func uint32FromPointerToUint32(i *uint32) uint32 {
 	if i != nil {
 		return *i
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pbconfigentry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestConfigEntry_RoundTrip(t *testing.T) {
+	cases := map[string]structs.ConfigEntry{
+		"mesh telemetry": &structs.MeshConfigEntry{
+			Telemetry: &structs.MeshTelemetryConfig{
+				TrackClusterStats: &structs.ClusterStatsConfig{
+					RequestResponseSizes: true,
+					TimeoutBudgets:       true,
+				},
+			},
+		},
+		"mesh connect timeout": &structs.MeshConfigEntry{
+			ConnectTimeout: durationPointer(7 * time.Second),
+		},
+		"mesh per connection buffer limit": &structs.MeshConfigEntry{
+			PerConnectionBufferLimitBytes: uint32Pointer(32768),
+		},
+		"mesh tcp keepalive": &structs.MeshConfigEntry{
+			TCPKeepalive: &structs.TCPKeepaliveConfig{
+				Time:     60,
+				Interval: 10,
+				Probes:   5,
+			},
+		},
+		"mesh default ring hash config": &structs.MeshConfigEntry{
+			DefaultRingHashConfig: &structs.RingHashConfig{
+				MinimumRingSize: 1024,
+				MaximumRingSize: 8192,
+			},
+		},
+		"mesh http idle timeout": &structs.MeshConfigEntry{
+			HTTPIdleTimeout: durationPointer(5 * time.Minute),
+		},
+		"jwt provider jwks cluster sni": &structs.JWTProviderConfigEntry{
+			Name: "okta",
+			JSONWebKeySet: &structs.JSONWebKeySet{
+				Remote: &structs.RemoteJWKS{
+					URI: "https://example.okta.com/.well-known/jwks.json",
+					JWKSCluster: &structs.JWKSCluster{
+						DiscoveryType:  structs.DiscoveryTypeStrictDNS,
+						ConnectTimeout: 5 * time.Second,
+						SNI:            "jwks.example.com",
+					},
+				},
+			},
+		},
+		"jwt provider jwks cluster socket options": &structs.JWTProviderConfigEntry{
+			Name: "okta",
+			JSONWebKeySet: &structs.JSONWebKeySet{
+				Remote: &structs.RemoteJWKS{
+					URI: "https://example.okta.com/.well-known/jwks.json",
+					JWKSCluster: &structs.JWKSCluster{
+						DiscoveryType:  structs.DiscoveryTypeStrictDNS,
+						ConnectTimeout: 5 * time.Second,
+						SocketOptions: []structs.SocketOption{
+							{Description: "dscp", Level: 0, Name: 1, IntValue: 184},
+							{Level: 6, Name: 13, BufferValue: []byte("bbr")},
+						},
+					},
+				},
+			},
+		},
+		"jwt provider jwks cluster dns refresh rate": &structs.JWTProviderConfigEntry{
+			Name: "okta",
+			JSONWebKeySet: &structs.JSONWebKeySet{
+				Remote: &structs.RemoteJWKS{
+					URI: "https://example.okta.com/.well-known/jwks.json",
+					JWKSCluster: &structs.JWKSCluster{
+						DiscoveryType:  structs.DiscoveryTypeLogicalDNS,
+						ConnectTimeout: 5 * time.Second,
+						DNSRefreshRate: durationPointer(30 * time.Second),
+					},
+				},
+			},
+		},
+		"jwt provider jwks cluster name": &structs.JWTProviderConfigEntry{
+			Name: "okta",
+			JSONWebKeySet: &structs.JSONWebKeySet{
+				Remote: &structs.RemoteJWKS{
+					URI: "https://example.okta.com/.well-known/jwks.json",
+					JWKSCluster: &structs.JWKSCluster{
+						DiscoveryType:  structs.DiscoveryTypeStrictDNS,
+						ConnectTimeout: 5 * time.Second,
+						ClusterName:    "okta-jwks",
+					},
+				},
+			},
+		},
+		"jwt provider jwks client certificate": &structs.JWTProviderConfigEntry{
+			Name: "okta",
+			JSONWebKeySet: &structs.JSONWebKeySet{
+				Remote: &structs.RemoteJWKS{
+					URI: "https://example.okta.com/.well-known/jwks.json",
+					JWKSCluster: &structs.JWKSCluster{
+						DiscoveryType:  structs.DiscoveryTypeStrictDNS,
+						ConnectTimeout: 5 * time.Second,
+						TLSCertificates: &structs.JWKSTLSCertificate{
+							TrustedCA: &structs.JWKSTLSCertTrustedCA{
+								Filename: "/etc/jwks/ca.pem",
+							},
+							ClientCertificate: &structs.JWKSTLSCertData{
+								Filename: "/etc/jwks/client.pem",
+							},
+							PrivateKey: &structs.JWKSTLSCertData{
+								InlineBytes: []byte("key"),
+							},
+						},
+					},
+				},
+			},
+		},
+		"service defaults upstream config": &structs.ServiceConfigEntry{
+			Name:     "web",
+			Protocol: "http",
+			UpstreamConfig: &structs.UpstreamConfiguration{
+				Defaults: &structs.UpstreamConfig{
+					ActiveHealthCheck: &structs.ActiveHealthCheck{
+						Type:               structs.ActiveHealthCheckTypeHTTP,
+						Interval:           10 * time.Second,
+						Timeout:            5 * time.Second,
+						UnhealthyThreshold: 3,
+						HealthyThreshold:   2,
+						Path:               "/health",
+					},
+					MaxHeaderCount:                   200,
+					ClusterWarmUpDurationMs:          30000,
+					WarmupTimeout:                    durationPointer(15 * time.Second),
+					WaitForWarmOnInit:                boolPointer(true),
+					HTTP2InitialStreamWindowSize:     65536,
+					HTTP2InitialConnectionWindowSize: 1048576,
+					HTTP2OverrideStreamError:         true,
+					HTTPVersionAuto:                  true,
+					MaxStreamDurationMs:              60000,
+					IdleTimeout:                      durationPointer(2 * time.Minute),
+					MaxRequestsPerConnection:         uint32Pointer(100),
+					LocalAddress:                     "10.0.0.5",
+					LocalPort:                        9000,
+					ExtraSourceAddresses: []structs.UpstreamSourceAddress{
+						{Address: "fd00::5"},
+						{Address: "10.0.0.6", Port: 9001},
+					},
+					TCPFastOpen:     true,
+					HeaderKeyFormat: "proper_case_words",
+					TCPKeepalive: &structs.TCPKeepaliveConfig{
+						Time:     60,
+						Interval: 10,
+						Probes:   5,
+					},
+					PerDownstreamConnectionPool: true,
+					ClusterStatsPrefix:          "web_upstreams",
+					ALPNProtocols:               []string{"h2", "http/1.1"},
+					SNI:                         "web.example.com",
+					ClusterMetadata:             map[string]string{"team": "payments"},
+					RetryPolicy: &structs.UpstreamRetryPolicy{
+						NumRetries:           3,
+						RetryOn:              "5xx,reset",
+						RetriableStatusCodes: []uint32{503, 504},
+						PerTryTimeout:        durationPointer(2 * time.Second),
+					},
+				},
+				Overrides: []*structs.UpstreamConfig{
+					{
+						Name:           "db",
+						MaxHeaderCount: 50,
+						SNI:            "db.example.com",
+					},
+				},
+			},
+		},
+		"service defaults upstream config zero values": &structs.ServiceConfigEntry{
+			Name: "web",
+			UpstreamConfig: &structs.UpstreamConfiguration{
+				Defaults: &structs.UpstreamConfig{
+					WarmupTimeout:     durationPointer(0),
+					WaitForWarmOnInit: boolPointer(false),
+					IdleTimeout:       durationPointer(0),
+				},
+			},
+		},
+		"service resolver empty subset action": &structs.ServiceResolverConfigEntry{
+			Name: "web",
+			Subsets: map[string]structs.ServiceResolverSubset{
+				"v2": {Filter: "Service.Meta.version == v2"},
+			},
+			EmptySubsetAction: structs.EmptySubsetActionFallbackToDefault,
+		},
+		"service resolver ring hash header name": &structs.ServiceResolverConfigEntry{
+			Name: "web",
+			LoadBalancer: &structs.LoadBalancer{
+				Policy: structs.LBPolicyRingHash,
+				RingHashConfig: &structs.RingHashConfig{
+					HeaderName: "x-user-id",
+				},
+			},
+		},
+		"service resolver custom cluster config": &structs.ServiceResolverConfigEntry{
+			Name: "redis",
+			LoadBalancer: &structs.LoadBalancer{
+				Policy:                  structs.LBPolicyClusterProvided,
+				CustomClusterType:       "envoy.clusters.redis",
+				CustomClusterConfigJSON: `{"@type": "type.googleapis.com/envoy.extensions.clusters.redis.v3.RedisClusterConfig"}`,
+			},
+		},
+	}
+
+	for name, entry := range cases {
+		t.Run(name, func(t *testing.T) {
+			pb := ConfigEntryFromStructs(entry)
+
+			// Go through the wire format so fields missing from the proto
+			// definition are caught rather than copied by reference.
+			raw, err := pb.MarshalBinary()
+			require.NoError(t, err)
+			var decoded ConfigEntry
+			require.NoError(t, decoded.UnmarshalBinary(raw))
+
+			// Empty slices come back as non-nil, which is fine.
+			if diff := cmp.Diff(entry, ConfigEntryToStructs(&decoded), cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("config entry did not round-trip (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func durationPointer(d time.Duration) *time.Duration {
+	return &d
+}
+
+func uint32Pointer(i uint32) *uint32 {
+	return &i
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
@@ -4,6 +4,88 @@ package pbservice
 
 import "github.com/hashicorp/consul/agent/structs"
 
+func AccessLogFilterToStructs(s *AccessLogFilter, t *structs.AccessLogFilter) {
+	if s == nil {
+		return
+	}
+	if s.StatusCodeRange != nil {
+		var x structs.AccessLogStatusCodeRange
+		AccessLogStatusCodeRangeToStructs(s.StatusCodeRange, &x)
+		t.StatusCodeRange = &x
+	}
+	if s.GRPCStatusCode != nil {
+		var x structs.AccessLogGRPCStatusFilter
+		AccessLogGRPCStatusFilterToStructs(s.GRPCStatusCode, &x)
+		t.GRPCStatusCode = &x
+	}
+	if s.RuntimeFilter != nil {
+		var x structs.AccessLogRuntimeFilter
+		AccessLogRuntimeFilterToStructs(s.RuntimeFilter, &x)
+		t.RuntimeFilter = &x
+	}
+}
+func AccessLogFilterFromStructs(t *structs.AccessLogFilter, s *AccessLogFilter) {
+	if s == nil {
+		return
+	}
+	if t.StatusCodeRange != nil {
+		var x AccessLogStatusCodeRange
+		AccessLogStatusCodeRangeFromStructs(t.StatusCodeRange, &x)
+		s.StatusCodeRange = &x
+	}
+	if t.GRPCStatusCode != nil {
+		var x AccessLogGRPCStatusFilter
+		AccessLogGRPCStatusFilterFromStructs(t.GRPCStatusCode, &x)
+		s.GRPCStatusCode = &x
+	}
+	if t.RuntimeFilter != nil {
+		var x AccessLogRuntimeFilter
+		AccessLogRuntimeFilterFromStructs(t.RuntimeFilter, &x)
+		s.RuntimeFilter = &x
+	}
+}
+func AccessLogGRPCStatusFilterToStructs(s *AccessLogGRPCStatusFilter, t *structs.AccessLogGRPCStatusFilter) {
+	if s == nil {
+		return
+	}
+	t.Statuses = s.Statuses
+	t.Exclude = s.Exclude
+}
+func AccessLogGRPCStatusFilterFromStructs(t *structs.AccessLogGRPCStatusFilter, s *AccessLogGRPCStatusFilter) {
+	if s == nil {
+		return
+	}
+	s.Statuses = t.Statuses
+	s.Exclude = t.Exclude
+}
+func AccessLogRuntimeFilterToStructs(s *AccessLogRuntimeFilter, t *structs.AccessLogRuntimeFilter) {
+	if s == nil {
+		return
+	}
+	t.RuntimeKey = s.RuntimeKey
+	t.PercentSampled = int(s.PercentSampled)
+}
+func AccessLogRuntimeFilterFromStructs(t *structs.AccessLogRuntimeFilter, s *AccessLogRuntimeFilter) {
+	if s == nil {
+		return
+	}
+	s.RuntimeKey = t.RuntimeKey
+	s.PercentSampled = int32(t.PercentSampled)
+}
+func AccessLogStatusCodeRangeToStructs(s *AccessLogStatusCodeRange, t *structs.AccessLogStatusCodeRange) {
+	if s == nil {
+		return
+	}
+	t.Min = int(s.Min)
+	t.Max = int(s.Max)
+}
+func AccessLogStatusCodeRangeFromStructs(t *structs.AccessLogStatusCodeRange, s *AccessLogStatusCodeRange) {
+	if s == nil {
+		return
+	}
+	s.Min = int32(t.Min)
+	s.Max = int32(t.Max)
+}
 func AccessLogsConfigToStructs(s *AccessLogsConfig, t *structs.AccessLogsConfig) {
 	if s == nil {
 		return
@@ -14,6 +96,14 @@ func AccessLogsConfigToStructs(s *AccessLogsConfig, t *structs.AccessLogsConfig)
 	t.Path = s.Path
 	t.JSONFormat = s.JSONFormat
 	t.TextFormat = s.TextFormat
+	{
+		t.AccessLogFilters = make([]structs.AccessLogFilter, len(s.AccessLogFilters))
+		for i := range s.AccessLogFilters {
+			if s.AccessLogFilters[i] != nil {
+				AccessLogFilterToStructs(s.AccessLogFilters[i], &t.AccessLogFilters[i])
+			}
+		}
+	}
 }
 func AccessLogsConfigFromStructs(t *structs.AccessLogsConfig, s *AccessLogsConfig) {
 	if s == nil {
@@ -25,6 +115,16 @@ func AccessLogsConfigFromStructs(t *structs.AccessLogsConfig, s *AccessLogsConfi
 	s.Path = t.Path
 	s.JSONFormat = t.JSONFormat
 	s.TextFormat = t.TextFormat
+	{
+		s.AccessLogFilters = make([]*AccessLogFilter, len(t.AccessLogFilters))
+		for i := range t.AccessLogFilters {
+			{
+				var x AccessLogFilter
+				AccessLogFilterFromStructs(&t.AccessLogFilters[i], &x)
+				s.AccessLogFilters[i] = &x
+			}
+		}
+	}
 }
 func ConnectProxyConfigToStructs(s *ConnectProxyConfig, t *structs.ConnectProxyConfig) {
 	if s == nil {